@@ -1,77 +1,217 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"github.com/dwiangraeni/dealls/handler"
 	"github.com/dwiangraeni/dealls/infra"
 	"github.com/dwiangraeni/dealls/manager"
+	"github.com/dwiangraeni/dealls/metrics"
 	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
 	"github.com/go-chi/chi"
+	chimiddleware "github.com/go-chi/chi/middleware"
+	"github.com/spf13/viper"
 	"log"
+	"log/slog"
+	"net"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 )
 
 // Server API server interface
 type Server interface {
-	Run()
+	// Run serve until SIGINT/SIGTERM, return an error when the server cannot start or stops unexpectedly
+	Run() error
 }
 
 type server struct {
 	router         chi.Router
 	infra          infra.Infra
 	serviceManager manager.ServiceManager
+	buildInfo      model.BuildInfo
 }
 
 // NewServer construct new API server
-func NewServer(infra infra.Infra) Server {
+func NewServer(infra infra.Infra, buildInfo model.BuildInfo) Server {
 	return &server{
 		router:         chi.NewRouter(),
 		infra:          infra,
 		serviceManager: manager.NewServiceManager(infra),
+		buildInfo:      buildInfo,
 	}
 }
 
-func (c *server) Run() {
+func (c *server) Run() error {
 	c.endpoint()
-	c.run()
+	return c.run()
+}
+
+// NewRouter every route of the API without starting the server, used to check the routes against the OpenAPI document
+func NewRouter(infra infra.Infra, serviceManager manager.ServiceManager, buildInfo model.BuildInfo) chi.Router {
+	c := &server{
+		router:         chi.NewRouter(),
+		infra:          infra,
+		serviceManager: serviceManager,
+		buildInfo:      buildInfo,
+	}
+	c.endpoint()
+
+	return c.router
 }
 
 func (c *server) endpoint() {
 	authHandler := handler.NewAuthHandler(c.serviceManager.AuthService())
 	accountHandler := handler.NewAccountHandler(c.serviceManager.AccountService())
-	token := middleware.NewTokenValidator(c.serviceManager.AccountManager())
+	token := middleware.NewTokenValidator(c.serviceManager.AccountManager(), c.serviceManager.AccountService())
 	userSwipeLogHandler := handler.NewUserSwipeLogHandler(c.serviceManager.UserSwipeLogService())
 	premiumPackageHandler := handler.NewPremiumPackageHandler(c.serviceManager.PremiumPackageService())
+	jwksHandler := handler.NewJWKSHandler(c.serviceManager.KeySet())
+	verificationHandler := handler.NewVerificationHandler(c.serviceManager.VerificationService())
+	identityVerificationHandler := handler.NewIdentityVerificationHandler(c.serviceManager.IdentityVerificationService(),
+		c.infra.Config().GetInt64("identity_verification.max_selfie_size"))
+	moderationHandler := handler.NewModerationHandler(c.serviceManager.ModerationService())
+	accountLifecycleHandler := handler.NewAccountLifecycleHandler(c.serviceManager.AccountLifecycleService())
+	accountExportHandler := handler.NewAccountExportHandler(c.serviceManager.AccountExportService())
+	healthHandler := handler.NewHealthHandler(c.serviceManager.HealthService(), c.buildInfo)
+	docsHandler := handler.NewDocsHandler()
+	limiter := c.serviceManager.RateLimiter()
 
-	c.router.Route("/dealls", func(r chi.Router) {
+	c.router.Use(middleware.RequestID)
+
+	// client ip is read from X-Forwarded-For / X-Real-IP only behind a trusted proxy
+	if c.infra.Config().GetBool("rate_limit.trust_forwarded_for") {
+		c.router.Use(chimiddleware.RealIP)
+	}
+	c.router.Use(metrics.NewHTTPMiddleware(c.serviceManager.MetricsRegistry()))
+	c.router.Use(middleware.Recoverer)
+	c.router.Use(middleware.SecurityHeaders(time.Duration(c.infra.Config().GetInt("api.hsts_max_age")) * time.Second))
+	c.router.Use(middleware.CORS(c.corsPolicy()))
+	c.router.Use(middleware.LimitBody(c.infra.Config().GetInt64("api.max_body_size")))
+
+	c.router.Get("/healthz", healthHandler.Healthz)
+	c.router.Get("/readyz", healthHandler.Readyz)
+	c.router.Get("/version", healthHandler.Version)
+	c.router.Method(http.MethodGet, "/metrics", metrics.Handler(c.serviceManager.MetricsRegistry()))
+	c.router.Get("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
+	// routes of API v1. A breaking change to a request or response ships as a new version mounted next to it, e.g.
+	// "v2" with its own routes func reusing the unchanged v1 handlers, so released app builds keep working.
+	routesV1 := func(r chi.Router) {
 		// auth
 		r.Route("/auth", func(an chi.Router) {
-			an.With().Post("/login", authHandler.HandlerLogin)
-			an.With().Post("/register", authHandler.HandlerRegister)
+			an.With(limiter.Limit("login")).Post("/login", authHandler.HandlerLogin)
+			an.With(limiter.Limit("register")).Post("/register", authHandler.HandlerRegister)
+			an.With(limiter.Limit("forgot_password")).Post("/forgot-password", authHandler.HandlerForgotPassword)
+			an.With(limiter.Limit("reset_password")).Post("/reset-password", authHandler.HandlerResetPassword)
 		})
 
 		// account
 		r.Route("/account", func(an chi.Router) {
-			an.With(token.RequireAccountToken()).Get("/list", accountHandler.GetListAccountNewMatchPagination)
+			an.With(limiter.Limit("account_list"), token.RequireAccountToken()).Get("/list", accountHandler.GetListAccountNewMatchPagination)
+			an.With(limiter.Limit("account_delete"), token.RequireAccountToken()).Delete("/", accountLifecycleHandler.DeleteAccount)
+			an.With(token.RequireAccountToken()).Put("/discovery", accountLifecycleHandler.UpdateDiscovery)
+			an.With(token.RequireAccountToken()).Put("/privacy", accountHandler.UpdateProfilePrivacy)
+			an.With(token.RequireAccountToken()).Get("/visitors", accountHandler.GetListProfileVisitorPagination)
+			an.With(token.RequireAccountToken()).Post("/deactivate", accountLifecycleHandler.DeactivateAccount)
+			an.With(limiter.Limit("account_export"), token.RequireAccountToken()).Post("/export", accountExportHandler.RequestAccountExport)
+			an.With(token.RequireAccountToken()).Get("/export", accountExportHandler.GetAccountExportStatus)
+			an.With(token.RequireAccountToken()).Get("/export/{export_uid}/download", accountExportHandler.DownloadAccountExport)
+			an.With(limiter.Limit("account_contact"), token.RequireAccountToken()).Put("/contact", verificationHandler.UpdateContact)
+			an.With(limiter.Limit("verification_send"), token.RequireAccountToken()).Post("/verification/send", verificationHandler.SendVerificationCode)
+			an.With(limiter.Limit("verification_verify"), token.RequireAccountToken()).Post("/verification/verify", verificationHandler.VerifyContact)
+			an.With(limiter.Limit("identity_verification_submit"), token.RequireAccountToken()).Post("/identity-verification", identityVerificationHandler.SubmitIdentityVerification)
+			an.With(limiter.Limit("identity_verification_status"), token.RequireAccountToken()).Get("/identity-verification", identityVerificationHandler.GetIdentityVerificationStatus)
+			an.With(limiter.Limit("account_block"), token.RequireAccountToken()).Post("/{account_mask_id}/block", moderationHandler.BlockAccount)
+			an.With(limiter.Limit("account_block"), token.RequireAccountToken()).Delete("/{account_mask_id}/block", moderationHandler.UnblockAccount)
+			an.With(limiter.Limit("account_report"), token.RequireAccountToken()).Post("/{account_mask_id}/report", moderationHandler.ReportAccount)
+			an.With(limiter.Limit("account_profile"), token.RequireAccountToken()).Get("/{account_mask_id}", accountHandler.GetAccountProfile)
 
 		})
 
 		// swipe
 		r.Route("/swipe", func(an chi.Router) {
-			an.With(token.RequireAccountToken()).Post("/interaction", userSwipeLogHandler.ProcessUserSwipe)
+			an.With(limiter.Limit("swipe"), token.RequireAccountToken()).Post("/interaction", userSwipeLogHandler.ProcessUserSwipe)
 		})
 
 		// premium package
 		r.Route("/premium-package", func(an chi.Router) {
-			an.With(token.RequireAccountToken()).Get("/list", premiumPackageHandler.GetListPremiumPackagePagination)
-			an.With(token.RequireAccountToken()).Post("/checkout", premiumPackageHandler.PremiumPackageCheckout)
+			an.With(limiter.Limit("premium_package_list"), token.RequireAccountToken()).Get("/list", premiumPackageHandler.GetListPremiumPackagePagination)
+			an.With(limiter.Limit("premium_package_checkout"), token.RequireAccountToken()).Post("/checkout", premiumPackageHandler.PremiumPackageCheckout)
+			an.With(token.RequireAccountToken()).Get("/effects", premiumPackageHandler.GetListAccountEffect)
+			an.With(limiter.Limit("premium_package_checkout"), token.RequireAccountToken()).Post("/effects/boost", premiumPackageHandler.ActivateBoost)
+			an.With(token.RequireAccountToken()).Get("/wallet", premiumPackageHandler.GetWallet)
+		})
+
+		// admin
+		r.Route("/admin", func(an chi.Router) {
+			an.Use(token.RequireAccountToken(), token.RequireAccountRole(model.AccountRoleAdmin))
+			an.Get("/identity-verification", identityVerificationHandler.GetListIdentityVerificationPagination)
+			an.Get("/identity-verification/{verification_uid}/selfie", identityVerificationHandler.GetSelfie)
+			an.Post("/identity-verification/{verification_uid}/approve", identityVerificationHandler.ApproveIdentityVerification)
+			an.Post("/identity-verification/{verification_uid}/reject", identityVerificationHandler.RejectIdentityVerification)
+			an.Get("/reports", moderationHandler.GetListAccountReportPagination)
+			an.Post("/reports/{report_uid}/action", moderationHandler.ModerateAccountReport)
 		})
+	}
+
+	versionConfig := c.infra.Config().Sub("api_version")
+	if versionConfig == nil {
+		versionConfig = viper.New()
+	}
+
+	c.router.Route("/dealls", func(r chi.Router) {
+		// probes and /metrics are left out, they would start a trace every few seconds
+		r.Use(tracing.NewHTTPMiddleware())
+		r.Use(middleware.RequireClientVersion(versionConfig.GetString("min_client_version")))
+
+		// api documentation, every route below must be described in docs/openapi.json
+		r.Get("/openapi.json", docsHandler.OpenAPI)
+		r.Get("/docs", docsHandler.UI)
+
+		r.Route("/v1", routesV1)
+
+		// unversioned routes used by the app builds released before v1, served by v1 until their sunset
+		r.With(middleware.Deprecated(middleware.Deprecation{
+			DeprecatedAt: configDate(versionConfig, "legacy_deprecated_at"),
+			SunsetAt:     configDate(versionConfig, "legacy_sunset_at"),
+			Successor:    "/dealls/v1",
+		})).Route("/", routesV1)
 	})
+}
 
+func (c *server) corsPolicy() middleware.CORSPolicy {
+	config := c.infra.Config()
+	return middleware.CORSPolicy{
+		AllowedOrigins:   config.GetStringSlice("cors.allowed_origins"),
+		AllowedMethods:   config.GetStringSlice("cors.allowed_methods"),
+		AllowedHeaders:   config.GetStringSlice("cors.allowed_headers"),
+		ExposedHeaders:   config.GetStringSlice("cors.exposed_headers"),
+		AllowCredentials: config.GetBool("cors.allow_credentials"),
+		MaxAge:           time.Duration(config.GetInt("cors.max_age")) * time.Second,
+	}
 }
 
-func (c *server) run() {
+// configDate YYYY-MM-DD date of key in UTC, zero when it is not set
+func configDate(config *viper.Viper, key string) time.Time {
+	value := config.GetString(key)
+	if value == "" {
+		return time.Time{}
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		log.Fatalf("invalid %s %q, want YYYY-MM-DD: %v", key, value, err)
+	}
+
+	return date
+}
+
+func (c *server) run() error {
 	apiConfig := c.infra.Config().Sub("api")
 	addr := fmt.Sprintf("%s:%d", apiConfig.GetString("host"), apiConfig.GetInt("port"))
 
@@ -83,9 +223,61 @@ func (c *server) run() {
 		IdleTimeout:  time.Duration(apiConfig.GetInt("idle_timeout")) * time.Second,
 	}
 
-	if err := svr.ListenAndServe(); err != nil {
-		fmt.Errorf("error Server start error with err : %v", err.Error())
+	// listen before reporting the server as started, so a busy port fails the command right away
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// builds the queued personal data exports in the background
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		c.serviceManager.AccountExportService().Run(ctx)
+	}()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- svr.Serve(listener)
+	}()
+	slog.Info("Server started", "addr", addr)
+
+	select {
+	case err = <-serveErr:
+		stop()
+		<-workerDone
+		return fmt.Errorf("server stopped unexpectedly: %w", err)
+	case <-ctx.Done():
+	}
+
+	// fail readiness first, the delay gives the load balancer time to stop routing here before the listener closes
+	c.serviceManager.HealthService().Drain()
+	if shutdownDelay := time.Duration(apiConfig.GetInt("shutdown_delay")) * time.Second; shutdownDelay > 0 {
+		slog.Info("Readiness failing, waiting before shutting down", "delay", shutdownDelay.String())
+		time.Sleep(shutdownDelay)
+	}
+
+	gracefulTimeout := time.Duration(apiConfig.GetInt("graceful_timeout")) * time.Second
+	slog.Info("Shutting down, waiting for in-flight requests", "timeout", gracefulTimeout.String())
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), gracefulTimeout)
+	defer cancel()
+
+	// stop accepting connections and drain the open ones, idle keep-alive connections are closed right away
+	if err = svr.Shutdown(shutdownCtx); err != nil {
+		slog.Warn("graceful shutdown timed out, closing remaining connections", "error", err)
+		_ = svr.Close()
+	}
+
+	select {
+	case <-workerDone:
+	case <-shutdownCtx.Done():
+		slog.Warn("account export worker did not stop in time, its job is picked up again after the processing timeout")
 	}
 
-	log.Printf("Server started at %s", addr)
+	slog.Info("Server stopped")
+	return nil
 }
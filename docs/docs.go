@@ -0,0 +1,14 @@
+package docs
+
+import _ "embed"
+
+// OpenAPI specification of the /dealls routes served at /dealls/openapi.json. Keep it in sync with the routes of
+// api.NewRouter, Test_OpenAPICoversRoutes fails when a route is missing.
+//
+//go:embed openapi.json
+var OpenAPI []byte
+
+// UI page served at /dealls/docs, it renders openapi.json without loading any external asset
+//
+//go:embed index.html
+var UI []byte
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/go-chi/chi"
+	"io"
+	"net/http"
+)
+
+type accountExportHandler struct {
+	accountExportService interfaces.IAccountExportService
+}
+
+func NewAccountExportHandler(accountExportService interfaces.IAccountExportService) *accountExportHandler {
+	return &accountExportHandler{accountExportService: accountExportService}
+}
+
+func (a *accountExportHandler) RequestAccountExport(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	data, err := a.accountExportService.RequestAccountExport(r.Context(), claim.AccountMaskID)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (a *accountExportHandler) GetAccountExportStatus(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	data, err := a.accountExportService.GetAccountExportStatus(r.Context(), claim.AccountMaskID)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	if data.Status == model.AccountExportDone {
+		data.DownloadURL = fmt.Sprintf("/dealls/v1/account/export/%s/download", data.ExportUID)
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (a *accountExportHandler) DownloadAccountExport(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	exportUID := chi.URLParam(r, "export_uid")
+	archive, err := a.accountExportService.OpenAccountExport(r.Context(), claim.AccountMaskID, exportUID)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	defer archive.Close()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="dealls-export-%s.zip"`, exportUID))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, archive)
+}
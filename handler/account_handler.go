@@ -1,12 +1,12 @@
 package handler
 
 import (
-	"errors"
 	"github.com/dwiangraeni/dealls/interfaces"
 	"github.com/dwiangraeni/dealls/middleware"
 	"github.com/dwiangraeni/dealls/model"
 	"github.com/dwiangraeni/dealls/resources/response"
 	"github.com/dwiangraeni/dealls/utils"
+	"github.com/go-chi/chi"
 	"net/http"
 	"strconv"
 )
@@ -32,36 +32,99 @@ func (a *accountHandler) GetListAccountNewMatchPagination(w http.ResponseWriter,
 		req.Limit = utils.DefaultLimit
 	}
 
-	tok := r.Context().Value("token")
-	if tok == nil {
-		response.HandleError(w, http.StatusUnauthorized, "unauthorized")
-		return
-	}
-
-	claim, ok := tok.(*middleware.AccessTokenClaim)
+	claim, ok := middleware.ClaimFromContext(r.Context())
 	if !ok {
-		response.HandleError(w, http.StatusUnauthorized, "unauthorized")
+		response.HandleAppError(w, utils.ErrUnauthorized)
 		return
 	}
 	req.AccountMaskID = claim.AccountMaskID
 
 	data, err := a.accountService.GetListAccountNewMatchPagination(r.Context(), req)
 	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
 
-		if !errors.Is(err, utils.ErrInternal) {
-			response.HandleError(w, http.StatusBadRequest, err.Error())
-			return
-		}
+	response.HandleSuccess(w, data.Data, map[string]interface{}{
+		"load_more":   data.LoadMore,
+		"next_cursor": data.NextCursor,
+		"prev_cursor": data.PrevCursor,
+		"limit":       data.Limit,
+		"q":           req.Keywords,
+	})
+}
 
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+func (a *accountHandler) GetListProfileVisitorPagination(w http.ResponseWriter, r *http.Request) {
+	var req model.ListProfileVisitorRequest
+	req.Limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+	req.Cursor = r.URL.Query().Get("cursor")
+	req.Direction = r.URL.Query().Get("direction")
+
+	if req.Limit == 0 || req.Limit > utils.DefaultMaxLimit {
+		req.Limit = utils.DefaultLimit
+	}
+
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+
+	data, err := a.accountService.GetListProfileVisitorPagination(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
 		return
 	}
 
 	response.HandleSuccess(w, data.Data, map[string]interface{}{
+		"unlocked":    data.Unlocked,
+		"total":       data.Total,
 		"load_more":   data.LoadMore,
 		"next_cursor": data.NextCursor,
 		"prev_cursor": data.PrevCursor,
 		"limit":       data.Limit,
-		"q":           req.Keywords,
 	})
 }
+
+func (a *accountHandler) GetAccountProfile(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	data, err := a.accountService.GetAccountProfile(r.Context(), model.GetAccountProfileRequest{
+		AccountMaskID:       claim.AccountMaskID,
+		TargetAccountMaskID: chi.URLParam(r, "account_mask_id"),
+	})
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (a *accountHandler) UpdateProfilePrivacy(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.UpdateProfilePrivacyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+
+	data, err := a.accountService.UpdateProfilePrivacy(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
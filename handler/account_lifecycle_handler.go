@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"github.com/dwiangraeni/dealls/utils"
+	"net/http"
+)
+
+type accountLifecycleHandler struct {
+	accountLifecycleService interfaces.IAccountLifecycleService
+}
+
+func NewAccountLifecycleHandler(accountLifecycleService interfaces.IAccountLifecycleService) *accountLifecycleHandler {
+	return &accountLifecycleHandler{accountLifecycleService: accountLifecycleService}
+}
+
+func (a *accountLifecycleHandler) UpdateDiscovery(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.UpdateDiscoveryRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+
+	data, err := a.accountLifecycleService.UpdateDiscovery(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (a *accountLifecycleHandler) DeactivateAccount(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	data, err := a.accountLifecycleService.DeactivateAccount(r.Context(), claim.AccountMaskID)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (a *accountLifecycleHandler) DeleteAccount(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.DeleteAccountRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+
+	if err := a.accountLifecycleService.DeleteAccount(r.Context(), req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, nil)
+}
@@ -1,12 +1,10 @@
 package handler
 
 import (
-	"encoding/json"
-	"errors"
 	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/middleware"
 	"github.com/dwiangraeni/dealls/resources/request"
 	"github.com/dwiangraeni/dealls/resources/response"
-	"github.com/dwiangraeni/dealls/utils"
 	"net/http"
 )
 
@@ -26,15 +24,15 @@ func NewAuthHandler(
 func (c *authHandler) HandlerLogin(w http.ResponseWriter, r *http.Request) {
 	var req request.LoginRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
 		return
 	}
+	req.IPAddress = middleware.ClientIP(r)
 
 	data, err := c.authService.Login(r.Context(), req)
 	if err != nil {
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+		response.HandleAppError(w, err)
 		return
 	}
 
@@ -45,32 +43,50 @@ func (c *authHandler) HandlerLogin(w http.ResponseWriter, r *http.Request) {
 func (c *authHandler) HandlerRegister(w http.ResponseWriter, r *http.Request) {
 	var req request.RegisterRequest
 
-	err := json.NewDecoder(r.Body).Decode(&req)
-	if err != nil {
-		if !errors.Is(err, utils.ErrInternal) {
-			response.HandleError(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
 		return
 	}
 
 	data, err := c.authService.Register(r.Context(), req)
 	if err != nil {
-		if !errors.Is(err, utils.ErrInternal) {
-			response.HandleError(w, http.StatusBadRequest, err.Error())
-			return
-		}
+		response.HandleAppError(w, err)
+		return
+	}
 
-		if errors.Is(err, utils.ErrDuplicateData) {
-			response.HandleError(w, http.StatusBadRequest, err.Error())
-			return
-		}
+	response.HandleSuccess(w, data)
+}
+
+// HandlerForgotPassword is a function to handle forgot password request
+func (c *authHandler) HandlerForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req request.ForgotPasswordRequest
 
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
 		return
 	}
 
-	response.HandleSuccess(w, data)
+	if err := c.authService.ForgotPassword(r.Context(), req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, nil)
+}
+
+// HandlerResetPassword is a function to handle reset password request
+func (c *authHandler) HandlerResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req request.ResetPasswordRequest
+
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	if err := c.authService.ResetPassword(r.Context(), req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, nil)
 }
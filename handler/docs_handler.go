@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/dwiangraeni/dealls/docs"
+	"net/http"
+)
+
+type docsHandler struct {
+	uiContentSecurityPolicy string
+}
+
+func NewDocsHandler() *docsHandler {
+	// the page only runs its own inline script and style and fetches openapi.json next to it
+	return &docsHandler{
+		uiContentSecurityPolicy: "default-src 'none'; connect-src 'self'; frame-ancestors 'none'" +
+			"; script-src " + inlineHash(docs.UI, "<script>", "</script>") +
+			"; style-src " + inlineHash(docs.UI, "<style>", "</style>"),
+	}
+}
+
+// OpenAPI serve the OpenAPI 3 specification of the API
+func (d *docsHandler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(docs.OpenAPI)
+}
+
+// UI serve the page rendering the specification
+func (d *docsHandler) UI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Set("Content-Security-Policy", d.uiContentSecurityPolicy)
+	w.Write(docs.UI)
+}
+
+// inlineHash CSP source allowing the inline element between open and close, 'none' when the page has none
+func inlineHash(page []byte, open, close string) string {
+	start := bytes.Index(page, []byte(open))
+	if start < 0 {
+		return "'none'"
+	}
+	content := page[start+len(open):]
+
+	end := bytes.Index(content, []byte(close))
+	if end < 0 {
+		return "'none'"
+	}
+
+	sum := sha256.Sum256(content[:end])
+	return "'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'"
+}
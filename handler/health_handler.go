@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"net/http"
+)
+
+type healthHandler struct {
+	healthService interfaces.IHealthService
+	buildInfo     model.BuildInfo
+}
+
+func NewHealthHandler(healthService interfaces.IHealthService, buildInfo model.BuildInfo) *healthHandler {
+	return &healthHandler{
+		healthService: healthService,
+		buildInfo:     buildInfo,
+	}
+}
+
+// Healthz liveness probe, the process is alive as long as it can answer
+func (h *healthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeProbe(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Readyz readiness probe, 503 while the database is unreachable, a migration is pending or the server shuts down
+func (h *healthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	readiness := h.healthService.CheckReadiness(r.Context())
+
+	status := http.StatusOK
+	if !readiness.Ready {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeProbe(w, status, readiness)
+}
+
+func (h *healthHandler) Version(w http.ResponseWriter, r *http.Request) {
+	writeProbe(w, http.StatusOK, h.buildInfo)
+}
+
+// writeProbe probes are read by orchestrators and scripts, so the body is the plain result without the response wrapper
+func writeProbe(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
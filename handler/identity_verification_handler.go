@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"errors"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/go-chi/chi"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// multipartOverhead room for the multipart boundaries and headers around the selfie
+const multipartOverhead = 1 << 20
+
+type identityVerificationHandler struct {
+	identityVerificationService interfaces.IIdentityVerificationService
+	maxSelfieSize               int64
+}
+
+func NewIdentityVerificationHandler(identityVerificationService interfaces.IIdentityVerificationService,
+	maxSelfieSize int64) *identityVerificationHandler {
+	return &identityVerificationHandler{
+		identityVerificationService: identityVerificationService,
+		maxSelfieSize:               maxSelfieSize,
+	}
+}
+
+func (i *identityVerificationHandler) SubmitIdentityVerification(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	// the selfie is larger than the default body limit
+	middleware.SetBodyLimit(w, r, i.maxSelfieSize+multipartOverhead)
+	file, header, err := r.FormFile("selfie")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			response.HandleAppError(w, utils.ErrSelfieTooLarge)
+			return
+		}
+
+		response.HandleAppError(w, utils.ErrInvalidParameter.WithDetails(map[string]string{"selfie": "selfie is required"}))
+		return
+	}
+	defer file.Close()
+
+	data, err := i.identityVerificationService.SubmitIdentityVerification(r.Context(), model.SubmitIdentityVerificationRequest{
+		AccountMaskID: claim.AccountMaskID,
+		Size:          header.Size,
+		Selfie:        file,
+	})
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (i *identityVerificationHandler) GetIdentityVerificationStatus(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	data, err := i.identityVerificationService.GetIdentityVerificationStatus(r.Context(), claim.AccountMaskID)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (i *identityVerificationHandler) GetListIdentityVerificationPagination(w http.ResponseWriter, r *http.Request) {
+	var req model.ListIdentityVerificationRequest
+	req.Limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+	req.Cursor = r.URL.Query().Get("cursor")
+	req.Direction = r.URL.Query().Get("direction")
+	req.Status = r.URL.Query().Get("status")
+
+	if req.Limit == 0 || req.Limit > utils.DefaultMaxLimit {
+		req.Limit = utils.DefaultLimit
+	}
+
+	data, err := i.identityVerificationService.GetListIdentityVerificationPagination(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data.Data, map[string]interface{}{
+		"load_more":   data.LoadMore,
+		"next_cursor": data.NextCursor,
+		"prev_cursor": data.PrevCursor,
+		"limit":       data.Limit,
+		"status":      req.Status,
+	})
+}
+
+func (i *identityVerificationHandler) ApproveIdentityVerification(w http.ResponseWriter, r *http.Request) {
+	i.review(w, r, true)
+}
+
+func (i *identityVerificationHandler) RejectIdentityVerification(w http.ResponseWriter, r *http.Request) {
+	i.review(w, r, false)
+}
+
+func (i *identityVerificationHandler) review(w http.ResponseWriter, r *http.Request, approve bool) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.ReviewIdentityVerificationRequest
+	// the body is optional, an approval carries no reason
+	if err := decodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.VerificationUID = chi.URLParam(r, "verification_uid")
+	req.ReviewedBy = claim.Username
+	req.Approve = approve
+
+	data, err := i.identityVerificationService.ReviewIdentityVerification(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (i *identityVerificationHandler) GetSelfie(w http.ResponseWriter, r *http.Request) {
+	selfie, contentType, err := i.identityVerificationService.OpenSelfie(r.Context(), chi.URLParam(r, "verification_uid"))
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	defer selfie.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.Copy(w, selfie)
+}
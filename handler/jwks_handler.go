@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"encoding/json"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"net/http"
+)
+
+type jwksHandler struct {
+	keySet middleware.KeySet
+}
+
+func NewJWKSHandler(keySet middleware.KeySet) *jwksHandler {
+	return &jwksHandler{keySet: keySet}
+}
+
+// GetJWKS serve the public signing keys so other services can verify our tokens
+func (j *jwksHandler) GetJWKS(w http.ResponseWriter, r *http.Request) {
+	jsonData, err := json.Marshal(j.keySet.JWKS())
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Write(jsonData)
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/go-chi/chi"
+	"net/http"
+	"strconv"
+)
+
+type moderationHandler struct {
+	moderationService interfaces.IModerationService
+}
+
+func NewModerationHandler(moderationService interfaces.IModerationService) *moderationHandler {
+	return &moderationHandler{moderationService: moderationService}
+}
+
+func (m *moderationHandler) BlockAccount(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	if err := m.moderationService.BlockAccount(r.Context(), model.BlockAccountRequest{
+		AccountMaskID:       claim.AccountMaskID,
+		TargetAccountMaskID: chi.URLParam(r, "account_mask_id"),
+	}); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, nil)
+}
+
+func (m *moderationHandler) UnblockAccount(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	if err := m.moderationService.UnblockAccount(r.Context(), model.BlockAccountRequest{
+		AccountMaskID:       claim.AccountMaskID,
+		TargetAccountMaskID: chi.URLParam(r, "account_mask_id"),
+	}); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, nil)
+}
+
+func (m *moderationHandler) ReportAccount(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.ReportAccountRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+	req.TargetAccountMaskID = chi.URLParam(r, "account_mask_id")
+
+	data, err := m.moderationService.ReportAccount(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (m *moderationHandler) GetListAccountReportPagination(w http.ResponseWriter, r *http.Request) {
+	var req model.ListAccountReportRequest
+	req.Limit, _ = strconv.Atoi(r.URL.Query().Get("limit"))
+	req.Cursor = r.URL.Query().Get("cursor")
+	req.Direction = r.URL.Query().Get("direction")
+	req.Status = r.URL.Query().Get("status")
+
+	if req.Limit == 0 || req.Limit > utils.DefaultMaxLimit {
+		req.Limit = utils.DefaultLimit
+	}
+
+	data, err := m.moderationService.GetListAccountReportPagination(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data.Data, map[string]interface{}{
+		"load_more":   data.LoadMore,
+		"next_cursor": data.NextCursor,
+		"prev_cursor": data.PrevCursor,
+		"limit":       data.Limit,
+		"status":      req.Status,
+	})
+}
+
+func (m *moderationHandler) ModerateAccountReport(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.ModerateAccountReportRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.ReportUID = chi.URLParam(r, "report_uid")
+	req.ModeratedBy = claim.Username
+
+	data, err := m.moderationService.ModerateAccountReport(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
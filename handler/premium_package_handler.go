@@ -1,8 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
-	"errors"
 	"github.com/dwiangraeni/dealls/interfaces"
 	"github.com/dwiangraeni/dealls/middleware"
 	"github.com/dwiangraeni/dealls/model"
@@ -30,27 +28,16 @@ func (p *premiumPackageHandler) GetListPremiumPackagePagination(w http.ResponseW
 		req.Limit = utils.DefaultLimit
 	}
 
-	tok := r.Context().Value("token")
-	if tok == nil {
-		response.HandleError(w, http.StatusUnauthorized, "unauthorized")
-		return
-	}
-
-	claim, ok := tok.(*middleware.AccessTokenClaim)
+	claim, ok := middleware.ClaimFromContext(r.Context())
 	if !ok {
-		response.HandleError(w, http.StatusUnauthorized, "unauthorized")
+		response.HandleAppError(w, utils.ErrUnauthorized)
 		return
 	}
 	req.AccountMaskID = claim.AccountMaskID
 
 	data, err := p.premiumPackageService.GetListPremiumPackagePagination(r.Context(), req)
 	if err != nil {
-		if !errors.Is(err, utils.ErrInternal) {
-			response.HandleError(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+		response.HandleAppError(w, err)
 		return
 	}
 
@@ -65,35 +52,71 @@ func (p *premiumPackageHandler) GetListPremiumPackagePagination(w http.ResponseW
 
 func (p *premiumPackageHandler) PremiumPackageCheckout(w http.ResponseWriter, r *http.Request) {
 	var req model.PremiumPackageCheckoutRequest
-	err := json.NewDecoder(r.Body).Decode(&req)
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+
+	err := p.premiumPackageService.PremiumPackageCheckout(r.Context(), req)
 	if err != nil {
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+		response.HandleAppError(w, err)
 		return
 	}
 
-	tok := r.Context().Value("token")
-	if tok == nil {
-		response.HandleError(w, http.StatusUnauthorized, "unauthorized")
+	response.HandleSuccess(w, nil)
+}
+
+func (p *premiumPackageHandler) GetListAccountEffect(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
 		return
 	}
 
-	claim, ok := tok.(*middleware.AccessTokenClaim)
+	data, err := p.premiumPackageService.GetListAccountEffect(r.Context(), claim.AccountMaskID)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (p *premiumPackageHandler) GetWallet(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
 	if !ok {
-		response.HandleError(w, http.StatusUnauthorized, "unauthorized")
+		response.HandleAppError(w, utils.ErrUnauthorized)
 		return
 	}
-	req.AccountMaskID = claim.AccountMaskID
 
-	err = p.premiumPackageService.PremiumPackageCheckout(r.Context(), req)
+	data, err := p.premiumPackageService.GetWallet(r.Context(), claim.AccountMaskID)
 	if err != nil {
-		if !errors.Is(err, utils.ErrInternal) {
-			response.HandleError(w, http.StatusBadRequest, err.Error())
-			return
-		}
+		response.HandleAppError(w, err)
+		return
+	}
 
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+	response.HandleSuccess(w, data)
+}
+
+func (p *premiumPackageHandler) ActivateBoost(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
 		return
 	}
 
-	response.HandleSuccess(w, nil)
+	data, err := p.premiumPackageService.ActivateBoost(r.Context(), claim.AccountMaskID)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
 }
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/dwiangraeni/dealls/utils"
+	"io"
+	"net/http"
+)
+
+// decodeJSON decode the request body into v. The body must hold a single JSON value with known fields only,
+// anything else is reported as INVALID_BODY, and a body over the size limit as BODY_TOO_LARGE.
+func decodeJSON(r *http.Request, v interface{}) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(v)
+	if err == nil {
+		// a second value or garbage after the first one
+		if _, trailingErr := decoder.Token(); trailingErr != io.EOF {
+			err = errors.New("body must contain a single JSON value")
+		}
+	}
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return utils.ErrBodyTooLarge.Wrap(err)
+		}
+
+		return utils.ErrInvalidBody.WithDetails(map[string]string{"body": err.Error()}).Wrap(err)
+	}
+
+	return nil
+}
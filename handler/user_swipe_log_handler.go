@@ -1,8 +1,6 @@
 package handler
 
 import (
-	"encoding/json"
-	"errors"
 	"github.com/dwiangraeni/dealls/interfaces"
 	"github.com/dwiangraeni/dealls/middleware"
 	"github.com/dwiangraeni/dealls/model"
@@ -20,31 +18,20 @@ func NewUserSwipeLogHandler(userSwipeLogService interfaces.IUserSwipeLogService)
 }
 
 func (u *userSwipeLogHandler) ProcessUserSwipe(w http.ResponseWriter, r *http.Request) {
-	tok := r.Context().Value("token")
-	if tok == nil {
-		response.HandleError(w, http.StatusUnauthorized, "unauthorized")
-		return
-	}
-
-	claim, ok := tok.(*middleware.AccessTokenClaim)
+	claim, ok := middleware.ClaimFromContext(r.Context())
 	if !ok {
-		response.HandleError(w, http.StatusUnauthorized, "unauthorized")
+		response.HandleAppError(w, utils.ErrUnauthorized)
 		return
 	}
 	req := model.UserSwipeRequest{}
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		response.HandleError(w, http.StatusBadRequest, err.Error())
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
 		return
 	}
 
 	req.SwiperAccountMaskID = claim.AccountMaskID
 	if err := u.userSwipeLogService.ProcessUserSwipe(r.Context(), req); err != nil {
-		if !errors.Is(err, utils.ErrInternal) {
-			response.HandleError(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		response.HandleError(w, http.StatusInternalServerError, err.Error())
+		response.HandleAppError(w, err)
 		return
 	}
 
@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"github.com/dwiangraeni/dealls/utils"
+	"net/http"
+)
+
+type verificationHandler struct {
+	verificationService interfaces.IVerificationService
+}
+
+func NewVerificationHandler(verificationService interfaces.IVerificationService) *verificationHandler {
+	return &verificationHandler{verificationService: verificationService}
+}
+
+func (v *verificationHandler) UpdateContact(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.UpdateContactRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+
+	data, err := v.verificationService.UpdateContact(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (v *verificationHandler) SendVerificationCode(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.SendVerificationRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+
+	data, err := v.verificationService.SendVerificationCode(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
+
+func (v *verificationHandler) VerifyContact(w http.ResponseWriter, r *http.Request) {
+	claim, ok := middleware.ClaimFromContext(r.Context())
+	if !ok {
+		response.HandleAppError(w, utils.ErrUnauthorized)
+		return
+	}
+
+	var req model.VerifyContactRequest
+	if err := decodeJSON(r, &req); err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+	req.AccountMaskID = claim.AccountMaskID
+
+	data, err := v.verificationService.VerifyContact(r.Context(), req)
+	if err != nil {
+		response.HandleAppError(w, err)
+		return
+	}
+
+	response.HandleSuccess(w, data)
+}
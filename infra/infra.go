@@ -1,6 +1,9 @@
 package infra
 
 import (
+	"database/sql"
+	"github.com/dwiangraeni/dealls/logging"
+	"github.com/dwiangraeni/dealls/tracing"
 	"github.com/jackc/pgx"
 	"github.com/jackc/pgx/stdlib"
 	"github.com/jmoiron/sqlx"
@@ -16,6 +19,8 @@ import (
 type Infra interface {
 	Config() *viper.Viper
 	SQLDB() *sqlx.DB
+	// Close release the database pool, it is a no-op when the pool was never opened
+	Close() error
 }
 
 type infraCtx struct {
@@ -51,6 +56,9 @@ func (c *infraCtx) Config() *viper.Viper {
 			log.Fatalf("failed to read config file: %v", err)
 		}
 
+		// every command logs through the same structured logger
+		logging.Setup(logging.Config{Level: v.GetString("log.level"), Format: v.GetString("log.format")})
+
 		cfg = v
 	})
 
@@ -94,7 +102,11 @@ func (c *infraCtx) SQLDB() *sqlx.DB {
 			}
 			conCfg.LogLevel = lvl
 		}
-		db := stdlib.OpenDB(conCfg)
+		// open through the tracing connector so every query gets a span. The dsn is sslmode=disable only because an
+		// empty one defaults to sslmode=prefer, which would override the tls settings of conCfg.
+		driverConfig := &stdlib.DriverConfig{ConnConfig: conCfg}
+		stdlib.RegisterDriverConfig(driverConfig)
+		db := sql.OpenDB(tracing.NewConnector(stdlib.GetDefaultDriver(), driverConfig.ConnectionString("sslmode=disable")))
 		db.SetConnMaxLifetime(time.Duration(pgConfig.GetInt("con_max_lifetime")) * time.Second)
 		db.SetMaxIdleConns(pgConfig.GetInt("con_max_idle"))
 		db.SetMaxOpenConns(pgConfig.GetInt("con_max_open"))
@@ -104,3 +116,11 @@ func (c *infraCtx) SQLDB() *sqlx.DB {
 	})
 	return sqlDB
 }
+
+func (c *infraCtx) Close() error {
+	if sqlDB == nil {
+		return nil
+	}
+
+	return sqlDB.Close()
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IAccountBlockRepo interface {
+	InsertAccountBlock(ctx context.Context, req *model.AccountBlockBaseModel) (err error)
+	DeleteAccountBlock(ctx context.Context, blockerID, blockedID int64) (err error)
+	IsAccountBlocked(ctx context.Context, accountID, otherAccountID int64) (blocked bool, err error)
+}
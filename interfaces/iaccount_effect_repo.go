@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/model"
+	"time"
+)
+
+type IAccountEffectRepo interface {
+	InsertAccountEffect(ctx context.Context, trx *sql.Tx, req *model.AccountEffectBaseModel, duration time.Duration) (err error)
+	GetListAccountEffectByAccountID(ctx context.Context, accountID int64) (output []model.AccountEffectBaseModel, err error)
+}
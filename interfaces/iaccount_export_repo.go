@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/model"
+	"time"
+)
+
+type IAccountExportRepo interface {
+	InsertAccountExport(ctx context.Context, req *model.AccountExportBaseModel) (err error)
+	GetLatestAccountExportByAccountID(ctx context.Context, accountID int64) (output model.AccountExportBaseModel, err error)
+	GetAccountExportByUID(ctx context.Context, exportUID string) (output model.AccountExportBaseModel, err error)
+	ClaimAccountExport(ctx context.Context, staleBefore time.Time) (output model.AccountExportBaseModel, err error)
+	CompleteAccountExport(ctx context.Context, req *model.AccountExportBaseModel) (err error)
+	ClearExpiredAccountExport(ctx context.Context, completedBefore time.Time) (filePaths []string, err error)
+	DeleteAccountExportByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) (filePaths []string, err error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+	"io"
+)
+
+type IAccountExportService interface {
+	RequestAccountExport(ctx context.Context, accountMaskID string) (model.AccountExportResponse, error)
+	GetAccountExportStatus(ctx context.Context, accountMaskID string) (model.AccountExportResponse, error)
+	OpenAccountExport(ctx context.Context, accountMaskID, exportUID string) (io.ReadCloser, error)
+	ProcessAccountExport(ctx context.Context) (processed bool, err error)
+	Run(ctx context.Context)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IAccountLifecycleService interface {
+	UpdateDiscovery(ctx context.Context, req model.UpdateDiscoveryRequest) (model.AccountSettingResponse, error)
+	DeactivateAccount(ctx context.Context, accountMaskID string) (model.AccountSettingResponse, error)
+	DeleteAccount(ctx context.Context, req model.DeleteAccountRequest) error
+}
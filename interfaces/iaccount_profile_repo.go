@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IAccountProfileRepo interface {
+	GetListAccountPhotoByAccountID(ctx context.Context, accountID int64) (output []model.AccountPhotoBaseModel, err error)
+	InsertAccountPhoto(ctx context.Context, req []model.AccountPhotoBaseModel) (err error)
+	GetListAccountInterestByAccountID(ctx context.Context, accountID int64) (output []string, err error)
+	InsertAccountInterest(ctx context.Context, accountID int64, interests []string) (err error)
+	DeleteAccountProfileByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) (err error)
+	InsertProfileView(ctx context.Context, req *model.ProfileViewBaseModel) (err error)
+	GetListProfileVisitorPagination(ctx context.Context, req model.ListProfileVisitorRequest) (output []model.ProfileVisitorBaseModel, err error)
+	CountProfileVisitor(ctx context.Context, viewedID int64) (total int64, err error)
+}
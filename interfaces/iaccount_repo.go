@@ -10,6 +10,16 @@ type IAccountRepo interface {
 	FindOneAccountByAccountUserName(ctx context.Context, userName string) (model.AccountBaseModel, error)
 	InsertAccount(ctx context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error)
 	UpdateAccountType(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error)
+	UpdateAccountVerified(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error)
+	LockAccount(ctx context.Context, trx *sql.Tx, accountID int64) error
+	UpdateAccountContact(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error)
+	UpdateAccountStatus(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error)
+	UpdateAccountDiscovery(ctx context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error)
+	UpdateAccountPrivacy(ctx context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error)
+	AnonymizeAccount(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error)
+	UpdateAccountPassword(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error)
 	FindOneAccountByAccountMaskID(ctx context.Context, accountMaskID string) (output model.AccountBaseModel, err error)
+	IsAccountIncognitoFor(ctx context.Context, accountID, viewerID int64) (bool, error)
+	GetListAccountBoosted(ctx context.Context, req model.PaginationRequest) (output []model.AccountBaseModel, err error)
 	GetListAccountNewMatchPagination(ctx context.Context, req model.PaginationRequest) (output []model.AccountBaseModel, err error)
 }
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IAccountReportRepo interface {
+	InsertAccountReport(ctx context.Context, req *model.AccountReportBaseModel) (err error)
+	GetAccountReportByUID(ctx context.Context, reportUID string) (output model.AccountReportBaseModel, err error)
+	GetListAccountReportPagination(ctx context.Context, req model.ListAccountReportRequest) (output []model.AccountReportBaseModel, err error)
+	ResolveAccountReport(ctx context.Context, trx *sql.Tx, req *model.AccountReportBaseModel) (err error)
+}
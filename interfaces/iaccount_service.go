@@ -7,4 +7,8 @@ import (
 
 type IAccountService interface {
 	GetListAccountNewMatchPagination(ctx context.Context, req model.PaginationRequest) (resp model.ListAccountPagination, err error)
+	GetAccountStatus(ctx context.Context, accountMaskID string) (status string, err error)
+	GetAccountProfile(ctx context.Context, req model.GetAccountProfileRequest) (resp model.AccountProfileResponse, err error)
+	UpdateProfilePrivacy(ctx context.Context, req model.UpdateProfilePrivacyRequest) (resp model.ProfilePrivacyResponse, err error)
+	GetListProfileVisitorPagination(ctx context.Context, req model.ListProfileVisitorRequest) (resp model.ListProfileVisitorPagination, err error)
 }
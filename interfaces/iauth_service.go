@@ -9,4 +9,6 @@ import (
 type IAuthService interface {
 	Login(ctx context.Context, form request.LoginRequest) (*response.LoginResponse, error)
 	Register(ctx context.Context, form request.RegisterRequest) (*response.RegisterResponse, error)
+	ForgotPassword(ctx context.Context, form request.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, form request.ResetPasswordRequest) error
 }
@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type ICreditLedgerRepo interface {
+	InsertCreditLedger(ctx context.Context, trx *sql.Tx, req *model.CreditLedgerBaseModel) (err error)
+	SpendCredit(ctx context.Context, trx *sql.Tx, accountID int64, creditType string, amount int) (err error)
+	GetListCreditBalanceByAccountID(ctx context.Context, accountID int64) (output []model.CreditBalance, err error)
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"io"
+)
+
+type IFileStorage interface {
+	Save(ctx context.Context, name string, content io.Reader) error
+	Open(ctx context.Context, name string) (io.ReadCloser, error)
+	Delete(ctx context.Context, name string) error
+}
@@ -0,0 +1,21 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/migration"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IHealthService interface {
+	CheckReadiness(ctx context.Context) model.Readiness
+	// Drain mark the instance as shutting down, readiness fails from then on
+	Drain()
+}
+
+type IDatabasePinger interface {
+	PingContext(ctx context.Context) error
+}
+
+type IMigrationStatus interface {
+	Status(ctx context.Context) ([]migration.Status, error)
+}
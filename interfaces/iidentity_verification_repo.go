@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IIdentityVerificationRepo interface {
+	InsertIdentityVerification(ctx context.Context, req *model.IdentityVerificationBaseModel) (err error)
+	GetLatestIdentityVerificationByAccountID(ctx context.Context, accountID int64) (output model.IdentityVerificationBaseModel, err error)
+	GetIdentityVerificationByUID(ctx context.Context, verificationUID string) (output model.IdentityVerificationBaseModel, err error)
+	GetListIdentityVerificationPagination(ctx context.Context, req model.ListIdentityVerificationRequest) (output []model.IdentityVerificationBaseModel, err error)
+	ReviewIdentityVerification(ctx context.Context, trx *sql.Tx, req *model.IdentityVerificationBaseModel) (err error)
+	ClearSelfieByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) (selfiePaths []string, err error)
+}
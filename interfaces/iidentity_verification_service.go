@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+	"io"
+)
+
+type IIdentityVerificationService interface {
+	SubmitIdentityVerification(ctx context.Context, req model.SubmitIdentityVerificationRequest) (model.IdentityVerificationResponse, error)
+	GetIdentityVerificationStatus(ctx context.Context, accountMaskID string) (model.IdentityVerificationResponse, error)
+	GetListIdentityVerificationPagination(ctx context.Context, req model.ListIdentityVerificationRequest) (model.ListIdentityVerificationPagination, error)
+	ReviewIdentityVerification(ctx context.Context, req model.ReviewIdentityVerificationRequest) (model.IdentityVerificationResponse, error)
+	OpenSelfie(ctx context.Context, verificationUID string) (io.ReadCloser, string, error)
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+	"time"
+)
+
+type ILoginAttemptRepo interface {
+	GetLoginAttempt(ctx context.Context, key string) (model.LoginAttemptBaseModel, error)
+	// IncrementLoginAttempt count a failure, the counter restarts when the last failure is older than window
+	IncrementLoginAttempt(ctx context.Context, key string, window time.Duration) (model.LoginAttemptBaseModel, error)
+	LockLoginAttempt(ctx context.Context, key string, lockedUntil time.Time) error
+	ResetLoginAttempt(ctx context.Context, key string) error
+}
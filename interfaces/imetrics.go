@@ -0,0 +1,11 @@
+package interfaces
+
+// IMetrics business events counted by the services
+type IMetrics interface {
+	SwipeRecorded(swipeType string)
+	SwipeQuotaRejected()
+	PackageCheckedOut(packageTitle string)
+	CreditSpent(creditType string)
+	LoginFailed(reason string)
+	ProfileViewed()
+}
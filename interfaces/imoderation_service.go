@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IModerationService interface {
+	BlockAccount(ctx context.Context, req model.BlockAccountRequest) error
+	UnblockAccount(ctx context.Context, req model.BlockAccountRequest) error
+	ReportAccount(ctx context.Context, req model.ReportAccountRequest) (model.AccountReportResponse, error)
+	GetListAccountReportPagination(ctx context.Context, req model.ListAccountReportRequest) (model.ListAccountReportPagination, error)
+	ModerateAccountReport(ctx context.Context, req model.ModerateAccountReportRequest) (model.AccountReportResponse, error)
+}
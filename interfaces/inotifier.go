@@ -0,0 +1,10 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type INotifier interface {
+	Send(ctx context.Context, notification model.Notification) error
+}
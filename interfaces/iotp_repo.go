@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IOTPRepo interface {
+	InsertOTP(ctx context.Context, trx *sql.Tx, req *model.OTPBaseModel) (err error)
+	InvalidateOTP(ctx context.Context, trx *sql.Tx, accountID int64, purpose string) (err error)
+	GetLatestOTP(ctx context.Context, accountID int64, purpose string) (output model.OTPBaseModel, err error)
+	IncrementOTPAttempt(ctx context.Context, id int64, maxAttempts int) (attempts int, err error)
+	ConsumeOTP(ctx context.Context, trx *sql.Tx, id int64) (err error)
+	DeleteOTPByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) (err error)
+}
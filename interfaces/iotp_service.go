@@ -0,0 +1,13 @@
+package interfaces
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IOTPService interface {
+	SendOTP(ctx context.Context, accountID int64, purpose, channel, recipient string) error
+	VerifyOTP(ctx context.Context, accountID int64, purpose, code string) (model.OTPBaseModel, error)
+	ConsumeOTP(ctx context.Context, trx *sql.Tx, otp model.OTPBaseModel) error
+}
@@ -8,4 +8,7 @@ import (
 type IPremiumPackageService interface {
 	GetListPremiumPackagePagination(ctx context.Context, req model.PaginationRequest) (output model.ListPackagePagination, err error)
 	PremiumPackageCheckout(ctx context.Context, req model.PremiumPackageCheckoutRequest) error
+	GetListAccountEffect(ctx context.Context, accountMaskID string) ([]model.AccountEffectResponse, error)
+	GetWallet(ctx context.Context, accountMaskID string) (model.WalletResponse, error)
+	ActivateBoost(ctx context.Context, accountMaskID string) (model.AccountEffectResponse, error)
 }
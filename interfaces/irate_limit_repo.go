@@ -0,0 +1,11 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IRateLimitRepo interface {
+	// Allow take one token from the bucket identified by key
+	Allow(ctx context.Context, key string, limit model.RateLimit) (model.RateLimitResult, error)
+}
@@ -2,11 +2,15 @@ package interfaces
 
 import (
 	"context"
+	"database/sql"
 	"github.com/dwiangraeni/dealls/model"
 )
 
 type IUserSwipeLogRepo interface {
-	InsertUserSwipeLog(ctx context.Context, req model.UserSwipeLogBaseModel) (model.UserSwipeLogBaseModel, error)
+	InsertUserSwipeLog(ctx context.Context, trx *sql.Tx, req model.UserSwipeLogBaseModel) (model.UserSwipeLogBaseModel, error)
+	InsertUserSwipeLogHistory(ctx context.Context, req []model.UserSwipeLogBaseModel) (err error)
 	GetSwipeCountByAccountID(ctx context.Context, accountMaskID string) (resp model.SwipeCountBaseModel, err error)
 	GetUserSwipeLogBySwiperIDAndSwpeeID(ctx context.Context, swiperID, swipeeID int64) (resp model.UserSwipeLogBaseModel, err error)
+	GetListUserSwipeLogBySwiperID(ctx context.Context, swiperID int64) (output []model.AccountExportSwipe, err error)
+	GetListMatchByAccountID(ctx context.Context, accountID int64) (output []model.AccountExportMatch, err error)
 }
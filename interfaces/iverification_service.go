@@ -0,0 +1,12 @@
+package interfaces
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+)
+
+type IVerificationService interface {
+	UpdateContact(ctx context.Context, req model.UpdateContactRequest) (model.ContactResponse, error)
+	SendVerificationCode(ctx context.Context, req model.SendVerificationRequest) (model.ContactResponse, error)
+	VerifyContact(ctx context.Context, req model.VerifyContactRequest) (model.ContactResponse, error)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/iaccount_block_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAccountBlockRepo is a mock of IAccountBlockRepo interface.
+type MockIAccountBlockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountBlockRepoMockRecorder
+}
+
+// MockIAccountBlockRepoMockRecorder is the mock recorder for MockIAccountBlockRepo.
+type MockIAccountBlockRepoMockRecorder struct {
+	mock *MockIAccountBlockRepo
+}
+
+// NewMockIAccountBlockRepo creates a new mock instance.
+func NewMockIAccountBlockRepo(ctrl *gomock.Controller) *MockIAccountBlockRepo {
+	mock := &MockIAccountBlockRepo{ctrl: ctrl}
+	mock.recorder = &MockIAccountBlockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountBlockRepo) EXPECT() *MockIAccountBlockRepoMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccountBlock mocks base method.
+func (m *MockIAccountBlockRepo) DeleteAccountBlock(ctx context.Context, blockerID, blockedID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountBlock", ctx, blockerID, blockedID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccountBlock indicates an expected call of DeleteAccountBlock.
+func (mr *MockIAccountBlockRepoMockRecorder) DeleteAccountBlock(ctx, blockerID, blockedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountBlock", reflect.TypeOf((*MockIAccountBlockRepo)(nil).DeleteAccountBlock), ctx, blockerID, blockedID)
+}
+
+// InsertAccountBlock mocks base method.
+func (m *MockIAccountBlockRepo) InsertAccountBlock(ctx context.Context, req *model.AccountBlockBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccountBlock", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAccountBlock indicates an expected call of InsertAccountBlock.
+func (mr *MockIAccountBlockRepoMockRecorder) InsertAccountBlock(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountBlock", reflect.TypeOf((*MockIAccountBlockRepo)(nil).InsertAccountBlock), ctx, req)
+}
+
+// IsAccountBlocked mocks base method.
+func (m *MockIAccountBlockRepo) IsAccountBlocked(ctx context.Context, accountID, otherAccountID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccountBlocked", ctx, accountID, otherAccountID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccountBlocked indicates an expected call of IsAccountBlocked.
+func (mr *MockIAccountBlockRepoMockRecorder) IsAccountBlocked(ctx, accountID, otherAccountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccountBlocked", reflect.TypeOf((*MockIAccountBlockRepo)(nil).IsAccountBlocked), ctx, accountID, otherAccountID)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/iaccount_effect_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAccountEffectRepo is a mock of IAccountEffectRepo interface.
+type MockIAccountEffectRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountEffectRepoMockRecorder
+}
+
+// MockIAccountEffectRepoMockRecorder is the mock recorder for MockIAccountEffectRepo.
+type MockIAccountEffectRepoMockRecorder struct {
+	mock *MockIAccountEffectRepo
+}
+
+// NewMockIAccountEffectRepo creates a new mock instance.
+func NewMockIAccountEffectRepo(ctrl *gomock.Controller) *MockIAccountEffectRepo {
+	mock := &MockIAccountEffectRepo{ctrl: ctrl}
+	mock.recorder = &MockIAccountEffectRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountEffectRepo) EXPECT() *MockIAccountEffectRepoMockRecorder {
+	return m.recorder
+}
+
+// GetListAccountEffectByAccountID mocks base method.
+func (m *MockIAccountEffectRepo) GetListAccountEffectByAccountID(ctx context.Context, accountID int64) ([]model.AccountEffectBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListAccountEffectByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]model.AccountEffectBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListAccountEffectByAccountID indicates an expected call of GetListAccountEffectByAccountID.
+func (mr *MockIAccountEffectRepoMockRecorder) GetListAccountEffectByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListAccountEffectByAccountID", reflect.TypeOf((*MockIAccountEffectRepo)(nil).GetListAccountEffectByAccountID), ctx, accountID)
+}
+
+// InsertAccountEffect mocks base method.
+func (m *MockIAccountEffectRepo) InsertAccountEffect(ctx context.Context, trx *sql.Tx, req *model.AccountEffectBaseModel, duration time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccountEffect", ctx, trx, req, duration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAccountEffect indicates an expected call of InsertAccountEffect.
+func (mr *MockIAccountEffectRepoMockRecorder) InsertAccountEffect(ctx, trx, req, duration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountEffect", reflect.TypeOf((*MockIAccountEffectRepo)(nil).InsertAccountEffect), ctx, trx, req, duration)
+}
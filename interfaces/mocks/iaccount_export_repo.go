@@ -0,0 +1,141 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/iaccount_export_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAccountExportRepo is a mock of IAccountExportRepo interface.
+type MockIAccountExportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountExportRepoMockRecorder
+}
+
+// MockIAccountExportRepoMockRecorder is the mock recorder for MockIAccountExportRepo.
+type MockIAccountExportRepoMockRecorder struct {
+	mock *MockIAccountExportRepo
+}
+
+// NewMockIAccountExportRepo creates a new mock instance.
+func NewMockIAccountExportRepo(ctrl *gomock.Controller) *MockIAccountExportRepo {
+	mock := &MockIAccountExportRepo{ctrl: ctrl}
+	mock.recorder = &MockIAccountExportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountExportRepo) EXPECT() *MockIAccountExportRepoMockRecorder {
+	return m.recorder
+}
+
+// ClaimAccountExport mocks base method.
+func (m *MockIAccountExportRepo) ClaimAccountExport(ctx context.Context, staleBefore time.Time) (model.AccountExportBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimAccountExport", ctx, staleBefore)
+	ret0, _ := ret[0].(model.AccountExportBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimAccountExport indicates an expected call of ClaimAccountExport.
+func (mr *MockIAccountExportRepoMockRecorder) ClaimAccountExport(ctx, staleBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimAccountExport", reflect.TypeOf((*MockIAccountExportRepo)(nil).ClaimAccountExport), ctx, staleBefore)
+}
+
+// ClearExpiredAccountExport mocks base method.
+func (m *MockIAccountExportRepo) ClearExpiredAccountExport(ctx context.Context, completedBefore time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearExpiredAccountExport", ctx, completedBefore)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearExpiredAccountExport indicates an expected call of ClearExpiredAccountExport.
+func (mr *MockIAccountExportRepoMockRecorder) ClearExpiredAccountExport(ctx, completedBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearExpiredAccountExport", reflect.TypeOf((*MockIAccountExportRepo)(nil).ClearExpiredAccountExport), ctx, completedBefore)
+}
+
+// CompleteAccountExport mocks base method.
+func (m *MockIAccountExportRepo) CompleteAccountExport(ctx context.Context, req *model.AccountExportBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteAccountExport", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteAccountExport indicates an expected call of CompleteAccountExport.
+func (mr *MockIAccountExportRepoMockRecorder) CompleteAccountExport(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteAccountExport", reflect.TypeOf((*MockIAccountExportRepo)(nil).CompleteAccountExport), ctx, req)
+}
+
+// DeleteAccountExportByAccountID mocks base method.
+func (m *MockIAccountExportRepo) DeleteAccountExportByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountExportByAccountID", ctx, trx, accountID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAccountExportByAccountID indicates an expected call of DeleteAccountExportByAccountID.
+func (mr *MockIAccountExportRepoMockRecorder) DeleteAccountExportByAccountID(ctx, trx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountExportByAccountID", reflect.TypeOf((*MockIAccountExportRepo)(nil).DeleteAccountExportByAccountID), ctx, trx, accountID)
+}
+
+// GetAccountExportByUID mocks base method.
+func (m *MockIAccountExportRepo) GetAccountExportByUID(ctx context.Context, exportUID string) (model.AccountExportBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountExportByUID", ctx, exportUID)
+	ret0, _ := ret[0].(model.AccountExportBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountExportByUID indicates an expected call of GetAccountExportByUID.
+func (mr *MockIAccountExportRepoMockRecorder) GetAccountExportByUID(ctx, exportUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountExportByUID", reflect.TypeOf((*MockIAccountExportRepo)(nil).GetAccountExportByUID), ctx, exportUID)
+}
+
+// GetLatestAccountExportByAccountID mocks base method.
+func (m *MockIAccountExportRepo) GetLatestAccountExportByAccountID(ctx context.Context, accountID int64) (model.AccountExportBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestAccountExportByAccountID", ctx, accountID)
+	ret0, _ := ret[0].(model.AccountExportBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestAccountExportByAccountID indicates an expected call of GetLatestAccountExportByAccountID.
+func (mr *MockIAccountExportRepoMockRecorder) GetLatestAccountExportByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAccountExportByAccountID", reflect.TypeOf((*MockIAccountExportRepo)(nil).GetLatestAccountExportByAccountID), ctx, accountID)
+}
+
+// InsertAccountExport mocks base method.
+func (m *MockIAccountExportRepo) InsertAccountExport(ctx context.Context, req *model.AccountExportBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccountExport", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAccountExport indicates an expected call of InsertAccountExport.
+func (mr *MockIAccountExportRepoMockRecorder) InsertAccountExport(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountExport", reflect.TypeOf((*MockIAccountExportRepo)(nil).InsertAccountExport), ctx, req)
+}
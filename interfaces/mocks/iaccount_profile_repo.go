@@ -0,0 +1,153 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/iaccount_profile_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAccountProfileRepo is a mock of IAccountProfileRepo interface.
+type MockIAccountProfileRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountProfileRepoMockRecorder
+}
+
+// MockIAccountProfileRepoMockRecorder is the mock recorder for MockIAccountProfileRepo.
+type MockIAccountProfileRepoMockRecorder struct {
+	mock *MockIAccountProfileRepo
+}
+
+// NewMockIAccountProfileRepo creates a new mock instance.
+func NewMockIAccountProfileRepo(ctrl *gomock.Controller) *MockIAccountProfileRepo {
+	mock := &MockIAccountProfileRepo{ctrl: ctrl}
+	mock.recorder = &MockIAccountProfileRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountProfileRepo) EXPECT() *MockIAccountProfileRepoMockRecorder {
+	return m.recorder
+}
+
+// CountProfileVisitor mocks base method.
+func (m *MockIAccountProfileRepo) CountProfileVisitor(ctx context.Context, viewedID int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountProfileVisitor", ctx, viewedID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountProfileVisitor indicates an expected call of CountProfileVisitor.
+func (mr *MockIAccountProfileRepoMockRecorder) CountProfileVisitor(ctx, viewedID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountProfileVisitor", reflect.TypeOf((*MockIAccountProfileRepo)(nil).CountProfileVisitor), ctx, viewedID)
+}
+
+// DeleteAccountProfileByAccountID mocks base method.
+func (m *MockIAccountProfileRepo) DeleteAccountProfileByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccountProfileByAccountID", ctx, trx, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccountProfileByAccountID indicates an expected call of DeleteAccountProfileByAccountID.
+func (mr *MockIAccountProfileRepoMockRecorder) DeleteAccountProfileByAccountID(ctx, trx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccountProfileByAccountID", reflect.TypeOf((*MockIAccountProfileRepo)(nil).DeleteAccountProfileByAccountID), ctx, trx, accountID)
+}
+
+// GetListAccountInterestByAccountID mocks base method.
+func (m *MockIAccountProfileRepo) GetListAccountInterestByAccountID(ctx context.Context, accountID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListAccountInterestByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListAccountInterestByAccountID indicates an expected call of GetListAccountInterestByAccountID.
+func (mr *MockIAccountProfileRepoMockRecorder) GetListAccountInterestByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListAccountInterestByAccountID", reflect.TypeOf((*MockIAccountProfileRepo)(nil).GetListAccountInterestByAccountID), ctx, accountID)
+}
+
+// GetListAccountPhotoByAccountID mocks base method.
+func (m *MockIAccountProfileRepo) GetListAccountPhotoByAccountID(ctx context.Context, accountID int64) ([]model.AccountPhotoBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListAccountPhotoByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]model.AccountPhotoBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListAccountPhotoByAccountID indicates an expected call of GetListAccountPhotoByAccountID.
+func (mr *MockIAccountProfileRepoMockRecorder) GetListAccountPhotoByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListAccountPhotoByAccountID", reflect.TypeOf((*MockIAccountProfileRepo)(nil).GetListAccountPhotoByAccountID), ctx, accountID)
+}
+
+// GetListProfileVisitorPagination mocks base method.
+func (m *MockIAccountProfileRepo) GetListProfileVisitorPagination(ctx context.Context, req model.ListProfileVisitorRequest) ([]model.ProfileVisitorBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListProfileVisitorPagination", ctx, req)
+	ret0, _ := ret[0].([]model.ProfileVisitorBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListProfileVisitorPagination indicates an expected call of GetListProfileVisitorPagination.
+func (mr *MockIAccountProfileRepoMockRecorder) GetListProfileVisitorPagination(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListProfileVisitorPagination", reflect.TypeOf((*MockIAccountProfileRepo)(nil).GetListProfileVisitorPagination), ctx, req)
+}
+
+// InsertAccountInterest mocks base method.
+func (m *MockIAccountProfileRepo) InsertAccountInterest(ctx context.Context, accountID int64, interests []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccountInterest", ctx, accountID, interests)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAccountInterest indicates an expected call of InsertAccountInterest.
+func (mr *MockIAccountProfileRepoMockRecorder) InsertAccountInterest(ctx, accountID, interests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountInterest", reflect.TypeOf((*MockIAccountProfileRepo)(nil).InsertAccountInterest), ctx, accountID, interests)
+}
+
+// InsertAccountPhoto mocks base method.
+func (m *MockIAccountProfileRepo) InsertAccountPhoto(ctx context.Context, req []model.AccountPhotoBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccountPhoto", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAccountPhoto indicates an expected call of InsertAccountPhoto.
+func (mr *MockIAccountProfileRepoMockRecorder) InsertAccountPhoto(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountPhoto", reflect.TypeOf((*MockIAccountProfileRepo)(nil).InsertAccountPhoto), ctx, req)
+}
+
+// InsertProfileView mocks base method.
+func (m *MockIAccountProfileRepo) InsertProfileView(ctx context.Context, req *model.ProfileViewBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertProfileView", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertProfileView indicates an expected call of InsertProfileView.
+func (mr *MockIAccountProfileRepoMockRecorder) InsertProfileView(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertProfileView", reflect.TypeOf((*MockIAccountProfileRepo)(nil).InsertProfileView), ctx, req)
+}
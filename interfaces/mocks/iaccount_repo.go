@@ -36,6 +36,21 @@ func (m *MockIAccountRepo) EXPECT() *MockIAccountRepoMockRecorder {
 	return m.recorder
 }
 
+// AnonymizeAccount mocks base method.
+func (m *MockIAccountRepo) AnonymizeAccount(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AnonymizeAccount", ctx, trx, account)
+	ret0, _ := ret[0].(model.AccountBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AnonymizeAccount indicates an expected call of AnonymizeAccount.
+func (mr *MockIAccountRepoMockRecorder) AnonymizeAccount(ctx, trx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AnonymizeAccount", reflect.TypeOf((*MockIAccountRepo)(nil).AnonymizeAccount), ctx, trx, account)
+}
+
 // FindOneAccountByAccountMaskID mocks base method.
 func (m *MockIAccountRepo) FindOneAccountByAccountMaskID(ctx context.Context, accountMaskID string) (model.AccountBaseModel, error) {
 	m.ctrl.T.Helper()
@@ -66,6 +81,21 @@ func (mr *MockIAccountRepoMockRecorder) FindOneAccountByAccountUserName(ctx, use
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindOneAccountByAccountUserName", reflect.TypeOf((*MockIAccountRepo)(nil).FindOneAccountByAccountUserName), ctx, userName)
 }
 
+// GetListAccountBoosted mocks base method.
+func (m *MockIAccountRepo) GetListAccountBoosted(ctx context.Context, req model.PaginationRequest) ([]model.AccountBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListAccountBoosted", ctx, req)
+	ret0, _ := ret[0].([]model.AccountBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListAccountBoosted indicates an expected call of GetListAccountBoosted.
+func (mr *MockIAccountRepoMockRecorder) GetListAccountBoosted(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListAccountBoosted", reflect.TypeOf((*MockIAccountRepo)(nil).GetListAccountBoosted), ctx, req)
+}
+
 // GetListAccountNewMatchPagination mocks base method.
 func (m *MockIAccountRepo) GetListAccountNewMatchPagination(ctx context.Context, req model.PaginationRequest) ([]model.AccountBaseModel, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +126,110 @@ func (mr *MockIAccountRepoMockRecorder) InsertAccount(ctx, account interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccount", reflect.TypeOf((*MockIAccountRepo)(nil).InsertAccount), ctx, account)
 }
 
+// IsAccountIncognitoFor mocks base method.
+func (m *MockIAccountRepo) IsAccountIncognitoFor(ctx context.Context, accountID, viewerID int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAccountIncognitoFor", ctx, accountID, viewerID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsAccountIncognitoFor indicates an expected call of IsAccountIncognitoFor.
+func (mr *MockIAccountRepoMockRecorder) IsAccountIncognitoFor(ctx, accountID, viewerID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAccountIncognitoFor", reflect.TypeOf((*MockIAccountRepo)(nil).IsAccountIncognitoFor), ctx, accountID, viewerID)
+}
+
+// LockAccount mocks base method.
+func (m *MockIAccountRepo) LockAccount(ctx context.Context, trx *sql.Tx, accountID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockAccount", ctx, trx, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockAccount indicates an expected call of LockAccount.
+func (mr *MockIAccountRepoMockRecorder) LockAccount(ctx, trx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockAccount", reflect.TypeOf((*MockIAccountRepo)(nil).LockAccount), ctx, trx, accountID)
+}
+
+// UpdateAccountContact mocks base method.
+func (m *MockIAccountRepo) UpdateAccountContact(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountContact", ctx, trx, account)
+	ret0, _ := ret[0].(model.AccountBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountContact indicates an expected call of UpdateAccountContact.
+func (mr *MockIAccountRepoMockRecorder) UpdateAccountContact(ctx, trx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountContact", reflect.TypeOf((*MockIAccountRepo)(nil).UpdateAccountContact), ctx, trx, account)
+}
+
+// UpdateAccountDiscovery mocks base method.
+func (m *MockIAccountRepo) UpdateAccountDiscovery(ctx context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountDiscovery", ctx, account)
+	ret0, _ := ret[0].(model.AccountBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountDiscovery indicates an expected call of UpdateAccountDiscovery.
+func (mr *MockIAccountRepoMockRecorder) UpdateAccountDiscovery(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountDiscovery", reflect.TypeOf((*MockIAccountRepo)(nil).UpdateAccountDiscovery), ctx, account)
+}
+
+// UpdateAccountPassword mocks base method.
+func (m *MockIAccountRepo) UpdateAccountPassword(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountPassword", ctx, trx, account)
+	ret0, _ := ret[0].(model.AccountBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountPassword indicates an expected call of UpdateAccountPassword.
+func (mr *MockIAccountRepoMockRecorder) UpdateAccountPassword(ctx, trx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountPassword", reflect.TypeOf((*MockIAccountRepo)(nil).UpdateAccountPassword), ctx, trx, account)
+}
+
+// UpdateAccountPrivacy mocks base method.
+func (m *MockIAccountRepo) UpdateAccountPrivacy(ctx context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountPrivacy", ctx, account)
+	ret0, _ := ret[0].(model.AccountBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountPrivacy indicates an expected call of UpdateAccountPrivacy.
+func (mr *MockIAccountRepoMockRecorder) UpdateAccountPrivacy(ctx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountPrivacy", reflect.TypeOf((*MockIAccountRepo)(nil).UpdateAccountPrivacy), ctx, account)
+}
+
+// UpdateAccountStatus mocks base method.
+func (m *MockIAccountRepo) UpdateAccountStatus(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountStatus", ctx, trx, account)
+	ret0, _ := ret[0].(model.AccountBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountStatus indicates an expected call of UpdateAccountStatus.
+func (mr *MockIAccountRepoMockRecorder) UpdateAccountStatus(ctx, trx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountStatus", reflect.TypeOf((*MockIAccountRepo)(nil).UpdateAccountStatus), ctx, trx, account)
+}
+
 // UpdateAccountType mocks base method.
 func (m *MockIAccountRepo) UpdateAccountType(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
 	m.ctrl.T.Helper()
@@ -110,3 +244,18 @@ func (mr *MockIAccountRepoMockRecorder) UpdateAccountType(ctx, trx, account inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountType", reflect.TypeOf((*MockIAccountRepo)(nil).UpdateAccountType), ctx, trx, account)
 }
+
+// UpdateAccountVerified mocks base method.
+func (m *MockIAccountRepo) UpdateAccountVerified(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccountVerified", ctx, trx, account)
+	ret0, _ := ret[0].(model.AccountBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccountVerified indicates an expected call of UpdateAccountVerified.
+func (mr *MockIAccountRepoMockRecorder) UpdateAccountVerified(ctx, trx, account interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccountVerified", reflect.TypeOf((*MockIAccountRepo)(nil).UpdateAccountVerified), ctx, trx, account)
+}
@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/iaccount_report_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAccountReportRepo is a mock of IAccountReportRepo interface.
+type MockIAccountReportRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountReportRepoMockRecorder
+}
+
+// MockIAccountReportRepoMockRecorder is the mock recorder for MockIAccountReportRepo.
+type MockIAccountReportRepoMockRecorder struct {
+	mock *MockIAccountReportRepo
+}
+
+// NewMockIAccountReportRepo creates a new mock instance.
+func NewMockIAccountReportRepo(ctrl *gomock.Controller) *MockIAccountReportRepo {
+	mock := &MockIAccountReportRepo{ctrl: ctrl}
+	mock.recorder = &MockIAccountReportRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountReportRepo) EXPECT() *MockIAccountReportRepoMockRecorder {
+	return m.recorder
+}
+
+// GetAccountReportByUID mocks base method.
+func (m *MockIAccountReportRepo) GetAccountReportByUID(ctx context.Context, reportUID string) (model.AccountReportBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountReportByUID", ctx, reportUID)
+	ret0, _ := ret[0].(model.AccountReportBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountReportByUID indicates an expected call of GetAccountReportByUID.
+func (mr *MockIAccountReportRepoMockRecorder) GetAccountReportByUID(ctx, reportUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountReportByUID", reflect.TypeOf((*MockIAccountReportRepo)(nil).GetAccountReportByUID), ctx, reportUID)
+}
+
+// GetListAccountReportPagination mocks base method.
+func (m *MockIAccountReportRepo) GetListAccountReportPagination(ctx context.Context, req model.ListAccountReportRequest) ([]model.AccountReportBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListAccountReportPagination", ctx, req)
+	ret0, _ := ret[0].([]model.AccountReportBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListAccountReportPagination indicates an expected call of GetListAccountReportPagination.
+func (mr *MockIAccountReportRepoMockRecorder) GetListAccountReportPagination(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListAccountReportPagination", reflect.TypeOf((*MockIAccountReportRepo)(nil).GetListAccountReportPagination), ctx, req)
+}
+
+// InsertAccountReport mocks base method.
+func (m *MockIAccountReportRepo) InsertAccountReport(ctx context.Context, req *model.AccountReportBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertAccountReport", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertAccountReport indicates an expected call of InsertAccountReport.
+func (mr *MockIAccountReportRepoMockRecorder) InsertAccountReport(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertAccountReport", reflect.TypeOf((*MockIAccountReportRepo)(nil).InsertAccountReport), ctx, req)
+}
+
+// ResolveAccountReport mocks base method.
+func (m *MockIAccountReportRepo) ResolveAccountReport(ctx context.Context, trx *sql.Tx, req *model.AccountReportBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveAccountReport", ctx, trx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveAccountReport indicates an expected call of ResolveAccountReport.
+func (mr *MockIAccountReportRepoMockRecorder) ResolveAccountReport(ctx, trx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveAccountReport", reflect.TypeOf((*MockIAccountReportRepo)(nil).ResolveAccountReport), ctx, trx, req)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/iaccount_service.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIAccountService is a mock of IAccountService interface.
+type MockIAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountServiceMockRecorder
+}
+
+// MockIAccountServiceMockRecorder is the mock recorder for MockIAccountService.
+type MockIAccountServiceMockRecorder struct {
+	mock *MockIAccountService
+}
+
+// NewMockIAccountService creates a new mock instance.
+func NewMockIAccountService(ctrl *gomock.Controller) *MockIAccountService {
+	mock := &MockIAccountService{ctrl: ctrl}
+	mock.recorder = &MockIAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountService) EXPECT() *MockIAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// GetAccountProfile mocks base method.
+func (m *MockIAccountService) GetAccountProfile(ctx context.Context, req model.GetAccountProfileRequest) (model.AccountProfileResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountProfile", ctx, req)
+	ret0, _ := ret[0].(model.AccountProfileResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountProfile indicates an expected call of GetAccountProfile.
+func (mr *MockIAccountServiceMockRecorder) GetAccountProfile(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountProfile", reflect.TypeOf((*MockIAccountService)(nil).GetAccountProfile), ctx, req)
+}
+
+// GetAccountStatus mocks base method.
+func (m *MockIAccountService) GetAccountStatus(ctx context.Context, accountMaskID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountStatus", ctx, accountMaskID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountStatus indicates an expected call of GetAccountStatus.
+func (mr *MockIAccountServiceMockRecorder) GetAccountStatus(ctx, accountMaskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountStatus", reflect.TypeOf((*MockIAccountService)(nil).GetAccountStatus), ctx, accountMaskID)
+}
+
+// GetListAccountNewMatchPagination mocks base method.
+func (m *MockIAccountService) GetListAccountNewMatchPagination(ctx context.Context, req model.PaginationRequest) (model.ListAccountPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListAccountNewMatchPagination", ctx, req)
+	ret0, _ := ret[0].(model.ListAccountPagination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListAccountNewMatchPagination indicates an expected call of GetListAccountNewMatchPagination.
+func (mr *MockIAccountServiceMockRecorder) GetListAccountNewMatchPagination(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListAccountNewMatchPagination", reflect.TypeOf((*MockIAccountService)(nil).GetListAccountNewMatchPagination), ctx, req)
+}
+
+// GetListProfileVisitorPagination mocks base method.
+func (m *MockIAccountService) GetListProfileVisitorPagination(ctx context.Context, req model.ListProfileVisitorRequest) (model.ListProfileVisitorPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListProfileVisitorPagination", ctx, req)
+	ret0, _ := ret[0].(model.ListProfileVisitorPagination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListProfileVisitorPagination indicates an expected call of GetListProfileVisitorPagination.
+func (mr *MockIAccountServiceMockRecorder) GetListProfileVisitorPagination(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListProfileVisitorPagination", reflect.TypeOf((*MockIAccountService)(nil).GetListProfileVisitorPagination), ctx, req)
+}
+
+// UpdateProfilePrivacy mocks base method.
+func (m *MockIAccountService) UpdateProfilePrivacy(ctx context.Context, req model.UpdateProfilePrivacyRequest) (model.ProfilePrivacyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfilePrivacy", ctx, req)
+	ret0, _ := ret[0].(model.ProfilePrivacyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateProfilePrivacy indicates an expected call of UpdateProfilePrivacy.
+func (mr *MockIAccountServiceMockRecorder) UpdateProfilePrivacy(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfilePrivacy", reflect.TypeOf((*MockIAccountService)(nil).UpdateProfilePrivacy), ctx, req)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/icredit_ledger_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockICreditLedgerRepo is a mock of ICreditLedgerRepo interface.
+type MockICreditLedgerRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockICreditLedgerRepoMockRecorder
+}
+
+// MockICreditLedgerRepoMockRecorder is the mock recorder for MockICreditLedgerRepo.
+type MockICreditLedgerRepoMockRecorder struct {
+	mock *MockICreditLedgerRepo
+}
+
+// NewMockICreditLedgerRepo creates a new mock instance.
+func NewMockICreditLedgerRepo(ctrl *gomock.Controller) *MockICreditLedgerRepo {
+	mock := &MockICreditLedgerRepo{ctrl: ctrl}
+	mock.recorder = &MockICreditLedgerRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockICreditLedgerRepo) EXPECT() *MockICreditLedgerRepoMockRecorder {
+	return m.recorder
+}
+
+// GetListCreditBalanceByAccountID mocks base method.
+func (m *MockICreditLedgerRepo) GetListCreditBalanceByAccountID(ctx context.Context, accountID int64) ([]model.CreditBalance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListCreditBalanceByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]model.CreditBalance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListCreditBalanceByAccountID indicates an expected call of GetListCreditBalanceByAccountID.
+func (mr *MockICreditLedgerRepoMockRecorder) GetListCreditBalanceByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListCreditBalanceByAccountID", reflect.TypeOf((*MockICreditLedgerRepo)(nil).GetListCreditBalanceByAccountID), ctx, accountID)
+}
+
+// InsertCreditLedger mocks base method.
+func (m *MockICreditLedgerRepo) InsertCreditLedger(ctx context.Context, trx *sql.Tx, req *model.CreditLedgerBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCreditLedger", ctx, trx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertCreditLedger indicates an expected call of InsertCreditLedger.
+func (mr *MockICreditLedgerRepoMockRecorder) InsertCreditLedger(ctx, trx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCreditLedger", reflect.TypeOf((*MockICreditLedgerRepo)(nil).InsertCreditLedger), ctx, trx, req)
+}
+
+// SpendCredit mocks base method.
+func (m *MockICreditLedgerRepo) SpendCredit(ctx context.Context, trx *sql.Tx, accountID int64, creditType string, amount int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SpendCredit", ctx, trx, accountID, creditType, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SpendCredit indicates an expected call of SpendCredit.
+func (mr *MockICreditLedgerRepoMockRecorder) SpendCredit(ctx, trx, accountID, creditType, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SpendCredit", reflect.TypeOf((*MockICreditLedgerRepo)(nil).SpendCredit), ctx, trx, accountID, creditType, amount)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/ifile_storage.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIFileStorage is a mock of IFileStorage interface.
+type MockIFileStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockIFileStorageMockRecorder
+}
+
+// MockIFileStorageMockRecorder is the mock recorder for MockIFileStorage.
+type MockIFileStorageMockRecorder struct {
+	mock *MockIFileStorage
+}
+
+// NewMockIFileStorage creates a new mock instance.
+func NewMockIFileStorage(ctrl *gomock.Controller) *MockIFileStorage {
+	mock := &MockIFileStorage{ctrl: ctrl}
+	mock.recorder = &MockIFileStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIFileStorage) EXPECT() *MockIFileStorageMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockIFileStorage) Delete(ctx context.Context, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockIFileStorageMockRecorder) Delete(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockIFileStorage)(nil).Delete), ctx, name)
+}
+
+// Open mocks base method.
+func (m *MockIFileStorage) Open(ctx context.Context, name string) (io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", ctx, name)
+	ret0, _ := ret[0].(io.ReadCloser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockIFileStorageMockRecorder) Open(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockIFileStorage)(nil).Open), ctx, name)
+}
+
+// Save mocks base method.
+func (m *MockIFileStorage) Save(ctx context.Context, name string, content io.Reader) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, name, content)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockIFileStorageMockRecorder) Save(ctx, name, content interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockIFileStorage)(nil).Save), ctx, name, content)
+}
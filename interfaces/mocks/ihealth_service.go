@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/ihealth_service.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	reflect "reflect"
+
+	migration "github.com/dwiangraeni/dealls/migration"
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIHealthService is a mock of IHealthService interface.
+type MockIHealthService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIHealthServiceMockRecorder
+}
+
+// MockIHealthServiceMockRecorder is the mock recorder for MockIHealthService.
+type MockIHealthServiceMockRecorder struct {
+	mock *MockIHealthService
+}
+
+// NewMockIHealthService creates a new mock instance.
+func NewMockIHealthService(ctrl *gomock.Controller) *MockIHealthService {
+	mock := &MockIHealthService{ctrl: ctrl}
+	mock.recorder = &MockIHealthServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIHealthService) EXPECT() *MockIHealthServiceMockRecorder {
+	return m.recorder
+}
+
+// CheckReadiness mocks base method.
+func (m *MockIHealthService) CheckReadiness(ctx context.Context) model.Readiness {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckReadiness", ctx)
+	ret0, _ := ret[0].(model.Readiness)
+	return ret0
+}
+
+// CheckReadiness indicates an expected call of CheckReadiness.
+func (mr *MockIHealthServiceMockRecorder) CheckReadiness(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckReadiness", reflect.TypeOf((*MockIHealthService)(nil).CheckReadiness), ctx)
+}
+
+// Drain mocks base method.
+func (m *MockIHealthService) Drain() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Drain")
+}
+
+// Drain indicates an expected call of Drain.
+func (mr *MockIHealthServiceMockRecorder) Drain() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Drain", reflect.TypeOf((*MockIHealthService)(nil).Drain))
+}
+
+// MockIDatabasePinger is a mock of IDatabasePinger interface.
+type MockIDatabasePinger struct {
+	ctrl     *gomock.Controller
+	recorder *MockIDatabasePingerMockRecorder
+}
+
+// MockIDatabasePingerMockRecorder is the mock recorder for MockIDatabasePinger.
+type MockIDatabasePingerMockRecorder struct {
+	mock *MockIDatabasePinger
+}
+
+// NewMockIDatabasePinger creates a new mock instance.
+func NewMockIDatabasePinger(ctrl *gomock.Controller) *MockIDatabasePinger {
+	mock := &MockIDatabasePinger{ctrl: ctrl}
+	mock.recorder = &MockIDatabasePingerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIDatabasePinger) EXPECT() *MockIDatabasePingerMockRecorder {
+	return m.recorder
+}
+
+// PingContext mocks base method.
+func (m *MockIDatabasePinger) PingContext(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PingContext", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PingContext indicates an expected call of PingContext.
+func (mr *MockIDatabasePingerMockRecorder) PingContext(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PingContext", reflect.TypeOf((*MockIDatabasePinger)(nil).PingContext), ctx)
+}
+
+// MockIMigrationStatus is a mock of IMigrationStatus interface.
+type MockIMigrationStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockIMigrationStatusMockRecorder
+}
+
+// MockIMigrationStatusMockRecorder is the mock recorder for MockIMigrationStatus.
+type MockIMigrationStatusMockRecorder struct {
+	mock *MockIMigrationStatus
+}
+
+// NewMockIMigrationStatus creates a new mock instance.
+func NewMockIMigrationStatus(ctrl *gomock.Controller) *MockIMigrationStatus {
+	mock := &MockIMigrationStatus{ctrl: ctrl}
+	mock.recorder = &MockIMigrationStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIMigrationStatus) EXPECT() *MockIMigrationStatusMockRecorder {
+	return m.recorder
+}
+
+// Status mocks base method.
+func (m *MockIMigrationStatus) Status(ctx context.Context) ([]migration.Status, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", ctx)
+	ret0, _ := ret[0].([]migration.Status)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockIMigrationStatusMockRecorder) Status(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockIMigrationStatus)(nil).Status), ctx)
+}
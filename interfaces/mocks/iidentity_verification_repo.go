@@ -0,0 +1,125 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/iidentity_verification_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIIdentityVerificationRepo is a mock of IIdentityVerificationRepo interface.
+type MockIIdentityVerificationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIIdentityVerificationRepoMockRecorder
+}
+
+// MockIIdentityVerificationRepoMockRecorder is the mock recorder for MockIIdentityVerificationRepo.
+type MockIIdentityVerificationRepoMockRecorder struct {
+	mock *MockIIdentityVerificationRepo
+}
+
+// NewMockIIdentityVerificationRepo creates a new mock instance.
+func NewMockIIdentityVerificationRepo(ctrl *gomock.Controller) *MockIIdentityVerificationRepo {
+	mock := &MockIIdentityVerificationRepo{ctrl: ctrl}
+	mock.recorder = &MockIIdentityVerificationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIIdentityVerificationRepo) EXPECT() *MockIIdentityVerificationRepoMockRecorder {
+	return m.recorder
+}
+
+// ClearSelfieByAccountID mocks base method.
+func (m *MockIIdentityVerificationRepo) ClearSelfieByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearSelfieByAccountID", ctx, trx, accountID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearSelfieByAccountID indicates an expected call of ClearSelfieByAccountID.
+func (mr *MockIIdentityVerificationRepoMockRecorder) ClearSelfieByAccountID(ctx, trx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSelfieByAccountID", reflect.TypeOf((*MockIIdentityVerificationRepo)(nil).ClearSelfieByAccountID), ctx, trx, accountID)
+}
+
+// GetIdentityVerificationByUID mocks base method.
+func (m *MockIIdentityVerificationRepo) GetIdentityVerificationByUID(ctx context.Context, verificationUID string) (model.IdentityVerificationBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentityVerificationByUID", ctx, verificationUID)
+	ret0, _ := ret[0].(model.IdentityVerificationBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentityVerificationByUID indicates an expected call of GetIdentityVerificationByUID.
+func (mr *MockIIdentityVerificationRepoMockRecorder) GetIdentityVerificationByUID(ctx, verificationUID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentityVerificationByUID", reflect.TypeOf((*MockIIdentityVerificationRepo)(nil).GetIdentityVerificationByUID), ctx, verificationUID)
+}
+
+// GetLatestIdentityVerificationByAccountID mocks base method.
+func (m *MockIIdentityVerificationRepo) GetLatestIdentityVerificationByAccountID(ctx context.Context, accountID int64) (model.IdentityVerificationBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestIdentityVerificationByAccountID", ctx, accountID)
+	ret0, _ := ret[0].(model.IdentityVerificationBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestIdentityVerificationByAccountID indicates an expected call of GetLatestIdentityVerificationByAccountID.
+func (mr *MockIIdentityVerificationRepoMockRecorder) GetLatestIdentityVerificationByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestIdentityVerificationByAccountID", reflect.TypeOf((*MockIIdentityVerificationRepo)(nil).GetLatestIdentityVerificationByAccountID), ctx, accountID)
+}
+
+// GetListIdentityVerificationPagination mocks base method.
+func (m *MockIIdentityVerificationRepo) GetListIdentityVerificationPagination(ctx context.Context, req model.ListIdentityVerificationRequest) ([]model.IdentityVerificationBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListIdentityVerificationPagination", ctx, req)
+	ret0, _ := ret[0].([]model.IdentityVerificationBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListIdentityVerificationPagination indicates an expected call of GetListIdentityVerificationPagination.
+func (mr *MockIIdentityVerificationRepoMockRecorder) GetListIdentityVerificationPagination(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListIdentityVerificationPagination", reflect.TypeOf((*MockIIdentityVerificationRepo)(nil).GetListIdentityVerificationPagination), ctx, req)
+}
+
+// InsertIdentityVerification mocks base method.
+func (m *MockIIdentityVerificationRepo) InsertIdentityVerification(ctx context.Context, req *model.IdentityVerificationBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertIdentityVerification", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertIdentityVerification indicates an expected call of InsertIdentityVerification.
+func (mr *MockIIdentityVerificationRepoMockRecorder) InsertIdentityVerification(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertIdentityVerification", reflect.TypeOf((*MockIIdentityVerificationRepo)(nil).InsertIdentityVerification), ctx, req)
+}
+
+// ReviewIdentityVerification mocks base method.
+func (m *MockIIdentityVerificationRepo) ReviewIdentityVerification(ctx context.Context, trx *sql.Tx, req *model.IdentityVerificationBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewIdentityVerification", ctx, trx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReviewIdentityVerification indicates an expected call of ReviewIdentityVerification.
+func (mr *MockIIdentityVerificationRepoMockRecorder) ReviewIdentityVerification(ctx, trx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewIdentityVerification", reflect.TypeOf((*MockIIdentityVerificationRepo)(nil).ReviewIdentityVerification), ctx, trx, req)
+}
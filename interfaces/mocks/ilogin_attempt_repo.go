@@ -0,0 +1,95 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/ilogin_attempt_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockILoginAttemptRepo is a mock of ILoginAttemptRepo interface.
+type MockILoginAttemptRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockILoginAttemptRepoMockRecorder
+}
+
+// MockILoginAttemptRepoMockRecorder is the mock recorder for MockILoginAttemptRepo.
+type MockILoginAttemptRepoMockRecorder struct {
+	mock *MockILoginAttemptRepo
+}
+
+// NewMockILoginAttemptRepo creates a new mock instance.
+func NewMockILoginAttemptRepo(ctrl *gomock.Controller) *MockILoginAttemptRepo {
+	mock := &MockILoginAttemptRepo{ctrl: ctrl}
+	mock.recorder = &MockILoginAttemptRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILoginAttemptRepo) EXPECT() *MockILoginAttemptRepoMockRecorder {
+	return m.recorder
+}
+
+// GetLoginAttempt mocks base method.
+func (m *MockILoginAttemptRepo) GetLoginAttempt(ctx context.Context, key string) (model.LoginAttemptBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLoginAttempt", ctx, key)
+	ret0, _ := ret[0].(model.LoginAttemptBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginAttempt indicates an expected call of GetLoginAttempt.
+func (mr *MockILoginAttemptRepoMockRecorder) GetLoginAttempt(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginAttempt", reflect.TypeOf((*MockILoginAttemptRepo)(nil).GetLoginAttempt), ctx, key)
+}
+
+// IncrementLoginAttempt mocks base method.
+func (m *MockILoginAttemptRepo) IncrementLoginAttempt(ctx context.Context, key string, window time.Duration) (model.LoginAttemptBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementLoginAttempt", ctx, key, window)
+	ret0, _ := ret[0].(model.LoginAttemptBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementLoginAttempt indicates an expected call of IncrementLoginAttempt.
+func (mr *MockILoginAttemptRepoMockRecorder) IncrementLoginAttempt(ctx, key, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementLoginAttempt", reflect.TypeOf((*MockILoginAttemptRepo)(nil).IncrementLoginAttempt), ctx, key, window)
+}
+
+// LockLoginAttempt mocks base method.
+func (m *MockILoginAttemptRepo) LockLoginAttempt(ctx context.Context, key string, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLoginAttempt", ctx, key, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLoginAttempt indicates an expected call of LockLoginAttempt.
+func (mr *MockILoginAttemptRepoMockRecorder) LockLoginAttempt(ctx, key, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLoginAttempt", reflect.TypeOf((*MockILoginAttemptRepo)(nil).LockLoginAttempt), ctx, key, lockedUntil)
+}
+
+// ResetLoginAttempt mocks base method.
+func (m *MockILoginAttemptRepo) ResetLoginAttempt(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginAttempt", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginAttempt indicates an expected call of ResetLoginAttempt.
+func (mr *MockILoginAttemptRepoMockRecorder) ResetLoginAttempt(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginAttempt", reflect.TypeOf((*MockILoginAttemptRepo)(nil).ResetLoginAttempt), ctx, key)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/inotifier.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockINotifier is a mock of INotifier interface.
+type MockINotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockINotifierMockRecorder
+}
+
+// MockINotifierMockRecorder is the mock recorder for MockINotifier.
+type MockINotifierMockRecorder struct {
+	mock *MockINotifier
+}
+
+// NewMockINotifier creates a new mock instance.
+func NewMockINotifier(ctrl *gomock.Controller) *MockINotifier {
+	mock := &MockINotifier{ctrl: ctrl}
+	mock.recorder = &MockINotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockINotifier) EXPECT() *MockINotifierMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockINotifier) Send(ctx context.Context, notification model.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, notification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockINotifierMockRecorder) Send(ctx, notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockINotifier)(nil).Send), ctx, notification)
+}
@@ -0,0 +1,123 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/iotp_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	sql "database/sql"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIOTPRepo is a mock of IOTPRepo interface.
+type MockIOTPRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOTPRepoMockRecorder
+}
+
+// MockIOTPRepoMockRecorder is the mock recorder for MockIOTPRepo.
+type MockIOTPRepoMockRecorder struct {
+	mock *MockIOTPRepo
+}
+
+// NewMockIOTPRepo creates a new mock instance.
+func NewMockIOTPRepo(ctrl *gomock.Controller) *MockIOTPRepo {
+	mock := &MockIOTPRepo{ctrl: ctrl}
+	mock.recorder = &MockIOTPRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOTPRepo) EXPECT() *MockIOTPRepoMockRecorder {
+	return m.recorder
+}
+
+// ConsumeOTP mocks base method.
+func (m *MockIOTPRepo) ConsumeOTP(ctx context.Context, trx *sql.Tx, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeOTP", ctx, trx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeOTP indicates an expected call of ConsumeOTP.
+func (mr *MockIOTPRepoMockRecorder) ConsumeOTP(ctx, trx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOTP", reflect.TypeOf((*MockIOTPRepo)(nil).ConsumeOTP), ctx, trx, id)
+}
+
+// DeleteOTPByAccountID mocks base method.
+func (m *MockIOTPRepo) DeleteOTPByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOTPByAccountID", ctx, trx, accountID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOTPByAccountID indicates an expected call of DeleteOTPByAccountID.
+func (mr *MockIOTPRepoMockRecorder) DeleteOTPByAccountID(ctx, trx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOTPByAccountID", reflect.TypeOf((*MockIOTPRepo)(nil).DeleteOTPByAccountID), ctx, trx, accountID)
+}
+
+// GetLatestOTP mocks base method.
+func (m *MockIOTPRepo) GetLatestOTP(ctx context.Context, accountID int64, purpose string) (model.OTPBaseModel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestOTP", ctx, accountID, purpose)
+	ret0, _ := ret[0].(model.OTPBaseModel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestOTP indicates an expected call of GetLatestOTP.
+func (mr *MockIOTPRepoMockRecorder) GetLatestOTP(ctx, accountID, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestOTP", reflect.TypeOf((*MockIOTPRepo)(nil).GetLatestOTP), ctx, accountID, purpose)
+}
+
+// IncrementOTPAttempt mocks base method.
+func (m *MockIOTPRepo) IncrementOTPAttempt(ctx context.Context, id int64, maxAttempts int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementOTPAttempt", ctx, id, maxAttempts)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementOTPAttempt indicates an expected call of IncrementOTPAttempt.
+func (mr *MockIOTPRepoMockRecorder) IncrementOTPAttempt(ctx, id, maxAttempts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementOTPAttempt", reflect.TypeOf((*MockIOTPRepo)(nil).IncrementOTPAttempt), ctx, id, maxAttempts)
+}
+
+// InsertOTP mocks base method.
+func (m *MockIOTPRepo) InsertOTP(ctx context.Context, trx *sql.Tx, req *model.OTPBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertOTP", ctx, trx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertOTP indicates an expected call of InsertOTP.
+func (mr *MockIOTPRepoMockRecorder) InsertOTP(ctx, trx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertOTP", reflect.TypeOf((*MockIOTPRepo)(nil).InsertOTP), ctx, trx, req)
+}
+
+// InvalidateOTP mocks base method.
+func (m *MockIOTPRepo) InvalidateOTP(ctx context.Context, trx *sql.Tx, accountID int64, purpose string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateOTP", ctx, trx, accountID, purpose)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateOTP indicates an expected call of InvalidateOTP.
+func (mr *MockIOTPRepoMockRecorder) InvalidateOTP(ctx, trx, accountID, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateOTP", reflect.TypeOf((*MockIOTPRepo)(nil).InvalidateOTP), ctx, trx, accountID, purpose)
+}
@@ -36,6 +36,20 @@ func (m *MockIPremiumPackageRepo) EXPECT() *MockIPremiumPackageRepoMockRecorder
 	return m.recorder
 }
 
+// EnsurePremiumPackage mocks base method.
+func (m *MockIPremiumPackageRepo) EnsurePremiumPackage(ctx context.Context, req *model.PremiumPackageBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsurePremiumPackage", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnsurePremiumPackage indicates an expected call of EnsurePremiumPackage.
+func (mr *MockIPremiumPackageRepoMockRecorder) EnsurePremiumPackage(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsurePremiumPackage", reflect.TypeOf((*MockIPremiumPackageRepo)(nil).EnsurePremiumPackage), ctx, req)
+}
+
 // GetListPremiumPackagePagination mocks base method.
 func (m *MockIPremiumPackageRepo) GetListPremiumPackagePagination(ctx context.Context, req model.PaginationRequest) ([]model.PremiumPackageBaseModel, error) {
 	m.ctrl.T.Helper()
@@ -51,6 +65,21 @@ func (mr *MockIPremiumPackageRepoMockRecorder) GetListPremiumPackagePagination(c
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListPremiumPackagePagination", reflect.TypeOf((*MockIPremiumPackageRepo)(nil).GetListPremiumPackagePagination), ctx, req)
 }
 
+// GetListPurchaseByAccountID mocks base method.
+func (m *MockIPremiumPackageRepo) GetListPurchaseByAccountID(ctx context.Context, accountID int64) ([]model.AccountExportPurchase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListPurchaseByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]model.AccountExportPurchase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListPurchaseByAccountID indicates an expected call of GetListPurchaseByAccountID.
+func (mr *MockIPremiumPackageRepoMockRecorder) GetListPurchaseByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListPurchaseByAccountID", reflect.TypeOf((*MockIPremiumPackageRepo)(nil).GetListPurchaseByAccountID), ctx, accountID)
+}
+
 // GetPremiumPackageByPackageUID mocks base method.
 func (m *MockIPremiumPackageRepo) GetPremiumPackageByPackageUID(ctx context.Context, packageUID string) (model.PremiumPackageBaseModel, error) {
 	m.ctrl.T.Helper()
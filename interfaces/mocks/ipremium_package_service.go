@@ -0,0 +1,110 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/ipremium_package_service.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIPremiumPackageService is a mock of IPremiumPackageService interface.
+type MockIPremiumPackageService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPremiumPackageServiceMockRecorder
+}
+
+// MockIPremiumPackageServiceMockRecorder is the mock recorder for MockIPremiumPackageService.
+type MockIPremiumPackageServiceMockRecorder struct {
+	mock *MockIPremiumPackageService
+}
+
+// NewMockIPremiumPackageService creates a new mock instance.
+func NewMockIPremiumPackageService(ctrl *gomock.Controller) *MockIPremiumPackageService {
+	mock := &MockIPremiumPackageService{ctrl: ctrl}
+	mock.recorder = &MockIPremiumPackageServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPremiumPackageService) EXPECT() *MockIPremiumPackageServiceMockRecorder {
+	return m.recorder
+}
+
+// ActivateBoost mocks base method.
+func (m *MockIPremiumPackageService) ActivateBoost(ctx context.Context, accountMaskID string) (model.AccountEffectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateBoost", ctx, accountMaskID)
+	ret0, _ := ret[0].(model.AccountEffectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateBoost indicates an expected call of ActivateBoost.
+func (mr *MockIPremiumPackageServiceMockRecorder) ActivateBoost(ctx, accountMaskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateBoost", reflect.TypeOf((*MockIPremiumPackageService)(nil).ActivateBoost), ctx, accountMaskID)
+}
+
+// GetListAccountEffect mocks base method.
+func (m *MockIPremiumPackageService) GetListAccountEffect(ctx context.Context, accountMaskID string) ([]model.AccountEffectResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListAccountEffect", ctx, accountMaskID)
+	ret0, _ := ret[0].([]model.AccountEffectResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListAccountEffect indicates an expected call of GetListAccountEffect.
+func (mr *MockIPremiumPackageServiceMockRecorder) GetListAccountEffect(ctx, accountMaskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListAccountEffect", reflect.TypeOf((*MockIPremiumPackageService)(nil).GetListAccountEffect), ctx, accountMaskID)
+}
+
+// GetListPremiumPackagePagination mocks base method.
+func (m *MockIPremiumPackageService) GetListPremiumPackagePagination(ctx context.Context, req model.PaginationRequest) (model.ListPackagePagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListPremiumPackagePagination", ctx, req)
+	ret0, _ := ret[0].(model.ListPackagePagination)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListPremiumPackagePagination indicates an expected call of GetListPremiumPackagePagination.
+func (mr *MockIPremiumPackageServiceMockRecorder) GetListPremiumPackagePagination(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListPremiumPackagePagination", reflect.TypeOf((*MockIPremiumPackageService)(nil).GetListPremiumPackagePagination), ctx, req)
+}
+
+// GetWallet mocks base method.
+func (m *MockIPremiumPackageService) GetWallet(ctx context.Context, accountMaskID string) (model.WalletResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWallet", ctx, accountMaskID)
+	ret0, _ := ret[0].(model.WalletResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWallet indicates an expected call of GetWallet.
+func (mr *MockIPremiumPackageServiceMockRecorder) GetWallet(ctx, accountMaskID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWallet", reflect.TypeOf((*MockIPremiumPackageService)(nil).GetWallet), ctx, accountMaskID)
+}
+
+// PremiumPackageCheckout mocks base method.
+func (m *MockIPremiumPackageService) PremiumPackageCheckout(ctx context.Context, req model.PremiumPackageCheckoutRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PremiumPackageCheckout", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PremiumPackageCheckout indicates an expected call of PremiumPackageCheckout.
+func (mr *MockIPremiumPackageServiceMockRecorder) PremiumPackageCheckout(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PremiumPackageCheckout", reflect.TypeOf((*MockIPremiumPackageService)(nil).PremiumPackageCheckout), ctx, req)
+}
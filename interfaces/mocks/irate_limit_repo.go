@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: /Users/dwiangraenihantik/go/src/github.com/dwiangraeni/dealls/interfaces/irate_limit_repo.go
+
+// Package mock_interfaces is a generated GoMock package.
+package mock_interfaces
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/dwiangraeni/dealls/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIRateLimitRepo is a mock of IRateLimitRepo interface.
+type MockIRateLimitRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRateLimitRepoMockRecorder
+}
+
+// MockIRateLimitRepoMockRecorder is the mock recorder for MockIRateLimitRepo.
+type MockIRateLimitRepoMockRecorder struct {
+	mock *MockIRateLimitRepo
+}
+
+// NewMockIRateLimitRepo creates a new mock instance.
+func NewMockIRateLimitRepo(ctrl *gomock.Controller) *MockIRateLimitRepo {
+	mock := &MockIRateLimitRepo{ctrl: ctrl}
+	mock.recorder = &MockIRateLimitRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRateLimitRepo) EXPECT() *MockIRateLimitRepoMockRecorder {
+	return m.recorder
+}
+
+// Allow mocks base method.
+func (m *MockIRateLimitRepo) Allow(ctx context.Context, key string, limit model.RateLimit) (model.RateLimitResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Allow", ctx, key, limit)
+	ret0, _ := ret[0].(model.RateLimitResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Allow indicates an expected call of Allow.
+func (mr *MockIRateLimitRepoMockRecorder) Allow(ctx, key, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Allow", reflect.TypeOf((*MockIRateLimitRepo)(nil).Allow), ctx, key, limit)
+}
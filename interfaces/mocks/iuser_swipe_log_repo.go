@@ -6,6 +6,7 @@ package mock_interfaces
 
 import (
 	context "context"
+	sql "database/sql"
 	reflect "reflect"
 
 	model "github.com/dwiangraeni/dealls/model"
@@ -35,6 +36,36 @@ func (m *MockIUserSwipeLogRepo) EXPECT() *MockIUserSwipeLogRepoMockRecorder {
 	return m.recorder
 }
 
+// GetListMatchByAccountID mocks base method.
+func (m *MockIUserSwipeLogRepo) GetListMatchByAccountID(ctx context.Context, accountID int64) ([]model.AccountExportMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListMatchByAccountID", ctx, accountID)
+	ret0, _ := ret[0].([]model.AccountExportMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListMatchByAccountID indicates an expected call of GetListMatchByAccountID.
+func (mr *MockIUserSwipeLogRepoMockRecorder) GetListMatchByAccountID(ctx, accountID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListMatchByAccountID", reflect.TypeOf((*MockIUserSwipeLogRepo)(nil).GetListMatchByAccountID), ctx, accountID)
+}
+
+// GetListUserSwipeLogBySwiperID mocks base method.
+func (m *MockIUserSwipeLogRepo) GetListUserSwipeLogBySwiperID(ctx context.Context, swiperID int64) ([]model.AccountExportSwipe, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListUserSwipeLogBySwiperID", ctx, swiperID)
+	ret0, _ := ret[0].([]model.AccountExportSwipe)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListUserSwipeLogBySwiperID indicates an expected call of GetListUserSwipeLogBySwiperID.
+func (mr *MockIUserSwipeLogRepoMockRecorder) GetListUserSwipeLogBySwiperID(ctx, swiperID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListUserSwipeLogBySwiperID", reflect.TypeOf((*MockIUserSwipeLogRepo)(nil).GetListUserSwipeLogBySwiperID), ctx, swiperID)
+}
+
 // GetSwipeCountByAccountID mocks base method.
 func (m *MockIUserSwipeLogRepo) GetSwipeCountByAccountID(ctx context.Context, accountMaskID string) (model.SwipeCountBaseModel, error) {
 	m.ctrl.T.Helper()
@@ -66,16 +97,30 @@ func (mr *MockIUserSwipeLogRepoMockRecorder) GetUserSwipeLogBySwiperIDAndSwpeeID
 }
 
 // InsertUserSwipeLog mocks base method.
-func (m *MockIUserSwipeLogRepo) InsertUserSwipeLog(ctx context.Context, req model.UserSwipeLogBaseModel) (model.UserSwipeLogBaseModel, error) {
+func (m *MockIUserSwipeLogRepo) InsertUserSwipeLog(ctx context.Context, trx *sql.Tx, req model.UserSwipeLogBaseModel) (model.UserSwipeLogBaseModel, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "InsertUserSwipeLog", ctx, req)
+	ret := m.ctrl.Call(m, "InsertUserSwipeLog", ctx, trx, req)
 	ret0, _ := ret[0].(model.UserSwipeLogBaseModel)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // InsertUserSwipeLog indicates an expected call of InsertUserSwipeLog.
-func (mr *MockIUserSwipeLogRepoMockRecorder) InsertUserSwipeLog(ctx, req interface{}) *gomock.Call {
+func (mr *MockIUserSwipeLogRepoMockRecorder) InsertUserSwipeLog(ctx, trx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserSwipeLog", reflect.TypeOf((*MockIUserSwipeLogRepo)(nil).InsertUserSwipeLog), ctx, trx, req)
+}
+
+// InsertUserSwipeLogHistory mocks base method.
+func (m *MockIUserSwipeLogRepo) InsertUserSwipeLogHistory(ctx context.Context, req []model.UserSwipeLogBaseModel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertUserSwipeLogHistory", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InsertUserSwipeLogHistory indicates an expected call of InsertUserSwipeLogHistory.
+func (mr *MockIUserSwipeLogRepoMockRecorder) InsertUserSwipeLogHistory(ctx, req interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserSwipeLog", reflect.TypeOf((*MockIUserSwipeLogRepo)(nil).InsertUserSwipeLog), ctx, req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertUserSwipeLogHistory", reflect.TypeOf((*MockIUserSwipeLogRepo)(nil).InsertUserSwipeLogHistory), ctx, req)
 }
@@ -0,0 +1,165 @@
+package logging
+
+import (
+	"context"
+	"encoding/json"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// Redacted replaces the value of every sensitive field
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys field names, compared case-insensitively and without underscores, whose value is never logged.
+// Request structs are logged whole, so the keys are matched at any depth.
+var sensitiveKeys = map[string]bool{
+	"password":        true,
+	"newpassword":     true,
+	"confirmpassword": true,
+	"token":           true,
+	"accesstoken":     true,
+	"refreshtoken":    true,
+	"authorization":   true,
+	"code":            true,
+	"otp":             true,
+	"codehash":        true,
+	"secret":          true,
+}
+
+type Config struct {
+	Level  string // debug, info, warn or error
+	Format string // json or text
+}
+
+// Setup install the default slog logger, the standard log package writes through it as well
+func Setup(cfg Config) {
+	slog.SetDefault(slog.New(NewHandler(os.Stdout, cfg)))
+}
+
+// NewHandler slog handler adding the request id of the context and redacting sensitive fields
+func NewHandler(w io.Writer, cfg Config) slog.Handler {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+
+	var handler slog.Handler
+	if strings.EqualFold(cfg.Format, "text") {
+		handler = slog.NewTextHandler(w, opts)
+	} else {
+		handler = slog.NewJSONHandler(w, opts)
+	}
+
+	return &contextHandler{Handler: handler}
+}
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// contextHandler add request_id, trace_id and span_id to every record logged with a request context.
+// An error logged inside a span also marks the span as failed, services log their errors instead of returning details.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+
+	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", span.SpanContext().TraceID().String()),
+			slog.String("span_id", span.SpanContext().SpanID().String()),
+		)
+
+		if record.Level >= slog.LevelError {
+			span.SetStatus(codes.Error, record.Message)
+		}
+	}
+
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+func isSensitive(key string) bool {
+	return sensitiveKeys[strings.ReplaceAll(strings.ToLower(key), "_", "")]
+}
+
+func redactAttr(_ []string, attr slog.Attr) slog.Attr {
+	if isSensitive(attr.Key) {
+		return slog.String(attr.Key, Redacted)
+	}
+
+	if attr.Value.Kind() != slog.KindAny {
+		return attr
+	}
+
+	value := attr.Value.Any()
+	if _, ok := value.(error); ok {
+		return attr
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice:
+		return slog.Any(attr.Key, redactValue(value))
+	}
+
+	return attr
+}
+
+// redactValue go through the json form of v, so fields hidden with json:"-" stay hidden and the keys match what is logged
+func redactValue(v interface{}) interface{} {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+
+	var decoded interface{}
+	if err = json.Unmarshal(raw, &decoded); err != nil {
+		return v
+	}
+
+	return redactDecoded(decoded)
+}
+
+func redactDecoded(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if isSensitive(key) {
+				value[key] = Redacted
+				continue
+			}
+			value[key] = redactDecoded(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactDecoded(item)
+		}
+	}
+
+	return v
+}
@@ -1,10 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/dwiangraeni/dealls/api"
 	"github.com/dwiangraeni/dealls/infra"
+	"github.com/dwiangraeni/dealls/manager"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/migration"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/schema"
+	"github.com/dwiangraeni/dealls/seed"
+	"github.com/dwiangraeni/dealls/tracing"
+	"github.com/dwiangraeni/dealls/utils"
 	"github.com/urfave/cli"
+	"log/slog"
 	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
 )
 
 const (
@@ -14,15 +29,348 @@ const (
 	AppTagLine = "Dating Dealls Service"
 )
 
+// set at build time, e.g. go build -ldflags "-X main.Commit=$(git rev-parse --short HEAD) -X main.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
 var API = cli.Command{
 	Name:     "api",
 	Usage:    "Run API Server",
 	HideHelp: true,
-	Action: func(ctx *cli.Context) {
-		api.NewServer(infra.New(ctx.GlobalString("config"))).Run()
+	Action: func(ctx *cli.Context) error {
+		inf := infra.New(ctx.GlobalString("config"))
+		defer func() {
+			if err := inf.Close(); err != nil {
+				slog.Error("failed to close infra", "error", err)
+			}
+		}()
+
+		buildInfo := model.BuildInfo{Version: ctx.App.Version, Commit: Commit, BuildTime: BuildTime}
+
+		traceConfig := inf.Config().Sub("tracing")
+		shutdownTracing, err := tracing.Setup(context.Background(), tracing.Config{
+			Exporter:       traceConfig.GetString("exporter"),
+			Endpoint:       traceConfig.GetString("endpoint"),
+			SampleRatio:    traceConfig.GetFloat64("sample_ratio"),
+			ServiceName:    AppName,
+			ServiceVersion: buildInfo.Version,
+		})
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		defer func() {
+			// flush the spans still buffered, bounded so an unreachable collector does not hang the exit
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(flushCtx); err != nil {
+				slog.Error("failed to flush traces", "error", err)
+			}
+		}()
+
+		if err := api.NewServer(inf, buildInfo).Run(); err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+		return nil
 	},
 }
 
+var Seed = cli.Command{
+	Name:  "seed",
+	Usage: "Fill the database with fake accounts, premium packages and swipes for local development",
+	Flags: []cli.Flag{
+		cli.IntFlag{
+			Name:  "accounts",
+			Value: 50,
+			Usage: "Number of accounts",
+		},
+		cli.IntFlag{
+			Name:  "swipes",
+			Value: 20,
+			Usage: "Swipes made by every account",
+		},
+		cli.Float64Flag{
+			Name:  "like-ratio",
+			Value: 0.5,
+			Usage: "Share of swipes that are LIKE",
+		},
+		cli.Float64Flag{
+			Name:  "premium-ratio",
+			Value: 0.2,
+			Usage: "Share of accounts that bought the SWIPE package",
+		},
+		cli.IntFlag{
+			Name:  "days",
+			Value: 30,
+			Usage: "Swipes are spread over this many days before today",
+		},
+		cli.Int64Flag{
+			Name:  "seed",
+			Value: 1,
+			Usage: "Random seed, the same seed gives the same data",
+		},
+		cli.StringFlag{
+			Name:  "password",
+			Value: "Seed-Passw0rd!",
+			Usage: "Password of every seeded account",
+		},
+	},
+	Action: func(ctx *cli.Context) error {
+		inf := infra.New(ctx.GlobalString("config"))
+		defer inf.Close()
+
+		repo := manager.NewRepoManager(inf)
+		seeder := seed.NewSeeder(repo.AccountRepoManager(), repo.AccountProfileRepoManager(), repo.PremiumPackageRepoManager(), repo.UserSwipeLogRepoManager(),
+			repo.TransactionRepoManager(), utils.NewBcryptPasswordHasher())
+
+		plan := seed.NewPlan(seed.Options{
+			Accounts:         ctx.Int("accounts"),
+			SwipesPerAccount: ctx.Int("swipes"),
+			LikeRatio:        ctx.Float64("like-ratio"),
+			PremiumRatio:     ctx.Float64("premium-ratio"),
+			HistoryDays:      ctx.Int("days"),
+			Seed:             ctx.Int64("seed"),
+		}, time.Now().UTC())
+
+		result, err := seeder.Apply(context.Background(), plan, ctx.String("password"))
+		if err != nil {
+			return cli.NewExitError(err.Error(), 1)
+		}
+
+		fmt.Printf("packages: %d, accounts created: %d, accounts skipped: %d, purchases: %d, swipes: %d\n",
+			result.Packages, result.AccountsCreated, result.AccountsSkipped, result.Purchases, result.Swipes)
+		fmt.Printf("log in as seed_user_0001 .. seed_user_%04d with password %q\n", len(plan.Accounts), ctx.String("password"))
+		return nil
+	},
+}
+
+var keyFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "dir",
+		Usage: "Key directory, default to rsa.key_dir in config",
+	},
+	cli.IntFlag{
+		Name:  "bits",
+		Value: 2048,
+		Usage: "RSA key size",
+	},
+}
+
+var Keys = cli.Command{
+	Name:  "keys",
+	Usage: "Manage JWT signing keys",
+	Subcommands: []cli.Command{
+		{
+			Name:  "generate",
+			Usage: "Generate a new signing key pair, it becomes active unless rsa.active_kid is set",
+			Flags: append([]cli.Flag{
+				cli.StringFlag{
+					Name:  "kid",
+					Usage: "Key id, default to current UTC timestamp",
+				},
+			}, keyFlags...),
+			Action: func(ctx *cli.Context) error {
+				dir, err := keyDir(ctx)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+
+				key, err := middleware.GenerateKeyFile(dir, ctx.String("kid"), ctx.Int("bits"))
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+
+				fmt.Printf("generated key %s\n", key.KID)
+				return nil
+			},
+		},
+		{
+			Name:  "rotate",
+			Usage: "Generate a new signing key pair and remove keys older than the last --keep",
+			Flags: append([]cli.Flag{
+				cli.IntFlag{
+					Name:  "keep",
+					Value: 2,
+					Usage: "Number of keys kept for verification, including the new one",
+				},
+				cli.StringFlag{
+					Name:  "active-kid",
+					Usage: "Key that is never removed, default to rsa.active_kid in config",
+				},
+				cli.DurationFlag{
+					Name:  "overlap",
+					Value: 10 * time.Minute,
+					Usage: "Time replicas may keep signing with a replaced key, added to the token ttl before it is removed",
+				},
+			}, keyFlags...),
+			Action: func(ctx *cli.Context) error {
+				dir, err := keyDir(ctx)
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+
+				key, err := middleware.GenerateKeyFile(dir, "", ctx.Int("bits"))
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+				fmt.Printf("generated key %s\n", key.KID)
+
+				activeKID := ctx.String("active-kid")
+				if activeKID == "" && ctx.String("dir") == "" {
+					activeKID = infra.New(ctx.GlobalString("config")).Config().Sub("rsa").GetString("active_kid")
+				}
+
+				removed, err := middleware.PruneKeyFiles(dir, middleware.KeyPrunePolicy{
+					Keep:      ctx.Int("keep"),
+					ActiveKID: activeKID,
+					MinAge:    middleware.AccessTokenTTL + ctx.Duration("overlap"),
+				})
+				if err != nil {
+					return cli.NewExitError(err.Error(), 1)
+				}
+
+				for _, kid := range removed {
+					fmt.Printf("removed key %s\n", kid)
+				}
+				return nil
+			},
+		},
+	},
+}
+
+func keyDir(ctx *cli.Context) (string, error) {
+	if dir := ctx.String("dir"); dir != "" {
+		return dir, nil
+	}
+
+	dir := infra.New(ctx.GlobalString("config")).Config().Sub("rsa").GetString("key_dir")
+	if dir == "" {
+		return "", errors.New("key directory is not set, use --dir or rsa.key_dir")
+	}
+
+	return dir, nil
+}
+
+var Migrate = cli.Command{
+	Name:  "migrate",
+	Usage: "Manage the database schema",
+	Subcommands: []cli.Command{
+		{
+			Name:  "up",
+			Usage: "Apply every pending migration",
+			Action: func(ctx *cli.Context) error {
+				return runMigrator(ctx, func(m migration.Migrator) error {
+					applied, err := m.Up(context.Background())
+					for _, mg := range applied {
+						fmt.Printf("applied %04d_%s\n", mg.Version, mg.Name)
+					}
+					if err == nil && len(applied) == 0 {
+						fmt.Println("no pending migration")
+					}
+					return err
+				})
+			},
+		},
+		{
+			Name:  "down",
+			Usage: "Revert the last applied migrations",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  "steps",
+					Value: 1,
+					Usage: "Number of migrations to revert",
+				},
+			},
+			Action: func(ctx *cli.Context) error {
+				return runMigrator(ctx, func(m migration.Migrator) error {
+					reverted, err := m.Down(context.Background(), ctx.Int("steps"))
+					for _, mg := range reverted {
+						fmt.Printf("reverted %04d_%s\n", mg.Version, mg.Name)
+					}
+					return err
+				})
+			},
+		},
+		{
+			Name:  "status",
+			Usage: "List migrations and whether they are applied",
+			Action: func(ctx *cli.Context) error {
+				return runMigrator(ctx, func(m migration.Migrator) error {
+					statuses, err := m.Status(context.Background())
+					if err != nil {
+						return err
+					}
+
+					w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+					fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+					for _, s := range statuses {
+						status, appliedAt := "pending", ""
+						if s.Applied {
+							status, appliedAt = "applied", s.AppliedAt.Format("2006-01-02 15:04:05")
+						}
+						if s.Modified {
+							status = "modified"
+						}
+						fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", s.Version, s.Name, status, appliedAt)
+					}
+					return w.Flush()
+				})
+			},
+		},
+		{
+			Name:  "version",
+			Usage: "Print the current schema version",
+			Action: func(ctx *cli.Context) error {
+				return runMigrator(ctx, func(m migration.Migrator) error {
+					version, err := m.Version(context.Background())
+					if err != nil {
+						return err
+					}
+
+					fmt.Println(version)
+					return nil
+				})
+			},
+		},
+		{
+			Name:      "baseline",
+			Usage:     "Mark migrations up to a version as applied without running them, for a schema applied by hand",
+			ArgsUsage: "<version>",
+			Action: func(ctx *cli.Context) error {
+				version, err := strconv.ParseInt(ctx.Args().First(), 10, 64)
+				if err != nil {
+					return cli.NewExitError("version is required, e.g. migrate baseline 7", 1)
+				}
+
+				return runMigrator(ctx, func(m migration.Migrator) error {
+					recorded, err := m.Baseline(context.Background(), version)
+					for _, mg := range recorded {
+						fmt.Printf("marked %04d_%s as applied\n", mg.Version, mg.Name)
+					}
+					return err
+				})
+			},
+		},
+	},
+}
+
+func runMigrator(ctx *cli.Context, fn func(m migration.Migrator) error) error {
+	inf := infra.New(ctx.GlobalString("config"))
+	defer inf.Close()
+
+	m, err := migration.NewMigrator(inf.SQLDB(), schema.Migrations)
+	if err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	if err = fn(m); err != nil {
+		return cli.NewExitError(err.Error(), 1)
+	}
+
+	return nil
+}
+
 func main() {
 	app := cli.NewApp()
 	app.Name = AppName
@@ -40,9 +388,14 @@ func main() {
 
 	app.Commands = []cli.Command{
 		API,
+		Seed,
+		Keys,
+		Migrate,
 	}
 
 	app.Flags = append(app.Flags, []cli.Flag{}...)
-	_ = app.Run(os.Args)
+	if err := app.Run(os.Args); err != nil {
+		os.Exit(1)
+	}
 
 }
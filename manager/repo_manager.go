@@ -12,6 +12,16 @@ type RepoManager interface {
 	UserSwipeLogRepoManager() interfaces.IUserSwipeLogRepo
 	PremiumPackageRepoManager() interfaces.IPremiumPackageRepo
 	TransactionRepoManager() interfaces.ITransactionRepo
+	RateLimitRepoManager() interfaces.IRateLimitRepo
+	LoginAttemptRepoManager() interfaces.ILoginAttemptRepo
+	OTPRepoManager() interfaces.IOTPRepo
+	IdentityVerificationRepoManager() interfaces.IIdentityVerificationRepo
+	AccountBlockRepoManager() interfaces.IAccountBlockRepo
+	AccountReportRepoManager() interfaces.IAccountReportRepo
+	AccountExportRepoManager() interfaces.IAccountExportRepo
+	AccountProfileRepoManager() interfaces.IAccountProfileRepo
+	AccountEffectRepoManager() interfaces.IAccountEffectRepo
+	CreditLedgerRepoManager() interfaces.ICreditLedgerRepo
 }
 
 type repoManager struct {
@@ -75,3 +85,148 @@ func (r *repoManager) TransactionRepoManager() interfaces.ITransactionRepo {
 
 	return transactionRepo
 }
+
+// rate limit state is kept in process unless rate_limit.store is postgres, use postgres with more than one replica
+func (r *repoManager) isRateLimitStorePostgres() bool {
+	return r.infra.Config().GetString("rate_limit.store") == "postgres"
+}
+
+var (
+	rateLimitRepoOnce sync.Once
+	rateLimitRepo     interfaces.IRateLimitRepo
+)
+
+func (r *repoManager) RateLimitRepoManager() interfaces.IRateLimitRepo {
+	rateLimitRepoOnce.Do(func() {
+		if r.isRateLimitStorePostgres() {
+			rateLimitRepo = repo.NewRateLimitRepo(r.infra.SQLDB())
+			return
+		}
+
+		rateLimitRepo = repo.NewRateLimitMemoryRepo()
+	})
+
+	return rateLimitRepo
+}
+
+var (
+	loginAttemptRepoOnce sync.Once
+	loginAttemptRepo     interfaces.ILoginAttemptRepo
+)
+
+func (r *repoManager) LoginAttemptRepoManager() interfaces.ILoginAttemptRepo {
+	loginAttemptRepoOnce.Do(func() {
+		if r.isRateLimitStorePostgres() {
+			loginAttemptRepo = repo.NewLoginAttemptRepo(r.infra.SQLDB())
+			return
+		}
+
+		loginAttemptRepo = repo.NewLoginAttemptMemoryRepo()
+	})
+
+	return loginAttemptRepo
+}
+
+var (
+	otpRepoOnce sync.Once
+	otpRepo     interfaces.IOTPRepo
+)
+
+func (r *repoManager) OTPRepoManager() interfaces.IOTPRepo {
+	otpRepoOnce.Do(func() {
+		otpRepo = repo.NewOTPRepo(r.infra.SQLDB())
+	})
+
+	return otpRepo
+}
+
+var (
+	identityVerificationRepoOnce sync.Once
+	identityVerificationRepo     interfaces.IIdentityVerificationRepo
+)
+
+func (r *repoManager) IdentityVerificationRepoManager() interfaces.IIdentityVerificationRepo {
+	identityVerificationRepoOnce.Do(func() {
+		identityVerificationRepo = repo.NewIdentityVerificationRepo(r.infra.SQLDB())
+	})
+
+	return identityVerificationRepo
+}
+
+var (
+	accountBlockRepoOnce sync.Once
+	accountBlockRepo     interfaces.IAccountBlockRepo
+)
+
+func (r *repoManager) AccountBlockRepoManager() interfaces.IAccountBlockRepo {
+	accountBlockRepoOnce.Do(func() {
+		accountBlockRepo = repo.NewAccountBlockRepo(r.infra.SQLDB())
+	})
+
+	return accountBlockRepo
+}
+
+var (
+	accountReportRepoOnce sync.Once
+	accountReportRepo     interfaces.IAccountReportRepo
+)
+
+func (r *repoManager) AccountReportRepoManager() interfaces.IAccountReportRepo {
+	accountReportRepoOnce.Do(func() {
+		accountReportRepo = repo.NewAccountReportRepo(r.infra.SQLDB())
+	})
+
+	return accountReportRepo
+}
+
+var (
+	accountExportRepoOnce sync.Once
+	accountExportRepo     interfaces.IAccountExportRepo
+)
+
+func (r *repoManager) AccountExportRepoManager() interfaces.IAccountExportRepo {
+	accountExportRepoOnce.Do(func() {
+		accountExportRepo = repo.NewAccountExportRepo(r.infra.SQLDB())
+	})
+
+	return accountExportRepo
+}
+
+var (
+	accountProfileRepoOnce sync.Once
+	accountProfileRepo     interfaces.IAccountProfileRepo
+)
+
+func (r *repoManager) AccountProfileRepoManager() interfaces.IAccountProfileRepo {
+	accountProfileRepoOnce.Do(func() {
+		accountProfileRepo = repo.NewAccountProfileRepo(r.infra.SQLDB())
+	})
+
+	return accountProfileRepo
+}
+
+var (
+	accountEffectRepoOnce sync.Once
+	accountEffectRepo     interfaces.IAccountEffectRepo
+)
+
+func (r *repoManager) AccountEffectRepoManager() interfaces.IAccountEffectRepo {
+	accountEffectRepoOnce.Do(func() {
+		accountEffectRepo = repo.NewAccountEffectRepo(r.infra.SQLDB())
+	})
+
+	return accountEffectRepo
+}
+
+var (
+	creditLedgerRepoOnce sync.Once
+	creditLedgerRepo     interfaces.ICreditLedgerRepo
+)
+
+func (r *repoManager) CreditLedgerRepoManager() interfaces.ICreditLedgerRepo {
+	creditLedgerRepoOnce.Do(func() {
+		creditLedgerRepo = repo.NewCreditLedgerRepo(r.infra.SQLDB())
+	})
+
+	return creditLedgerRepo
+}
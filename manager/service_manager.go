@@ -3,18 +3,38 @@ package manager
 import (
 	"github.com/dwiangraeni/dealls/infra"
 	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/metrics"
 	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/migration"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/notifier"
+	"github.com/dwiangraeni/dealls/schema"
 	"github.com/dwiangraeni/dealls/service"
+	"github.com/dwiangraeni/dealls/storage"
 	"github.com/dwiangraeni/dealls/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	"log"
 	"sync"
+	"time"
 )
 
 type ServiceManager interface {
 	AuthService() interfaces.IAuthService
 	AccountService() interfaces.IAccountService
 	AccountManager() middleware.AccountToken
+	KeySet() middleware.KeySet
+	RateLimiter() middleware.RateLimiter
+	OTPService() interfaces.IOTPService
+	VerificationService() interfaces.IVerificationService
+	IdentityVerificationService() interfaces.IIdentityVerificationService
+	ModerationService() interfaces.IModerationService
+	AccountLifecycleService() interfaces.IAccountLifecycleService
+	AccountExportService() interfaces.IAccountExportService
 	UserSwipeLogService() interfaces.IUserSwipeLogService
 	PremiumPackageService() interfaces.IPremiumPackageService
+	HealthService() interfaces.IHealthService
+	MetricsRegistry() *prometheus.Registry
+	Metrics() interfaces.IMetrics
 }
 
 type serviceManager struct {
@@ -36,12 +56,28 @@ var (
 
 func (s *serviceManager) AuthService() interfaces.IAuthService {
 	authServiceOnce.Do(func() {
-		key := s.infra.Config().Sub("rsa")
+		passwordKey := s.infra.Config().Sub("password_policy")
+		passwordPolicy, err := utils.NewPasswordPolicy(passwordKey.GetInt("min_length"), passwordKey.GetString("wordlist"))
+		if err != nil {
+			log.Fatalf("failed to load password policy: %v", err)
+		}
+
+		key := s.infra.Config().Sub("login_attempt")
 		authService = service.NewAuthService(
 			s.repo.AccountRepoManager(),
-			key.GetString("public_key"),
-			key.GetString("private_key"),
-			utils.NewBcryptPasswordHasher())
+			s.repo.LoginAttemptRepoManager(),
+			s.repo.TransactionRepoManager(),
+			s.OTPService(),
+			s.KeySet(),
+			utils.NewBcryptPasswordHasher(),
+			passwordPolicy,
+			model.LoginAttemptPolicy{
+				MaxAttempts: key.GetInt("max_attempts"),
+				Window:      time.Duration(key.GetInt("window")) * time.Second,
+				BaseLockout: time.Duration(key.GetInt("base_lockout")) * time.Second,
+				MaxLockout:  time.Duration(key.GetInt("max_lockout")) * time.Second,
+			},
+			s.Metrics())
 	})
 	return authService
 }
@@ -53,7 +89,8 @@ var (
 
 func (s *serviceManager) AccountService() interfaces.IAccountService {
 	accountServiceOnce.Do(func() {
-		accountService = service.NewAccountService(s.repo.AccountRepoManager())
+		accountService = service.NewAccountService(s.repo.AccountRepoManager(), s.repo.AccountBlockRepoManager(),
+			s.repo.AccountProfileRepoManager(), s.repo.PremiumPackageRepoManager(), s.accountEffectPolicy(), s.Metrics(), s.cursorCodec())
 	})
 	return accountService
 }
@@ -65,13 +102,41 @@ var (
 
 func (s *serviceManager) AccountManager() middleware.AccountToken {
 	accountManagerOnce.Do(func() {
-		key := s.infra.Config().Sub("rsa")
-		accountManager = middleware.NewAccountToken(key.GetString("public_key"))
+		accountManager = middleware.NewAccountToken(s.KeySet())
 	})
 
 	return accountManager
 }
 
+var (
+	keySetOnce sync.Once
+	keySet     middleware.KeySet
+)
+
+func (s *serviceManager) KeySet() middleware.KeySet {
+	keySetOnce.Do(func() {
+		key := s.infra.Config().Sub("rsa")
+
+		// key_dir holds rotated <kid>.pem keys, private_key/public_key is the single key setup
+		if dir := key.GetString("key_dir"); dir != "" {
+			set, err := middleware.NewFileKeySet(dir, key.GetString("active_kid"))
+			if err != nil {
+				log.Fatalf("failed to load rsa keys from %s: %v", dir, err)
+			}
+			keySet = set
+			return
+		}
+
+		signingKey, err := middleware.ParseSigningKey(middleware.DefaultKeyID, key.GetString("private_key"), key.GetString("public_key"))
+		if err != nil {
+			log.Fatalf("failed to parse rsa key: %v", err)
+		}
+		keySet = middleware.NewStaticKeySet(middleware.DefaultKeyID, signingKey)
+	})
+
+	return keySet
+}
+
 var (
 	userSwipeLogServiceOnce sync.Once
 	userSwipeLogService     interfaces.IUserSwipeLogService
@@ -81,7 +146,8 @@ func (s *serviceManager) UserSwipeLogService() interfaces.IUserSwipeLogService {
 	userSwipeLogServiceOnce.Do(func() {
 		key := s.infra.Config().Sub("user_swipe")
 
-		userSwipeLogService = service.NewUserSwipeLogService(s.repo.UserSwipeLogRepoManager(), s.repo.AccountRepoManager(), s.repo.PremiumPackageRepoManager(), key.GetInt("max_swipe_a_day"))
+		userSwipeLogService = service.NewUserSwipeLogService(s.repo.UserSwipeLogRepoManager(), s.repo.AccountRepoManager(), s.repo.PremiumPackageRepoManager(),
+			s.repo.AccountBlockRepoManager(), s.repo.CreditLedgerRepoManager(), s.repo.TransactionRepoManager(), s.Metrics(), key.GetInt("max_swipe_a_day"))
 	})
 	return userSwipeLogService
 }
@@ -93,7 +159,272 @@ var (
 
 func (s *serviceManager) PremiumPackageService() interfaces.IPremiumPackageService {
 	premiumPackageServiceOnce.Do(func() {
-		premiumPackageService = service.NewPremiumPackageService(s.repo.AccountRepoManager(), s.repo.PremiumPackageRepoManager(), s.repo.TransactionRepoManager())
+		premiumPackageService = service.NewPremiumPackageService(s.repo.AccountRepoManager(), s.repo.PremiumPackageRepoManager(),
+			s.repo.AccountEffectRepoManager(), s.repo.CreditLedgerRepoManager(), s.repo.TransactionRepoManager(), s.accountEffectPolicy(), s.Metrics(),
+			s.cursorCodec())
 	})
 	return premiumPackageService
 }
+
+var (
+	rateLimiterOnce sync.Once
+	rateLimiter     middleware.RateLimiter
+)
+
+func (s *serviceManager) RateLimiter() middleware.RateLimiter {
+	rateLimiterOnce.Do(func() {
+		key := s.infra.Config().Sub("rate_limit")
+
+		routeLimits := make(map[string]model.RateLimit)
+		for route := range key.GetStringMap("routes") {
+			routeLimits[route] = model.RateLimit{
+				Rate:  key.GetFloat64("routes." + route + ".rate"),
+				Burst: key.GetInt("routes." + route + ".burst"),
+			}
+		}
+
+		rateLimiter = middleware.NewRateLimiter(
+			s.repo.RateLimitRepoManager(),
+			model.RateLimit{
+				Rate:  key.GetFloat64("default.rate"),
+				Burst: key.GetInt("default.burst"),
+			},
+			routeLimits)
+	})
+
+	return rateLimiter
+}
+
+func (s *serviceManager) accountEffectPolicy() model.AccountEffectPolicy {
+	key := s.infra.Config().Sub("account_effect")
+	return model.AccountEffectPolicy{
+		Durations: map[string]time.Duration{
+			model.AccountEffectBoost:     time.Duration(key.GetInt("boost_duration")) * time.Second,
+			model.AccountEffectIncognito: time.Duration(key.GetInt("incognito_duration")) * time.Second,
+		},
+		BoostRadiusKM: key.GetFloat64("boost_radius_km"),
+		BoostSlots:    key.GetInt("boost_slots"),
+	}
+}
+
+func (s *serviceManager) otpPolicy() model.OTPPolicy {
+	key := s.infra.Config().Sub("otp")
+	return model.OTPPolicy{
+		Length:         key.GetInt("length"),
+		TTL:            time.Duration(key.GetInt("ttl")) * time.Second,
+		MaxAttempts:    key.GetInt("max_attempts"),
+		ResendCooldown: time.Duration(key.GetInt("resend_cooldown")) * time.Second,
+	}
+}
+
+var (
+	otpServiceOnce sync.Once
+	otpService     interfaces.IOTPService
+)
+
+func (s *serviceManager) OTPService() interfaces.IOTPService {
+	otpServiceOnce.Do(func() {
+		secret := s.infra.Config().GetString("otp.secret")
+		if secret == "" {
+			log.Fatalf("otp.secret must be set")
+		}
+
+		var sender interfaces.INotifier
+		key := s.infra.Config().Sub("notifier")
+		switch key.GetString("driver") {
+		case "file":
+			sender = notifier.NewFileNotifier(key.GetString("file_path"))
+		default:
+			sender = notifier.NewLogNotifier()
+		}
+
+		otpService = service.NewOTPService(
+			s.repo.OTPRepoManager(),
+			s.repo.TransactionRepoManager(),
+			sender,
+			utils.NewOTPGenerator(secret),
+			s.otpPolicy())
+	})
+	return otpService
+}
+
+var (
+	verificationServiceOnce sync.Once
+	verificationService     interfaces.IVerificationService
+)
+
+func (s *serviceManager) VerificationService() interfaces.IVerificationService {
+	verificationServiceOnce.Do(func() {
+		verificationService = service.NewVerificationService(
+			s.repo.AccountRepoManager(),
+			s.repo.OTPRepoManager(),
+			s.repo.TransactionRepoManager(),
+			s.OTPService(),
+			s.otpPolicy().TTL)
+	})
+	return verificationService
+}
+
+var (
+	selfieStorageOnce sync.Once
+	selfieStorage     interfaces.IFileStorage
+)
+
+func (s *serviceManager) selfieStorage() interfaces.IFileStorage {
+	selfieStorageOnce.Do(func() {
+		selfieStorage = storage.NewLocalStorage(s.infra.Config().GetString("identity_verification.selfie_dir"))
+	})
+	return selfieStorage
+}
+
+var (
+	cursorCodecOnce sync.Once
+	cursorCodec     utils.CursorCodec
+)
+
+func (s *serviceManager) cursorCodec() utils.CursorCodec {
+	cursorCodecOnce.Do(func() {
+		key := s.infra.Config().Sub("pagination")
+		secret := key.GetString("cursor_secret")
+		if secret == "" {
+			log.Fatalf("pagination.cursor_secret must be set")
+		}
+
+		cursorCodec = utils.NewCursorCodec(secret, time.Duration(key.GetInt("cursor_ttl"))*time.Second)
+	})
+	return cursorCodec
+}
+
+var (
+	identityVerificationServiceOnce sync.Once
+	identityVerificationService     interfaces.IIdentityVerificationService
+)
+
+func (s *serviceManager) IdentityVerificationService() interfaces.IIdentityVerificationService {
+	identityVerificationServiceOnce.Do(func() {
+		identityVerificationService = service.NewIdentityVerificationService(
+			s.repo.AccountRepoManager(),
+			s.repo.PremiumPackageRepoManager(),
+			s.repo.IdentityVerificationRepoManager(),
+			s.repo.TransactionRepoManager(),
+			s.selfieStorage(),
+			model.IdentityVerificationPolicy{MaxSelfieSize: s.infra.Config().GetInt64("identity_verification.max_selfie_size")},
+			s.cursorCodec())
+	})
+	return identityVerificationService
+}
+
+var (
+	moderationServiceOnce sync.Once
+	moderationService     interfaces.IModerationService
+)
+
+func (s *serviceManager) ModerationService() interfaces.IModerationService {
+	moderationServiceOnce.Do(func() {
+		moderationService = service.NewModerationService(
+			s.repo.AccountRepoManager(),
+			s.repo.AccountBlockRepoManager(),
+			s.repo.AccountReportRepoManager(),
+			s.repo.TransactionRepoManager(),
+			s.cursorCodec())
+	})
+	return moderationService
+}
+
+var (
+	accountLifecycleServiceOnce sync.Once
+	accountLifecycleService     interfaces.IAccountLifecycleService
+)
+
+func (s *serviceManager) AccountLifecycleService() interfaces.IAccountLifecycleService {
+	accountLifecycleServiceOnce.Do(func() {
+		accountLifecycleService = service.NewAccountLifecycleService(
+			s.repo.AccountRepoManager(),
+			s.repo.OTPRepoManager(),
+			s.repo.IdentityVerificationRepoManager(),
+			s.repo.AccountExportRepoManager(),
+			s.repo.AccountProfileRepoManager(),
+			s.repo.TransactionRepoManager(),
+			s.selfieStorage(),
+			s.exportStorage(),
+			utils.NewBcryptPasswordHasher())
+	})
+	return accountLifecycleService
+}
+
+var (
+	exportStorageOnce sync.Once
+	exportStorage     interfaces.IFileStorage
+)
+
+func (s *serviceManager) exportStorage() interfaces.IFileStorage {
+	exportStorageOnce.Do(func() {
+		exportStorage = storage.NewLocalStorage(s.infra.Config().GetString("account_export.dir"))
+	})
+	return exportStorage
+}
+
+var (
+	accountExportServiceOnce sync.Once
+	accountExportService     interfaces.IAccountExportService
+)
+
+func (s *serviceManager) AccountExportService() interfaces.IAccountExportService {
+	accountExportServiceOnce.Do(func() {
+		key := s.infra.Config().Sub("account_export")
+		accountExportService = service.NewAccountExportService(
+			s.repo.AccountRepoManager(),
+			s.repo.UserSwipeLogRepoManager(),
+			s.repo.PremiumPackageRepoManager(),
+			s.repo.AccountProfileRepoManager(),
+			s.repo.AccountExportRepoManager(),
+			s.exportStorage(),
+			model.AccountExportPolicy{
+				PollInterval:      time.Duration(key.GetInt("poll_interval")) * time.Second,
+				ProcessingTimeout: time.Duration(key.GetInt("processing_timeout")) * time.Second,
+				TTL:               time.Duration(key.GetInt("ttl")) * time.Second,
+			})
+	})
+	return accountExportService
+}
+
+var (
+	healthServiceOnce sync.Once
+	healthService     interfaces.IHealthService
+)
+
+func (s *serviceManager) HealthService() interfaces.IHealthService {
+	healthServiceOnce.Do(func() {
+		migrator, err := migration.NewMigrator(s.infra.SQLDB(), schema.Migrations)
+		if err != nil {
+			log.Fatalf("failed to load migrations: %v", err)
+		}
+
+		healthService = service.NewHealthService(s.infra.SQLDB(), migrator)
+	})
+	return healthService
+}
+
+var (
+	metricsRegistryOnce sync.Once
+	metricsRegistry     *prometheus.Registry
+)
+
+func (s *serviceManager) MetricsRegistry() *prometheus.Registry {
+	metricsRegistryOnce.Do(func() {
+		metricsRegistry = metrics.NewRegistry(s.infra.SQLDB().DB, s.infra.Config().GetString("postgres.database"))
+	})
+	return metricsRegistry
+}
+
+var (
+	metricsOnce    sync.Once
+	serviceMetrics interfaces.IMetrics
+)
+
+func (s *serviceManager) Metrics() interfaces.IMetrics {
+	metricsOnce.Do(func() {
+		serviceMetrics = metrics.NewPrometheusMetrics(s.MetricsRegistry())
+	})
+	return serviceMetrics
+}
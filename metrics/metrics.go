@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const namespace = "dealls"
+
+// login failure reasons, kept to a fixed set so the label does not grow with user input
+const (
+	LoginFailedInvalidCredentials = "invalid_credentials"
+	LoginFailedLocked             = "locked"
+	LoginFailedSuspended          = "suspended"
+	LoginFailedBanned             = "banned"
+)
+
+type prometheusMetrics struct {
+	swipes        *prometheus.CounterVec
+	swipeQuota    prometheus.Counter
+	checkouts     *prometheus.CounterVec
+	creditSpends  *prometheus.CounterVec
+	loginFailures *prometheus.CounterVec
+	profileViews  prometheus.Counter
+}
+
+// NewPrometheusMetrics register the business counters on reg
+func NewPrometheusMetrics(reg prometheus.Registerer) interfaces.IMetrics {
+	m := &prometheusMetrics{
+		swipes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "swipes_total",
+			Help:      "Swipes recorded by swipe type.",
+		}, []string{"type"}),
+		swipeQuota: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "swipe_quota_rejections_total",
+			Help:      "Swipes rejected because the daily quota of a free account was used up.",
+		}),
+		checkouts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "premium_package_checkouts_total",
+			Help:      "Premium packages bought by package title.",
+		}, []string{"package"}),
+		creditSpends: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "credit_spends_total",
+			Help:      "Credits spent by credit type.",
+		}, []string{"credit_type"}),
+		loginFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "login_failures_total",
+			Help:      "Rejected logins by reason.",
+		}, []string{"reason"}),
+		profileViews: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "profile_views_total",
+			Help:      "Profiles opened by another account.",
+		}),
+	}
+
+	reg.MustRegister(m.swipes, m.swipeQuota, m.checkouts, m.creditSpends, m.loginFailures, m.profileViews)
+	return m
+}
+
+func (m *prometheusMetrics) SwipeRecorded(swipeType string) {
+	m.swipes.WithLabelValues(swipeType).Inc()
+}
+
+func (m *prometheusMetrics) SwipeQuotaRejected() {
+	m.swipeQuota.Inc()
+}
+
+func (m *prometheusMetrics) PackageCheckedOut(packageTitle string) {
+	m.checkouts.WithLabelValues(packageTitle).Inc()
+}
+
+func (m *prometheusMetrics) CreditSpent(creditType string) {
+	m.creditSpends.WithLabelValues(creditType).Inc()
+}
+
+func (m *prometheusMetrics) LoginFailed(reason string) {
+	m.loginFailures.WithLabelValues(reason).Inc()
+}
+
+func (m *prometheusMetrics) ProfileViewed() {
+	m.profileViews.Inc()
+}
+
+type noopMetrics struct{}
+
+// NewNoopMetrics discard every event, for tests and tools that do not serve /metrics
+func NewNoopMetrics() interfaces.IMetrics {
+	return noopMetrics{}
+}
+
+func (noopMetrics) SwipeRecorded(string)     {}
+func (noopMetrics) SwipeQuotaRejected()      {}
+func (noopMetrics) PackageCheckedOut(string) {}
+func (noopMetrics) CreditSpent(string)       {}
+func (noopMetrics) LoginFailed(string)       {}
+func (noopMetrics) ProfileViewed()           {}
@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"database/sql"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+)
+
+// NewRegistry registry served at /metrics with the go runtime, process and database pool metrics
+func NewRegistry(db *sql.DB, dbName string) *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		// sql.DBStats of the pgx pool: open, in use and idle connections, waits and closed connections
+		collectors.NewDBStatsCollector(db, dbName),
+	)
+
+	return reg
+}
+
+func Handler(reg *prometheus.Registry) http.Handler {
+	return promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg})
+}
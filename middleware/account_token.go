@@ -11,26 +11,29 @@ type AccountToken interface {
 }
 
 type accountTokenCtx struct {
-	publicKey string
+	keySet KeySet
 }
 
-// NewToken construct new Token sevice implementation.
-func NewAccountToken(publicKey string) AccountToken {
+// NewAccountToken construct new Token sevice implementation.
+func NewAccountToken(keySet KeySet) AccountToken {
 	return &accountTokenCtx{
-		publicKey: publicKey,
+		keySet: keySet,
 	}
 }
 
 func (c *accountTokenCtx) VerifyAccessToken(ctx context.Context, token string) (*AccessTokenClaim, error) {
 	claim := new(AccessTokenClaim)
 	tok, err := jwt.ParseWithClaims(token, claim, func(token *jwt.Token) (interface{}, error) {
-		return jwt.ParseRSAPublicKeyFromPEM([]byte(c.publicKey))
+		if token.Method.Alg() != jwt.SigningMethodRS256.Alg() {
+			return nil, errors.New("invalid token")
+		}
+
+		kid, _ := token.Header["kid"].(string)
+		return c.keySet.PublicKey(kid)
 	})
 
 	if err != nil || !tok.Valid {
 		return nil, errors.New("invalid token")
-	} else if tok.Method.Alg() != jwt.SigningMethodRS256.Alg() {
-		return nil, errors.New("invalid token")
 	}
 
 	return claim, nil
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+)
+
+// limitedBody a request body capped by LimitBody, keeping the original so a route can set its own limit
+type limitedBody struct {
+	io.ReadCloser
+	original io.ReadCloser
+}
+
+// LimitBody cap request bodies at maxBytes, 0 = unlimited. Reading past the limit fails with *http.MaxBytesError,
+// reported by the handler as 413 BODY_TOO_LARGE. The check happens on read, so a route can still raise its limit
+// with SetBodyLimit before reading the body.
+func LimitBody(maxBytes int64) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if maxBytes <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			SetBodyLimit(w, r, maxBytes)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetBodyLimit replace the body limit of the request, for routes accepting more than the default, e.g. uploads.
+func SetBodyLimit(w http.ResponseWriter, r *http.Request, maxBytes int64) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return
+	}
+
+	body := r.Body
+	if limited, ok := body.(*limitedBody); ok {
+		body = limited.original
+	}
+
+	r.Body = &limitedBody{ReadCloser: http.MaxBytesReader(w, body, maxBytes), original: body}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"github.com/dwiangraeni/dealls/resources/response"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CORSPolicy cross origin access of browser clients.
+type CORSPolicy struct {
+	AllowedOrigins   []string // exact origins, e.g. https://app.dealls.com, "*" allows any origin without credentials
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string // response headers readable by the page besides the CORS safelisted ones
+	AllowCredentials bool
+	MaxAge           time.Duration // how long a browser may cache a preflight answer
+}
+
+// CORS answer preflight requests and add the CORS headers to the responses of allowed origins.
+// Requests from other origins are served without the headers, so the browser keeps the response from the page;
+// their preflights are answered 403.
+func CORS(policy CORSPolicy) func(next http.Handler) http.Handler {
+	allowedOrigins := make(map[string]bool, len(policy.AllowedOrigins))
+	for _, origin := range policy.AllowedOrigins {
+		allowedOrigins[strings.TrimSuffix(strings.TrimSpace(origin), "/")] = true
+	}
+	anyOrigin := allowedOrigins["*"] && !policy.AllowCredentials
+
+	allowedMethods := strings.Join(policy.AllowedMethods, ", ")
+	allowedHeaders := strings.Join(policy.AllowedHeaders, ", ")
+	exposedHeaders := strings.Join(policy.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(policy.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// the answer depends on the origin, shared caches must not hand it to another one
+			w.Header().Add("Vary", "Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+			if !anyOrigin && !allowedOrigins[origin] {
+				if preflight {
+					response.HandleErrorWithCode(w, http.StatusForbidden, response.CodeForbidden, "origin not allowed")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if anyOrigin {
+				w.Header().Set("Access-Control-Allow-Origin", "*")
+			} else {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+			if policy.AllowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if !preflight {
+				if exposedHeaders != "" {
+					w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", allowedMethods)
+			w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+			w.Header().Set("Access-Control-Max-Age", maxAge)
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
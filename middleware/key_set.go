@@ -0,0 +1,386 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/dgrijalva/jwt-go"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultKeyID kid used for keys configured directly as PEM strings.
+	DefaultKeyID = "default"
+
+	keyFileExt        = ".pem"
+	keyReloadInterval = 10 * time.Second
+)
+
+var (
+	ErrNoSigningKey = errors.New("no signing key configured")
+	ErrUnknownKeyID = errors.New("unknown key id")
+)
+
+// SigningKey RSA key pair identified by its kid header.
+type SigningKey struct {
+	KID        string
+	PrivateKey *rsa.PrivateKey
+	PublicKey  *rsa.PublicKey
+}
+
+// JWK public RSA key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// JWKS JSON Web Key Set served on /.well-known/jwks.json.
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// KeySet holds the parsed signing keys, the active one signs new tokens and
+// every key in the set is accepted for verification.
+type KeySet interface {
+	SigningKey() (SigningKey, error)
+	PublicKey(kid string) (*rsa.PublicKey, error)
+	JWKS() JWKS
+}
+
+type staticKeySet struct {
+	activeKID string
+	keys      map[string]SigningKey
+}
+
+// NewStaticKeySet construct key set from already parsed keys.
+func NewStaticKeySet(activeKID string, keys ...SigningKey) KeySet {
+	set := &staticKeySet{
+		activeKID: activeKID,
+		keys:      make(map[string]SigningKey, len(keys)),
+	}
+
+	for _, k := range keys {
+		set.keys[k.KID] = k
+	}
+
+	return set
+}
+
+func (s *staticKeySet) SigningKey() (SigningKey, error) {
+	key, ok := s.keys[s.activeKID]
+	if !ok || key.PrivateKey == nil {
+		return SigningKey{}, ErrNoSigningKey
+	}
+
+	return key, nil
+}
+
+func (s *staticKeySet) PublicKey(kid string) (*rsa.PublicKey, error) {
+	if kid == "" {
+		kid = s.activeKID
+	}
+
+	key, ok := s.keys[kid]
+	if !ok || key.PublicKey == nil {
+		return nil, ErrUnknownKeyID
+	}
+
+	return key.PublicKey, nil
+}
+
+func (s *staticKeySet) JWKS() JWKS {
+	return newJWKS(s.keys)
+}
+
+type fileKeySet struct {
+	dir       string
+	activeKID string
+
+	mu       sync.RWMutex
+	keys     map[string]SigningKey
+	active   string
+	loadedAt time.Time
+}
+
+// NewFileKeySet construct key set from a directory of <kid>.pem private keys.
+// When activeKID is empty the newest kid (in lexical order) signs new tokens.
+func NewFileKeySet(dir, activeKID string) (KeySet, error) {
+	set := &fileKeySet{
+		dir:       dir,
+		activeKID: activeKID,
+	}
+
+	if err := set.load(); err != nil {
+		return nil, err
+	}
+
+	return set, nil
+}
+
+func (f *fileKeySet) load() error {
+	keys, err := ReadKeyDir(f.dir)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: %s is empty", ErrNoSigningKey, f.dir)
+	}
+
+	active := f.activeKID
+	if active == "" {
+		active = keys[len(keys)-1].KID
+	}
+
+	loaded := make(map[string]SigningKey, len(keys))
+	for _, k := range keys {
+		loaded[k.KID] = k
+	}
+
+	if _, ok := loaded[active]; !ok {
+		return fmt.Errorf("%w: active kid %s", ErrUnknownKeyID, active)
+	}
+
+	f.mu.Lock()
+	f.keys = loaded
+	f.active = active
+	f.loadedAt = time.Now()
+	f.mu.Unlock()
+
+	return nil
+}
+
+func (f *fileKeySet) SigningKey() (SigningKey, error) {
+	// another replica may have rotated the keys, a failed reload keeps signing with the loaded key
+	_ = f.reloadIfStale()
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	key, ok := f.keys[f.active]
+	if !ok {
+		return SigningKey{}, ErrNoSigningKey
+	}
+
+	return key, nil
+}
+
+func (f *fileKeySet) PublicKey(kid string) (*rsa.PublicKey, error) {
+	if pub, ok := f.lookup(kid); ok {
+		return pub, nil
+	}
+
+	// the key may have been rotated in by another replica, pick it up from disk
+	if err := f.reloadIfStale(); err != nil {
+		return nil, err
+	}
+
+	if pub, ok := f.lookup(kid); ok {
+		return pub, nil
+	}
+
+	return nil, ErrUnknownKeyID
+}
+
+// reloadIfStale read the key directory again at most once per keyReloadInterval
+func (f *fileKeySet) reloadIfStale() error {
+	f.mu.Lock()
+	if time.Since(f.loadedAt) <= keyReloadInterval {
+		f.mu.Unlock()
+		return nil
+	}
+	// concurrent callers keep using the loaded keys instead of reading the directory again
+	f.loadedAt = time.Now()
+	f.mu.Unlock()
+
+	return f.load()
+}
+
+func (f *fileKeySet) lookup(kid string) (*rsa.PublicKey, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if kid == "" {
+		kid = f.active
+	}
+
+	key, ok := f.keys[kid]
+	return key.PublicKey, ok
+}
+
+func (f *fileKeySet) JWKS() JWKS {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return newJWKS(f.keys)
+}
+
+func newJWKS(keys map[string]SigningKey) JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(keys))}
+	for _, k := range keys {
+		if k.PublicKey == nil {
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, JWK{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			Kid: k.KID,
+			N:   base64.RawURLEncoding.EncodeToString(k.PublicKey.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.PublicKey.E)).Bytes()),
+		})
+	}
+
+	sort.Slice(jwks.Keys, func(i, j int) bool {
+		return jwks.Keys[i].Kid < jwks.Keys[j].Kid
+	})
+
+	return jwks
+}
+
+// ParseSigningKey parse PEM encoded keys, privatePEM may be empty for verify only keys.
+func ParseSigningKey(kid, privatePEM, publicPEM string) (SigningKey, error) {
+	key := SigningKey{KID: kid}
+
+	if privatePEM != "" {
+		priv, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(privatePEM))
+		if err != nil {
+			return key, fmt.Errorf("parse private key %s: %w", kid, err)
+		}
+		key.PrivateKey = priv
+		key.PublicKey = &priv.PublicKey
+	}
+
+	if publicPEM != "" {
+		pub, err := jwt.ParseRSAPublicKeyFromPEM([]byte(publicPEM))
+		if err != nil {
+			return key, fmt.Errorf("parse public key %s: %w", kid, err)
+		}
+		key.PublicKey = pub
+	}
+
+	if key.PublicKey == nil {
+		return key, fmt.Errorf("%w: %s", ErrNoSigningKey, kid)
+	}
+
+	return key, nil
+}
+
+// ReadKeyDir read every <kid>.pem private key in dir, sorted by kid.
+func ReadKeyDir(dir string) ([]SigningKey, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*"+keyFileExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	keys := make([]SigningKey, 0, len(files))
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := ParseSigningKey(strings.TrimSuffix(filepath.Base(file), keyFileExt), string(b), "")
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// GenerateKeyFile create a new RSA key pair and write it as <kid>.pem in dir.
+func GenerateKeyFile(dir, kid string, bits int) (SigningKey, error) {
+	if kid == "" {
+		kid = NewKeyID(time.Now())
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return SigningKey{}, err
+	}
+
+	path := filepath.Join(dir, kid+keyFileExt)
+	if _, err := os.Stat(path); err == nil {
+		return SigningKey{}, fmt.Errorf("key %s already exists", kid)
+	}
+
+	priv, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return SigningKey{}, err
+	}
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)}
+	if err = os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		return SigningKey{}, err
+	}
+
+	return SigningKey{KID: kid, PrivateKey: priv, PublicKey: &priv.PublicKey}, nil
+}
+
+// KeyPrunePolicy which keys PruneKeyFiles must keep.
+type KeyPrunePolicy struct {
+	Keep      int    // newest keys always kept, at least 1
+	ActiveKID string // configured active key, never removed
+	// MinAge a key is kept until it was replaced at least MinAge ago, so the tokens it signed have expired
+	// and every replica has picked up its successor. Set it to the token ttl plus the rotation overlap.
+	MinAge time.Duration
+}
+
+// PruneKeyFiles remove the keys of dir the policy no longer needs and return the removed kids.
+func PruneKeyFiles(dir string, policy KeyPrunePolicy) ([]string, error) {
+	keys, err := ReadKeyDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	keep := policy.Keep
+	if keep < 1 {
+		keep = 1
+	}
+
+	createdAt := make([]time.Time, len(keys))
+	for i, k := range keys {
+		info, err := os.Stat(filepath.Join(dir, k.KID+keyFileExt))
+		if err != nil {
+			return nil, err
+		}
+		createdAt[i] = info.ModTime()
+	}
+
+	var removed []string
+	for i := 0; i < len(keys)-keep; i++ {
+		// keys[i+1] replaced keys[i], which may still be signing or have signed tokens that did not expire yet
+		if keys[i].KID == policy.ActiveKID || time.Since(createdAt[i+1]) < policy.MinAge {
+			continue
+		}
+
+		if err = os.Remove(filepath.Join(dir, keys[i].KID+keyFileExt)); err != nil {
+			return removed, err
+		}
+		removed = append(removed, keys[i].KID)
+	}
+
+	return removed, nil
+}
+
+// NewKeyID kid based on creation time so newer keys sort last.
+func NewKeyID(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
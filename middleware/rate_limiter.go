@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"log/slog"
+	"net"
+	"net/http"
+)
+
+type RateLimiter interface {
+	// Limit token bucket per client ip for the named route, unknown routes use the default limit.
+	Limit(route string) func(next http.Handler) http.Handler
+}
+
+type rateLimiter struct {
+	rateLimitRepo interfaces.IRateLimitRepo
+	defaultLimit  model.RateLimit
+	routeLimits   map[string]model.RateLimit
+}
+
+// NewRateLimiter construct rate limiter middleware.
+func NewRateLimiter(
+	rateLimitRepo interfaces.IRateLimitRepo,
+	defaultLimit model.RateLimit,
+	routeLimits map[string]model.RateLimit,
+) RateLimiter {
+	return &rateLimiter{
+		rateLimitRepo: rateLimitRepo,
+		defaultLimit:  defaultLimit,
+		routeLimits:   routeLimits,
+	}
+}
+
+func (c *rateLimiter) Limit(route string) func(next http.Handler) http.Handler {
+	limit, ok := c.routeLimits[route]
+	if !ok {
+		limit = c.defaultLimit
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if limit.Rate <= 0 || limit.Burst <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := "route:" + route + ":ip:" + ClientIP(r)
+			result, err := c.rateLimitRepo.Allow(r.Context(), key, limit)
+			if err != nil {
+				// fail open, losing the limiter must not take the api down
+				slog.ErrorContext(r.Context(), "failed to take rate limit token", "event", "rateLimiter.Limit", "key", key, "error", err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !result.Allowed {
+				response.HandleTooManyRequests(w, result.RetryAfter, "Too Many Requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientIP remote address of the request without port.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"github.com/dwiangraeni/dealls/resources/response"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// Recoverer turn a panic of a handler into a 500 INTERNAL response and log it with its stack,
+// so one bad request neither drops the connection nor answers with a plain text body.
+func Recoverer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			// the handler asked net/http to abort the response, keep doing so
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			slog.ErrorContext(r.Context(), "panic serving request",
+				"event", "middleware.Recoverer", "method", r.Method, "path", r.URL.Path,
+				"panic", rec, "stack", string(debug.Stack()))
+
+			response.HandleErrorWithCode(w, http.StatusInternalServerError, response.CodeInternal, "error internal")
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
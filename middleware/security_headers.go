@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// apiContentSecurityPolicy the API only answers JSON, nothing it returns may load or run anything.
+// Pages served by a handler, e.g. /dealls/docs, set their own policy.
+const apiContentSecurityPolicy = "default-src 'none'; frame-ancestors 'none'"
+
+// SecurityHeaders set the standard security headers on every response. hstsMaxAge > 0 adds
+// Strict-Transport-Security, only enable it when the API is reached over https.
+func SecurityHeaders(hstsMaxAge time.Duration) func(next http.Handler) http.Handler {
+	hsts := ""
+	if hstsMaxAge > 0 {
+		hsts = "max-age=" + strconv.Itoa(int(hstsMaxAge.Seconds())) + "; includeSubDomains"
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Set("X-Content-Type-Options", "nosniff")
+			header.Set("X-Frame-Options", "DENY")
+			header.Set("Referrer-Policy", "no-referrer")
+			header.Set("Content-Security-Policy", apiContentSecurityPolicy)
+			if hsts != "" {
+				header.Set("Strict-Transport-Security", hsts)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
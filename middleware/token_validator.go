@@ -3,30 +3,59 @@ package middleware
 import (
 	"context"
 	"github.com/dgrijalva/jwt-go"
+	"github.com/dwiangraeni/dealls/model"
 	"github.com/dwiangraeni/dealls/resources/response"
 	"net/http"
 	"strings"
+	"time"
 )
 
+// AccountStatusChecker return the current moderation status of an account,
+// empty when the account no longer exists.
+type AccountStatusChecker interface {
+	GetAccountStatus(ctx context.Context, accountMaskID string) (status string, err error)
+}
+
 type accountValidator struct {
-	tokenService AccountToken
+	tokenService  AccountToken
+	statusChecker AccountStatusChecker
 }
 
 // NewTokenValidator Authorization header token validator.
 func NewTokenValidator(
 	tokenService AccountToken,
+	statusChecker AccountStatusChecker,
 ) *accountValidator {
 	return &accountValidator{
-		tokenService: tokenService,
+		tokenService:  tokenService,
+		statusChecker: statusChecker,
 	}
 }
 
+// AccessTokenTTL lifetime of an access token, signing keys are kept at least this long after they are replaced
+const AccessTokenTTL = 24 * time.Hour
+
 type AccessTokenClaim struct {
 	jwt.StandardClaims
 	AccountMaskID string `json:"account_mask_id"`
 	Name          string `json:"name"`
 	Username      string `json:"username"`
 	AccountType   string `json:"account_type"`
+	Role          string `json:"role"`
+}
+
+// claimContextKey key of the verified claim in the request context, a private type can not collide with other keys
+type claimContextKey struct{}
+
+// WithClaim context carrying the verified claim of the account sending the request.
+func WithClaim(ctx context.Context, claim *AccessTokenClaim) context.Context {
+	return context.WithValue(ctx, claimContextKey{}, claim)
+}
+
+// ClaimFromContext claim stored by RequireAccountToken, ok is false when the request was not authenticated.
+func ClaimFromContext(ctx context.Context) (claim *AccessTokenClaim, ok bool) {
+	claim, ok = ctx.Value(claimContextKey{}).(*AccessTokenClaim)
+	return claim, ok && claim != nil
 }
 
 // RequireAccountToken Validate request to require a valid authorization token from account service.
@@ -48,17 +77,36 @@ func (c *accountValidator) RequireAccountToken() func(next http.Handler) http.Ha
 				jwtString = sp[1] // Authorization: Bearer yourJWThere
 			}
 
+			// any verification failure is a rejection, a request never reaches the handler without a claim
 			claim, err := c.tokenService.VerifyAccessToken(r.Context(), jwtString)
+			if err != nil || claim == nil {
+				response.HandleErrorWithCode(w, http.StatusUnauthorized, response.CodeTokenInvalid, "token invalid")
+				return
+			}
+
+			// moderated, deactivated or deleted accounts lose access immediately, not when their token expires
+			status, err := c.statusChecker.GetAccountStatus(r.Context(), claim.AccountMaskID)
 			if err != nil {
-				if strings.Contains(err.Error(), "invalid token") {
-					response.HandleError(w, http.StatusUnauthorized, "token invalid")
-					return
-				}
+				response.HandleError(w, http.StatusInternalServerError, "error internal")
+				return
+			}
+
+			switch status {
+			case "", model.AccountStatusDeleted:
+				response.HandleErrorWithCode(w, http.StatusUnauthorized, response.CodeTokenInvalid, "token invalid")
+				return
+			case model.AccountStatusDeactivated:
+				response.HandleErrorWithCode(w, http.StatusUnauthorized, response.CodeAccountDeactivated, "account is deactivated, log in again to reactivate it")
+				return
+			case model.AccountStatusSuspended:
+				response.HandleErrorWithCode(w, http.StatusForbidden, response.CodeAccountSuspended, "account is suspended")
+				return
+			case model.AccountStatusBanned:
+				response.HandleErrorWithCode(w, http.StatusForbidden, response.CodeAccountBanned, "account is banned")
+				return
 			}
 
-			ctx := context.WithValue(r.Context(), "token", claim)
-			ctx = context.WithValue(ctx, "jwt", jwtString)
-			r = r.WithContext(ctx)
+			r = r.WithContext(WithClaim(r.Context(), claim))
 
 			next.ServeHTTP(w, r)
 		})
@@ -69,25 +117,45 @@ func (c *accountValidator) RequireAccountToken() func(next http.Handler) http.Ha
 func (c *accountValidator) RequireAccountType(accountType string, nextRoles ...string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			tok := r.Context().Value("token")
-			if tok == nil {
+			claim, ok := ClaimFromContext(r.Context())
+			if !ok {
 				response.HandleError(w, http.StatusUnauthorized, "Unauthorized")
 				return
 			}
 
-			claim, ok := tok.(*AccessTokenClaim)
+			roles := make(map[string]bool, len(nextRoles)+1) // include requiredRole
+			roles[accountType] = true
+			for _, v := range nextRoles {
+				roles[v] = true
+			}
+
+			if !roles[claim.AccountType] {
+				response.HandleError(w, http.StatusForbidden, "Forbidden")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireAccountRole Validate account role (e.g. ADMIN) after RequireAccountToken().
+func (c *accountValidator) RequireAccountRole(role string, nextRoles ...string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claim, ok := ClaimFromContext(r.Context())
 			if !ok {
 				response.HandleError(w, http.StatusUnauthorized, "Unauthorized")
 				return
 			}
 
-			roles := make(map[string]bool, len(nextRoles)+1) // include requiredRole
-			roles[accountType] = true
+			roles := make(map[string]bool, len(nextRoles)+1) // include required role
+			roles[role] = true
 			for _, v := range nextRoles {
 				roles[v] = true
 			}
 
-			if !roles[claim.AccountType] {
+			if !roles[claim.Role] {
 				response.HandleError(w, http.StatusForbidden, "Forbidden")
 				return
 			}
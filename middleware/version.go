@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"github.com/dwiangraeni/dealls/resources/response"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClientVersionHeader version of the app build sending the request, e.g. 2.3.0
+const ClientVersionHeader = "X-Client-Version"
+
+// Deprecation policy of a retired route group.
+type Deprecation struct {
+	DeprecatedAt time.Time // zero = not announced yet, the routes only get a Sunset
+	SunsetAt     time.Time // zero = no end date, the routes keep answering
+	Successor    string    // link to the routes replacing the retired ones, e.g. /dealls/v1
+}
+
+// Deprecated announce on every response that the routes are retired, with Deprecation (RFC 9745), Sunset (RFC 8594)
+// and a successor-version Link. From the sunset on the routes answer 410 API_VERSION_RETIRED.
+func Deprecated(policy Deprecation) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !policy.DeprecatedAt.IsZero() {
+				w.Header().Set("Deprecation", "@"+strconv.FormatInt(policy.DeprecatedAt.Unix(), 10))
+			}
+			if !policy.SunsetAt.IsZero() {
+				w.Header().Set("Sunset", policy.SunsetAt.UTC().Format(http.TimeFormat))
+			}
+			if policy.Successor != "" {
+				w.Header().Set("Link", "<"+policy.Successor+`>; rel="successor-version"`)
+			}
+
+			if !policy.SunsetAt.IsZero() && !time.Now().Before(policy.SunsetAt) {
+				response.HandleErrorWithCode(w, http.StatusGone, response.CodeAPIVersionRetired,
+					"this api version is retired, use "+policy.Successor)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireClientVersion answer 426 CLIENT_UPGRADE_REQUIRED to app builds older than minVersion. Requests without the
+// X-Client-Version header, e.g. from the web or tools, are not checked; an empty minVersion disables the check.
+func RequireClientVersion(minVersion string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientVersion := strings.TrimSpace(r.Header.Get(ClientVersionHeader))
+			if minVersion == "" || clientVersion == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if cmp, ok := CompareVersion(clientVersion, minVersion); !ok || cmp < 0 {
+				response.HandleUpgradeRequired(w, minVersion)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// CompareVersion compare dotted numeric versions (1.4, v2.0.1, 2.0.1-beta), missing parts count as 0.
+// ok is false when a version is not numeric.
+func CompareVersion(a, b string) (cmp int, ok bool) {
+	pa, okA := parseVersion(a)
+	pb, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return 1, true
+		}
+	}
+
+	return 0, true
+}
+
+func parseVersion(version string) ([]int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	// pre-release and build metadata do not take part in the comparison
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	if version == "" {
+		return nil, false
+	}
+
+	parts := strings.Split(version, ".")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, false
+		}
+		numbers[i] = n
+	}
+
+	return numbers, true
+}
@@ -0,0 +1,355 @@
+package migration
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// lockKey pg_advisory_lock key held while migrating, so replicas starting together run one after another
+const lockKey = 7_220_914_101
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	ErrNoDownFile = errors.New("migration has no down file")
+	ErrModified   = errors.New("migration was modified after it was applied")
+)
+
+const (
+	createTableQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		"version"    bigint       NOT NULL,
+		"name"       varchar(255) NOT NULL,
+		"checksum"   varchar(64)  NOT NULL,
+		"applied_at" timestamp    NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+		PRIMARY KEY ("version")
+	);`
+	tableExistsQuery   = `SELECT to_regclass('schema_migrations') IS NOT NULL;`
+	selectAppliedQuery = `SELECT version, checksum, applied_at FROM schema_migrations ORDER BY version ASC;`
+	insertAppliedQuery = `INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4);`
+	deleteAppliedQuery = `DELETE FROM schema_migrations WHERE version = $1;`
+)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // sha256 of the up file
+}
+
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+	Modified  bool // the up file changed since it was applied
+}
+
+type Migrator interface {
+	// Up apply every pending migration in version order
+	Up(ctx context.Context) ([]Migration, error)
+	// Down revert the last steps applied migrations
+	Down(ctx context.Context, steps int) ([]Migration, error)
+	// Baseline record migrations up to version as applied without running them, for databases migrated by hand
+	Baseline(ctx context.Context, version int64) ([]Migration, error)
+	Status(ctx context.Context) ([]Status, error)
+	// Version highest applied migration, 0 when none is applied
+	Version(ctx context.Context) (int64, error)
+}
+
+type migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *sqlx.DB, fsys fs.FS) (Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	return &migrator{db: db, migrations: migrations}, nil
+}
+
+// Load read <version>_<name>.up.sql and .down.sql files from the root of fsys, sorted by version
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version %s: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has two names: %s and %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			sum := sha256.Sum256(content)
+			m.Up = string(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+func (m *migrator) Up(ctx context.Context) (applied []Migration, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		statuses, err := m.status(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+			if s.Modified {
+				return fmt.Errorf("%w: %d_%s", ErrModified, s.Version, s.Name)
+			}
+		}
+
+		for _, s := range statuses {
+			if s.Applied {
+				continue
+			}
+
+			if err = m.apply(ctx, conn, s.Migration, s.Up, true); err != nil {
+				return err
+			}
+			applied = append(applied, s.Migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+func (m *migrator) Down(ctx context.Context, steps int) (reverted []Migration, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		statuses, err := m.status(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+			s := statuses[i]
+			if !s.Applied {
+				continue
+			}
+
+			if s.Down == "" {
+				return fmt.Errorf("%w: %d_%s", ErrNoDownFile, s.Version, s.Name)
+			}
+
+			if err = m.apply(ctx, conn, s.Migration, s.Down, false); err != nil {
+				return err
+			}
+			reverted = append(reverted, s.Migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+func (m *migrator) Baseline(ctx context.Context, version int64) (recorded []Migration, err error) {
+	err = m.withLock(ctx, func(conn *sql.Conn) error {
+		statuses, err := m.status(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+			if s.Applied || s.Version > version {
+				continue
+			}
+
+			if _, err = conn.ExecContext(ctx, insertAppliedQuery, s.Version, s.Name, s.Checksum, time.Now().UTC()); err != nil {
+				return err
+			}
+			recorded = append(recorded, s.Migration)
+		}
+
+		return nil
+	})
+
+	return recorded, err
+}
+
+func (m *migrator) Status(ctx context.Context) (statuses []Status, err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return m.status(ctx, conn)
+}
+
+func (m *migrator) Version(ctx context.Context) (version int64, err error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, s := range statuses {
+		if s.Applied && s.Version > version {
+			version = s.Version
+		}
+	}
+
+	return version, nil
+}
+
+// withLock run fn on a single connection holding the advisory lock, session locks belong to the connection
+func (m *migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) (err error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err = conn.ExecContext(ctx, `SELECT pg_advisory_lock($1);`, lockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer func() {
+		// a fresh context so the lock is released even when ctx was cancelled
+		if _, errUnlock := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1);`, lockKey); errUnlock != nil && err == nil {
+			err = fmt.Errorf("failed to release migration lock: %w", errUnlock)
+		}
+	}()
+
+	if _, err = conn.ExecContext(ctx, createTableQuery); err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+// apply run one migration file and record it in the same transaction
+func (m *migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, query string, up bool) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, query); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, insertAppliedQuery, migration.Version, migration.Name, migration.Checksum, time.Now().UTC())
+	} else {
+		_, err = tx.ExecContext(ctx, deleteAppliedQuery, migration.Version)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (m *migrator) status(ctx context.Context, conn *sql.Conn) ([]Status, error) {
+	// a database never migrated has no table yet, every migration is pending
+	var exists bool
+	if err := conn.QueryRowContext(ctx, tableExistsQuery).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if !exists {
+		return Compare(m.migrations, func(int64) (string, time.Time, bool) { return "", time.Time{}, false }), nil
+	}
+
+	rows, err := conn.QueryContext(ctx, selectAppliedQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	type applied struct {
+		checksum  string
+		appliedAt time.Time
+	}
+	appliedByVersion := make(map[int64]applied)
+	for rows.Next() {
+		var (
+			version int64
+			a       applied
+		)
+		if err = rows.Scan(&version, &a.checksum, &a.appliedAt); err != nil {
+			return nil, err
+		}
+		appliedByVersion[version] = a
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return Compare(m.migrations, func(version int64) (checksum string, appliedAt time.Time, ok bool) {
+		a, ok := appliedByVersion[version]
+		return a.checksum, a.appliedAt, ok
+	}), nil
+}
+
+// Compare status of every migration against the applied ones reported by lookup
+func Compare(migrations []Migration, lookup func(version int64) (checksum string, appliedAt time.Time, ok bool)) []Status {
+	statuses := make([]Status, 0, len(migrations))
+	for _, migration := range migrations {
+		s := Status{Migration: migration}
+		if checksum, appliedAt, ok := lookup(migration.Version); ok {
+			s.Applied = true
+			s.AppliedAt = appliedAt
+			s.Modified = checksum != migration.Checksum
+		}
+		statuses = append(statuses, s)
+	}
+
+	return statuses
+}
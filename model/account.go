@@ -6,26 +6,45 @@ import (
 )
 
 type AccountBaseModel struct {
-	ID            int64          `db:"id"`
-	AccountMaskID string         `db:"account_mask_id"`
-	Type          string         `db:"type"`
-	Name          string         `db:"name"`
-	UserName      string         `db:"user_name"`
-	Password      string         `db:"password"`
-	IsVerified    bool           `db:"is_verified"`
-	CreatedAt     time.Time      `db:"created_at"`
-	CreatedBy     string         `db:"created_by"`
-	UpdatedAt     time.Time      `db:"updated_at"`
-	UpdatedBy     sql.NullString `db:"updated_by"`
+	ID               int64           `db:"id"`
+	AccountMaskID    string          `db:"account_mask_id"`
+	Type             string          `db:"type"`
+	Role             string          `db:"role"`
+	Status           string          `db:"status"`
+	SuspendedUntil   sql.NullTime    `db:"suspended_until"`
+	ReactivateStatus sql.NullString  `db:"reactivate_status"`
+	IsHidden         bool            `db:"is_hidden"`
+	DeletedAt        sql.NullTime    `db:"deleted_at"`
+	Name             string          `db:"name"`
+	UserName         string          `db:"user_name"`
+	Password         string          `db:"password"`
+	IsVerified       bool            `db:"is_verified"`
+	Email            sql.NullString  `db:"email"`
+	Phone            sql.NullString  `db:"phone"`
+	EmailVerifiedAt  sql.NullTime    `db:"email_verified_at"`
+	PhoneVerifiedAt  sql.NullTime    `db:"phone_verified_at"`
+	Bio              sql.NullString  `db:"bio"`
+	BirthDate        sql.NullTime    `db:"birth_date"`
+	Latitude         sql.NullFloat64 `db:"latitude"`
+	Longitude        sql.NullFloat64 `db:"longitude"`
+	HideAge          bool            `db:"hide_age"`
+	HideDistance     bool            `db:"hide_distance"`
+	HideVisits       bool            `db:"hide_visits"`
+	CreatedAt        time.Time       `db:"created_at"`
+	CreatedBy        string          `db:"created_by"`
+	UpdatedAt        time.Time       `db:"updated_at"`
+	UpdatedBy        sql.NullString  `db:"updated_by"`
 }
 
 type PaginationRequest struct {
-	Keywords      string `json:"q"`
-	Cursor        string `json:"cursor"`
-	Direction     string `json:"direction" valid:"optional,in(next|prev)"`
-	Limit         int    `json:"limit" valid:"required"`
-	CursorID      int64  `json:"-"`
-	AccountMaskID string `json:"-"`
+	Keywords      string  `json:"q"`
+	Cursor        string  `json:"cursor"`
+	Direction     string  `json:"direction" valid:"optional,in(next|prev)"`
+	Limit         int     `json:"limit" valid:"required"`
+	CursorID      int64   `json:"-"`
+	AccountMaskID string  `json:"-"`
+	BoostRadiusKM float64 `json:"-"` // match list only, 0 = boosted accounts are not put first
+	ExcludeIDs    []int64 `json:"-"` // match list only, the boosted accounts put on top of the first page
 }
 
 type AccountResponse struct {
@@ -34,6 +53,7 @@ type AccountResponse struct {
 	Name          string `json:"name"`
 	UserName      string `json:"user_name"`
 	IsVerified    bool   `json:"is_verified"`
+	IsBoosted     bool   `json:"is_boosted"`
 }
 
 type ListAccountPagination struct {
@@ -49,3 +69,54 @@ type PremiumPackageCheckoutRequest struct {
 	AccountMaskID string `json:"-" valid:"required"`
 	PackageUID    string `json:"package_uid" valid:"required"`
 }
+
+// EffectiveStatus status after an expired suspension is lifted, without touching the database.
+func (a AccountBaseModel) EffectiveStatus(now time.Time) string {
+	if a.Status == AccountStatusSuspended && a.SuspendedUntil.Valid && !now.Before(a.SuspendedUntil.Time) {
+		return AccountStatusActive
+	}
+
+	return a.Status
+}
+
+// Reactivated account as it is after its owner logs in again, the status held in reactivate_status
+// (ACTIVE when none) replaces DEACTIVATED. Other statuses are returned unchanged.
+func (a AccountBaseModel) Reactivated() AccountBaseModel {
+	if a.Status != AccountStatusDeactivated {
+		return a
+	}
+
+	a.Status = AccountStatusActive
+	if a.ReactivateStatus.Valid {
+		a.Status = a.ReactivateStatus.String
+	}
+	a.ReactivateStatus = sql.NullString{}
+
+	return a
+}
+
+// CanSignIn account status allows login, a deactivated account is reactivated by logging in.
+func (a AccountBaseModel) CanSignIn(now time.Time) bool {
+	switch a.Reactivated().EffectiveStatus(now) {
+	case AccountStatusSuspended, AccountStatusBanned, AccountStatusDeleted:
+		return false
+	}
+
+	return true
+}
+
+type UpdateDiscoveryRequest struct {
+	AccountMaskID string `json:"-" valid:"required"`
+	Hidden        bool   `json:"hidden"`
+}
+
+type DeleteAccountRequest struct {
+	AccountMaskID string `json:"-" valid:"required"`
+	Password      string `json:"password" valid:"required~password is required"`
+}
+
+type AccountSettingResponse struct {
+	AccountMaskID string `json:"account_mask_id"`
+	Status        string `json:"status"`
+	Hidden        bool   `json:"hidden"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type AccountEffectBaseModel struct {
+	ID               int64         `db:"id"`
+	AccountID        int64         `db:"account_id"`
+	PremiumPackageID sql.NullInt64 `db:"premium_package_id"` // not set for a boost started with a credit
+	EffectType       string        `db:"effect_type"`
+	StartsAt         time.Time     `db:"starts_at"`
+	EndsAt           time.Time     `db:"ends_at"`
+	CreatedAt        time.Time     `db:"created_at"`
+}
+
+// IsActiveAt the effect is running on now
+func (a AccountEffectBaseModel) IsActiveAt(now time.Time) bool {
+	return !now.Before(a.StartsAt) && now.Before(a.EndsAt)
+}
+
+// AccountEffectPolicy how long the effects last and how boosted accounts are placed in the match list
+type AccountEffectPolicy struct {
+	Durations     map[string]time.Duration // by effect type, a package of that title without credits starts the effect on purchase
+	BoostRadiusKM float64                  // boosted accounts come first for viewers within this distance, 0 = off
+	BoostSlots    int                      // boosted accounts put on top of the first page
+}
+
+// EffectDuration how long one effect of the type lasts, ok is false when the effect is not offered
+func (a AccountEffectPolicy) EffectDuration(title string) (duration time.Duration, ok bool) {
+	duration, ok = a.Durations[title]
+	return duration, ok && duration > 0
+}
+
+type AccountEffectResponse struct {
+	EffectType string    `json:"effect_type"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	IsActive   bool      `json:"is_active"` // false for a purchase queued after the running effect
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type AccountExportBaseModel struct {
+	ID          int64          `db:"id"`
+	ExportUID   string         `db:"export_uid"`
+	AccountID   int64          `db:"account_id"`
+	Status      string         `db:"status"`
+	FilePath    string         `db:"file_path"`
+	Error       sql.NullString `db:"error"`
+	StartedAt   sql.NullTime   `db:"started_at"`
+	CompletedAt sql.NullTime   `db:"completed_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+
+	// joined from account
+	AccountMaskID string `db:"account_mask_id"`
+}
+
+type AccountExportPolicy struct {
+	PollInterval      time.Duration // how often the worker looks for queued jobs
+	ProcessingTimeout time.Duration // a job still processing after this is picked up again
+	TTL               time.Duration // how long a finished archive can be downloaded
+}
+
+type AccountExportResponse struct {
+	ExportUID   string     `json:"export_uid"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	DownloadURL string     `json:"download_url,omitempty"`
+}
+
+// AccountExportProfile profile.json of the archive
+type AccountExportProfile struct {
+	AccountMaskID   string                 `json:"account_mask_id"`
+	Type            string                 `json:"type"`
+	Role            string                 `json:"role"`
+	Status          string                 `json:"status"`
+	Name            string                 `json:"name"`
+	UserName        string                 `json:"user_name"`
+	IsVerified      bool                   `json:"is_verified"`
+	IsHidden        bool                   `json:"is_hidden"`
+	Email           string                 `json:"email,omitempty"`
+	Phone           string                 `json:"phone,omitempty"`
+	EmailVerifiedAt *time.Time             `json:"email_verified_at,omitempty"`
+	PhoneVerifiedAt *time.Time             `json:"phone_verified_at,omitempty"`
+	Bio             string                 `json:"bio,omitempty"`
+	BirthDate       string                 `json:"birth_date,omitempty"`
+	Latitude        *float64               `json:"latitude,omitempty"`
+	Longitude       *float64               `json:"longitude,omitempty"`
+	HideAge         bool                   `json:"hide_age"`
+	HideDistance    bool                   `json:"hide_distance"`
+	HideVisits      bool                   `json:"hide_visits"`
+	Photos          []AccountPhotoResponse `json:"photos"`
+	Interests       []string               `json:"interests"`
+	CreatedAt       time.Time              `json:"created_at"`
+	UpdatedAt       time.Time              `json:"updated_at"`
+}
+
+// AccountExportSwipe one row of swipes.json
+type AccountExportSwipe struct {
+	SwipeeAccountMaskID string    `json:"swipee_account_mask_id" db:"swipee_mask_id"`
+	SwipeType           string    `json:"swipe_type" db:"swipe_type"`
+	SuperLike           bool      `json:"super_like" db:"super_like"`
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+}
+
+// AccountExportMatch one row of matches.json, a match is a LIKE in both directions
+type AccountExportMatch struct {
+	AccountMaskID string    `json:"account_mask_id" db:"account_mask_id"`
+	Name          string    `json:"name" db:"name"`
+	MatchedAt     time.Time `json:"matched_at" db:"matched_at"`
+}
+
+// AccountExportPurchase one row of purchases.json
+type AccountExportPurchase struct {
+	PackageUID    string    `json:"package_uid" db:"package_uid"`
+	Title         string    `json:"title" db:"title"`
+	Price         float64   `json:"price" db:"price"`
+	PurchasedDate time.Time `json:"purchased_date" db:"purchased_date"`
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"time"
+)
+
+type AccountPhotoBaseModel struct {
+	ID        int64     `db:"id"`
+	AccountID int64     `db:"account_id"`
+	URL       string    `db:"url"`
+	Position  int       `db:"position"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type ProfileViewBaseModel struct {
+	ID        int64     `db:"id"`
+	ViewerID  int64     `db:"viewer_id"`
+	ViewedID  int64     `db:"viewed_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type GetAccountProfileRequest struct {
+	AccountMaskID       string `json:"-" valid:"required"` // viewer
+	TargetAccountMaskID string `json:"-" valid:"required"`
+}
+
+type AccountProfileResponse struct {
+	AccountMaskID string                 `json:"account_mask_id"`
+	Type          string                 `json:"type"`
+	Name          string                 `json:"name"`
+	UserName      string                 `json:"user_name"`
+	IsVerified    bool                   `json:"is_verified"`
+	Bio           string                 `json:"bio"`
+	Age           *int                   `json:"age,omitempty"`         // absent when unknown or hidden by the owner
+	DistanceKM    *int                   `json:"distance_km,omitempty"` // absent when unknown or hidden by the owner
+	Photos        []AccountPhotoResponse `json:"photos"`
+	Interests     []string               `json:"interests"`
+}
+
+type AccountPhotoResponse struct {
+	URL      string `json:"url"`
+	Position int    `json:"position"`
+}
+
+// UpdateProfilePrivacyRequest a field left out keeps its current value
+type UpdateProfilePrivacyRequest struct {
+	AccountMaskID string `json:"-" valid:"required"`
+	HideAge       *bool  `json:"hide_age"`
+	HideDistance  *bool  `json:"hide_distance"`
+	HideVisits    *bool  `json:"hide_visits"` // views are not recorded, the account never shows up as a visitor
+}
+
+type ProfilePrivacyResponse struct {
+	AccountMaskID string `json:"account_mask_id"`
+	HideAge       bool   `json:"hide_age"`
+	HideDistance  bool   `json:"hide_distance"`
+	HideVisits    bool   `json:"hide_visits"`
+}
+
+// AgeAt age in full years on now, ok is false when the birth date is unknown.
+func (a AccountBaseModel) AgeAt(now time.Time) (age int, ok bool) {
+	if !a.BirthDate.Valid {
+		return 0, false
+	}
+
+	birth := a.BirthDate.Time
+	age = now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+
+	return age, true
+}
+
+// HasLocation the account shared its last known location
+func (a AccountBaseModel) HasLocation() bool {
+	return a.Latitude.Valid && a.Longitude.Valid
+}
+
+// IsProfileVisible other accounts can open the profile: it is not hidden from discovery and not
+// deactivated, deleted or moderated away.
+func (a AccountBaseModel) IsProfileVisible(now time.Time) bool {
+	if a.IsHidden {
+		return false
+	}
+
+	switch a.EffectiveStatus(now) {
+	case AccountStatusActive, AccountStatusWarned:
+		return true
+	}
+
+	return false
+}
+
+// ProfileVisitorBaseModel a recorded profile view joined with the viewer
+type ProfileVisitorBaseModel struct {
+	ID            int64     `db:"id"`
+	ViewedAt      time.Time `db:"created_at"`
+	AccountMaskID string    `db:"account_mask_id"`
+	Type          string    `db:"type"`
+	Name          string    `db:"name"`
+	UserName      string    `db:"user_name"`
+	IsVerified    bool      `db:"is_verified"`
+}
+
+type ListProfileVisitorRequest struct {
+	AccountMaskID string `json:"-" valid:"required"`
+	Cursor        string `json:"cursor"`
+	Direction     string `json:"direction" valid:"optional,in(next|prev)"`
+	Limit         int    `json:"limit" valid:"required"`
+	CursorID      int64  `json:"-"`
+	ViewedID      int64  `json:"-"`
+}
+
+type ProfileVisitorResponse struct {
+	AccountMaskID string    `json:"account_mask_id"`
+	Type          string    `json:"type"`
+	Name          string    `json:"name"`
+	UserName      string    `json:"user_name"`
+	IsVerified    bool      `json:"is_verified"`
+	ViewedAt      time.Time `json:"viewed_at"`
+}
+
+type ListProfileVisitorPagination struct {
+	Unlocked   bool                     `json:"unlocked"` // false without the VISITORS package, Data is then always empty
+	Total      int64                    `json:"total"`    // visits counted once per viewer per day
+	Data       []ProfileVisitorResponse `json:"data"`
+	LoadMore   bool                     `json:"load_more"`
+	NextCursor string                   `json:"next_cursor"`
+	PrevCursor string                   `json:"prev_cursor"`
+	Limit      int                      `json:"limit"`
+}
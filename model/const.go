@@ -4,6 +4,67 @@ const (
 	AccountTypePremium = "PREMIUM"
 	AccountTypeFree    = "FREE"
 
+	AccountRoleUser  = "USER"
+	AccountRoleAdmin = "ADMIN"
+
+	AccountStatusActive    = "ACTIVE"
+	AccountStatusWarned    = "WARNED"
+	AccountStatusSuspended = "SUSPENDED"
+	AccountStatusBanned    = "BANNED"
+
+	AccountStatusDeactivated = "DEACTIVATED"
+	AccountStatusDeleted     = "DELETED"
+
+	DeletedAccountName = "Deleted User"
+
 	PremiumPackageSwipe    = "SWIPE"
 	PremiumPackageVerified = "VERIFIED"
+	PremiumPackageVisitors = "VISITORS"
+
+	// consumable packages, every purchase starts a time boxed effect of the same name
+	PremiumPackageIncognito = "INCOGNITO"
+
+	// credit packages, every purchase tops up the credits of the package
+	PremiumPackageBoost     = "BOOST"
+	PremiumPackageSuperLike = "SUPER_LIKE"
+	PremiumPackageSwipePack = "SWIPE_PACK"
+
+	CreditTypeSuperLike = "SUPER_LIKE" // a LIKE marked as a super like
+	CreditTypeBoost     = "BOOST"      // starts a BOOST effect
+	CreditTypeSwipe     = "SWIPE"      // one swipe over the daily quota of a free account
+
+	CreditReasonPurchase = "PURCHASE"
+	CreditReasonSpend    = "SPEND"
+
+	SwipeTypeLike      = "LIKE"
+	SwipeTypeSuperLike = "SUPER_LIKE" // stored as a LIKE with super_like set
+
+	AccountEffectBoost     = "BOOST"     // first in the match list of nearby accounts
+	AccountEffectIncognito = "INCOGNITO" // only in the match list of accounts it liked
+
+	ContactChannelEmail = "email"
+	ContactChannelPhone = "phone"
+
+	OTPPurposeVerifyEmail   = "VERIFY_EMAIL"
+	OTPPurposeVerifyPhone   = "VERIFY_PHONE"
+	OTPPurposeResetPassword = "RESET_PASSWORD"
+
+	IdentityVerificationPending  = "PENDING"
+	IdentityVerificationApproved = "APPROVED"
+	IdentityVerificationRejected = "REJECTED"
+
+	ReportStatusOpen      = "OPEN"
+	ReportStatusResolved  = "RESOLVED"
+	ReportStatusDismissed = "DISMISSED"
+
+	ModerationActionWarn    = "WARN"
+	ModerationActionSuspend = "SUSPEND"
+	ModerationActionBan     = "BAN"
+	ModerationActionDismiss = "DISMISS"
+
+	AccountExportPending    = "PENDING"
+	AccountExportProcessing = "PROCESSING"
+	AccountExportDone       = "DONE"
+	AccountExportFailed     = "FAILED"
+	AccountExportExpired    = "EXPIRED" // response only, a DONE archive past its ttl
 )
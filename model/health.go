@@ -0,0 +1,14 @@
+package model
+
+// BuildInfo version of the running binary, commit and build time are injected with -ldflags at build time
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+}
+
+// Readiness result of the readiness checks, Checks holds "ok" or the failure of every check by name
+type Readiness struct {
+	Ready  bool              `json:"ready"`
+	Checks map[string]string `json:"checks"`
+}
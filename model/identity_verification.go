@@ -0,0 +1,69 @@
+package model
+
+import (
+	"database/sql"
+	"io"
+	"time"
+)
+
+type IdentityVerificationBaseModel struct {
+	ID              int64          `db:"id"`
+	VerificationUID string         `db:"verification_uid"`
+	AccountID       int64          `db:"account_id"`
+	SelfiePath      string         `db:"selfie_path"`
+	Status          string         `db:"status"`
+	Reason          sql.NullString `db:"reason"`
+	ReviewedBy      sql.NullString `db:"reviewed_by"`
+	ReviewedAt      sql.NullTime   `db:"reviewed_at"`
+	CreatedAt       time.Time      `db:"created_at"`
+
+	// joined from account
+	AccountMaskID string `db:"account_mask_id"`
+	Name          string `db:"name"`
+	UserName      string `db:"user_name"`
+}
+
+type IdentityVerificationPolicy struct {
+	MaxSelfieSize int64 // bytes
+}
+
+type SubmitIdentityVerificationRequest struct {
+	AccountMaskID string    `valid:"required"`
+	Size          int64     `valid:"-"`
+	Selfie        io.Reader `valid:"-"`
+}
+
+type ListIdentityVerificationRequest struct {
+	Status    string `json:"status" valid:"optional,in(PENDING|APPROVED|REJECTED)"`
+	Cursor    string `json:"cursor"`
+	Direction string `json:"direction" valid:"optional,in(next|prev)"`
+	Limit     int    `json:"limit" valid:"required"`
+	CursorID  int64  `json:"-"`
+}
+
+type ReviewIdentityVerificationRequest struct {
+	VerificationUID string `json:"-" valid:"required"`
+	ReviewedBy      string `json:"-" valid:"required"`
+	Approve         bool   `json:"-"`
+	Reason          string `json:"reason" valid:"optional,runelength(1|500)~reason must be at most 500 characters"`
+}
+
+type IdentityVerificationResponse struct {
+	VerificationUID string     `json:"verification_uid"`
+	AccountMaskID   string     `json:"account_mask_id,omitempty"`
+	Name            string     `json:"name,omitempty"`
+	UserName        string     `json:"user_name,omitempty"`
+	Status          string     `json:"status"`
+	Reason          string     `json:"reason,omitempty"`
+	ReviewedBy      string     `json:"reviewed_by,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type ListIdentityVerificationPagination struct {
+	Data       []IdentityVerificationResponse `json:"data"`
+	LoadMore   bool                           `json:"load_more"`
+	NextCursor string                         `json:"next_cursor"`
+	PrevCursor string                         `json:"prev_cursor"`
+	Limit      int                            `json:"limit"`
+}
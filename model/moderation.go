@@ -0,0 +1,86 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type AccountBlockBaseModel struct {
+	ID        int64     `db:"id"`
+	BlockerID int64     `db:"blocker_id"`
+	BlockedID int64     `db:"blocked_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type AccountReportBaseModel struct {
+	ID         int64          `db:"id"`
+	ReportUID  string         `db:"report_uid"`
+	ReporterID int64          `db:"reporter_id"`
+	ReportedID int64          `db:"reported_id"`
+	Reason     string         `db:"reason"`
+	Details    sql.NullString `db:"details"`
+	Status     string         `db:"status"`
+	Action     sql.NullString `db:"action"`
+	Note       sql.NullString `db:"note"`
+	ResolvedBy sql.NullString `db:"resolved_by"`
+	ResolvedAt sql.NullTime   `db:"resolved_at"`
+	CreatedAt  time.Time      `db:"created_at"`
+
+	// joined from account
+	ReporterMaskID   string `db:"reporter_mask_id"`
+	ReportedMaskID   string `db:"reported_mask_id"`
+	ReportedUserName string `db:"reported_user_name"`
+	ReportedStatus   string `db:"reported_status"`
+}
+
+type BlockAccountRequest struct {
+	AccountMaskID       string `json:"-" valid:"required"`
+	TargetAccountMaskID string `json:"-" valid:"required"`
+}
+
+type ReportAccountRequest struct {
+	AccountMaskID       string `json:"-" valid:"required"`
+	TargetAccountMaskID string `json:"-" valid:"required"`
+	Reason              string `json:"reason" valid:"required,in(SPAM|FAKE_PROFILE|INAPPROPRIATE_CONTENT|HARASSMENT|UNDERAGE|SCAM|OTHER)"`
+	Details             string `json:"details" valid:"optional,runelength(1|1000)~details must be at most 1000 characters"`
+}
+
+type ListAccountReportRequest struct {
+	Status    string `json:"status" valid:"optional,in(OPEN|RESOLVED|DISMISSED)"`
+	Cursor    string `json:"cursor"`
+	Direction string `json:"direction" valid:"optional,in(next|prev)"`
+	Limit     int    `json:"limit" valid:"required"`
+	CursorID  int64  `json:"-"`
+}
+
+type ModerateAccountReportRequest struct {
+	ReportUID    string `json:"-" valid:"required"`
+	ModeratedBy  string `json:"-" valid:"required"`
+	Action       string `json:"action" valid:"required,in(WARN|SUSPEND|BAN|DISMISS)"`
+	SuspendHours int    `json:"suspend_hours" valid:"optional,range(0|8760)~suspend_hours must be between 0 and 8760"`
+	Note         string `json:"note" valid:"optional,runelength(1|1000)~note must be at most 1000 characters"`
+}
+
+type AccountReportResponse struct {
+	ReportUID        string     `json:"report_uid"`
+	ReporterMaskID   string     `json:"reporter_account_mask_id,omitempty"`
+	ReportedMaskID   string     `json:"reported_account_mask_id"`
+	ReportedUserName string     `json:"reported_user_name,omitempty"`
+	ReportedStatus   string     `json:"reported_account_status,omitempty"`
+	Reason           string     `json:"reason"`
+	Details          string     `json:"details,omitempty"`
+	Status           string     `json:"status"`
+	Action           string     `json:"action,omitempty"`
+	Note             string     `json:"note,omitempty"`
+	ResolvedBy       string     `json:"resolved_by,omitempty"`
+	ResolvedAt       *time.Time `json:"resolved_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type ListAccountReportPagination struct {
+	Data       []AccountReportResponse `json:"data"`
+	LoadMore   bool                    `json:"load_more"`
+	NextCursor string                  `json:"next_cursor"`
+	PrevCursor string                  `json:"prev_cursor"`
+	Limit      int                     `json:"limit"`
+}
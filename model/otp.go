@@ -0,0 +1,57 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type OTPBaseModel struct {
+	ID         int64        `db:"id"`
+	AccountID  int64        `db:"account_id"`
+	Purpose    string       `db:"purpose"`
+	Recipient  string       `db:"recipient"`
+	CodeHash   string       `db:"code_hash"`
+	Attempts   int          `db:"attempts"`
+	ExpiresAt  time.Time    `db:"expires_at"`
+	ConsumedAt sql.NullTime `db:"consumed_at"`
+	CreatedAt  time.Time    `db:"created_at"`
+}
+
+type OTPPolicy struct {
+	Length         int           // digits in a code
+	TTL            time.Duration // code lifetime
+	MaxAttempts    int           // wrong guesses before the code is burned
+	ResendCooldown time.Duration // minimum time between two codes of the same purpose
+}
+
+type Notification struct {
+	Channel   string `json:"channel"`
+	Recipient string `json:"recipient"`
+	Subject   string `json:"subject"`
+	Body      string `json:"body"`
+}
+
+type UpdateContactRequest struct {
+	AccountMaskID string `json:"-" valid:"required"`
+	Email         string `json:"email" valid:"optional,email~email is not valid,length(3|255)~email must be at most 255 characters"`
+	Phone         string `json:"phone" valid:"optional,phone~phone must be in international format"`
+}
+
+type SendVerificationRequest struct {
+	AccountMaskID string `json:"-" valid:"required"`
+	Channel       string `json:"channel" valid:"required,in(email|phone)"`
+}
+
+type VerifyContactRequest struct {
+	AccountMaskID string `json:"-" valid:"required"`
+	Channel       string `json:"channel" valid:"required,in(email|phone)"`
+	Code          string `json:"code" valid:"required,numeric"`
+}
+
+type ContactResponse struct {
+	Email           string `json:"email"`
+	EmailVerified   bool   `json:"email_verified"`
+	Phone           string `json:"phone"`
+	PhoneVerified   bool   `json:"phone_verified"`
+	VerificationTTL int    `json:"verification_ttl,omitempty"`
+}
@@ -6,16 +6,23 @@ import (
 )
 
 type PremiumPackageBaseModel struct {
-	ID          int64          `db:"id"`
-	PackageUID  string         `db:"package_uid"`
-	Title       string         `db:"title"`
-	Description string         `db:"description"`
-	Price       float64        `db:"price"`
-	IsActive    bool           `db:"is_active"`
-	CreatedAt   time.Time      `db:"created_at"`
-	UpdatedAt   sql.NullTime   `db:"updated_at"`
-	CreatedBy   string         `db:"created_by"`
-	UpdatedBy   sql.NullString `db:"updated_by"`
+	ID           int64          `db:"id"`
+	PackageUID   string         `db:"package_uid"`
+	Title        string         `db:"title"`
+	Description  string         `db:"description"`
+	Price        float64        `db:"price"`
+	IsActive     bool           `db:"is_active"`
+	CreditType   sql.NullString `db:"credit_type"`
+	CreditAmount int            `db:"credit_amount"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    sql.NullTime   `db:"updated_at"`
+	CreatedBy    string         `db:"created_by"`
+	UpdatedBy    sql.NullString `db:"updated_by"`
+}
+
+// IsCredit every purchase of the package tops up credits instead of unlocking a feature once
+func (p PremiumPackageBaseModel) IsCredit() bool {
+	return p.CreditType.Valid && p.CreditAmount > 0
 }
 
 type PremiumPackageUserBaseModel struct {
@@ -26,16 +33,18 @@ type PremiumPackageUserBaseModel struct {
 }
 
 type PremiumPackageResponse struct {
-	PackageUID  string    `json:"package_uid"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	Price       float64   `json:"price"`
-	IsActive    bool      `json:"is_active"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	CreatedBy   string    `json:"created_by"`
-	UpdateBy    string    `json:"updated_by"`
-	IsPurchased bool      `json:"is_purchased"`
+	PackageUID   string    `json:"package_uid"`
+	Title        string    `json:"title"`
+	Description  string    `json:"description"`
+	Price        float64   `json:"price"`
+	IsActive     bool      `json:"is_active"`
+	CreditType   string    `json:"credit_type,omitempty"`
+	CreditAmount int       `json:"credit_amount,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	CreatedBy    string    `json:"created_by"`
+	UpdateBy     string    `json:"updated_by"`
+	IsPurchased  bool      `json:"is_purchased"`
 }
 
 type ListPackagePagination struct {
@@ -46,3 +55,25 @@ type ListPackagePagination struct {
 	Limit      int                      `json:"limit"`
 	Keywords   string                   `json:"q"`
 }
+
+type CreditLedgerBaseModel struct {
+	ID               int64         `db:"id"`
+	AccountID        int64         `db:"account_id"`
+	CreditType       string        `db:"credit_type"`
+	Amount           int           `db:"amount"` // positive for a purchase, negative for a spend
+	Reason           string        `db:"reason"`
+	PremiumPackageID sql.NullInt64 `db:"premium_package_id"`
+	CreatedAt        time.Time     `db:"created_at"`
+}
+
+type CreditBalance struct {
+	CreditType string `db:"credit_type"`
+	Balance    int    `db:"balance"`
+}
+
+// WalletResponse credits left of every credit type
+type WalletResponse struct {
+	SuperLike int `json:"super_like"`
+	Boost     int `json:"boost"`
+	Swipe     int `json:"swipe"`
+}
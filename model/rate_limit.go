@@ -0,0 +1,48 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+type RateLimit struct {
+	Rate  float64 // tokens refilled per second
+	Burst int     // bucket capacity
+}
+
+type RateLimitResult struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+type LoginAttemptBaseModel struct {
+	Key          string       `db:"key"`
+	FailedCount  int          `db:"failed_count"`
+	LockedUntil  sql.NullTime `db:"locked_until"`
+	LastFailedAt time.Time    `db:"last_failed_at"`
+}
+
+type LoginAttemptPolicy struct {
+	MaxAttempts int           // failures before the first lockout
+	Window      time.Duration // failures older than this are forgotten
+	BaseLockout time.Duration // first lockout, doubled on every further failure
+	MaxLockout  time.Duration
+}
+
+// LockoutFor exponential lockout for the given number of consecutive failures.
+func (p LoginAttemptPolicy) LockoutFor(failedCount int) time.Duration {
+	if p.MaxAttempts <= 0 || failedCount < p.MaxAttempts {
+		return 0
+	}
+
+	lockout := p.BaseLockout
+	for i := p.MaxAttempts; i < failedCount && lockout < p.MaxLockout; i++ {
+		lockout *= 2
+	}
+
+	if p.MaxLockout > 0 && lockout > p.MaxLockout {
+		lockout = p.MaxLockout
+	}
+
+	return lockout
+}
@@ -5,13 +5,14 @@ type UserSwipeLogBaseModel struct {
 	SwiperID  int64  `db:"swiper_id"`
 	SwipeeID  int64  `db:"swipee_id"`
 	SwipeType string `db:"swipe_type"`
+	SuperLike bool   `db:"super_like"`
 	CreatedAt string `db:"created_at"`
 }
 
 type UserSwipeRequest struct {
 	SwiperAccountMaskID string `json:"-" valid:"required"`
 	SwipeeAccountMaskID string `json:"swipee_id" valid:"required"`
-	SwipeType           string `json:"swipe_type" valid:"required,in(LIKE|PASS)"`
+	SwipeType           string `json:"swipe_type" valid:"required,in(LIKE|PASS|SUPER_LIKE)"`
 }
 
 type SwipeCountBaseModel struct {
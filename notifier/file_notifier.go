@@ -0,0 +1,48 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type fileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileNotifier append notifications as json lines to path, for local use only.
+func NewFileNotifier(path string) interfaces.INotifier {
+	return &fileNotifier{path: path}
+}
+
+func (f *fileNotifier) Send(ctx context.Context, notification model.Notification) error {
+	line, err := json.Marshal(struct {
+		model.Notification
+		SentAt time.Time `json:"sent_at"`
+	}{notification, time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err = os.MkdirAll(filepath.Dir(f.path), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
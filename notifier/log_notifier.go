@@ -0,0 +1,21 @@
+package notifier
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"log/slog"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier print notifications to the application log, for local use only.
+func NewLogNotifier() interfaces.INotifier {
+	return &logNotifier{}
+}
+
+func (l *logNotifier) Send(ctx context.Context, notification model.Notification) error {
+	slog.InfoContext(ctx, "notifier: send notification", "channel", notification.Channel, "recipient", notification.Recipient,
+		"subject", notification.Subject, "body", notification.Body)
+	return nil
+}
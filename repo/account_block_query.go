@@ -0,0 +1,16 @@
+package repo
+
+var (
+	// account_block
+	RepoInsertAccountBlock = `
+	INSERT INTO account_block (blocker_id, blocked_id)
+		VALUES ($1, $2)
+	ON CONFLICT (blocker_id, blocked_id) DO UPDATE SET blocker_id = EXCLUDED.blocker_id
+	RETURNING id, created_at;`
+	RepoDeleteAccountBlock = `
+	DELETE FROM account_block WHERE blocker_id = $1 AND blocked_id = $2;`
+	RepoIsAccountBlocked = `
+	SELECT EXISTS (
+		SELECT 1 FROM account_block
+		WHERE (blocker_id = $1 AND blocked_id = $2) OR (blocker_id = $2 AND blocked_id = $1));`
+)
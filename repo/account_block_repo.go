@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type accountBlockRepo struct {
+	db *sqlx.DB
+}
+
+func NewAccountBlockRepo(db *sqlx.DB) interfaces.IAccountBlockRepo {
+	return &accountBlockRepo{db: db}
+}
+
+// InsertAccountBlock is idempotent, blocking twice keeps the first block
+func (a *accountBlockRepo) InsertAccountBlock(ctx context.Context, req *model.AccountBlockBaseModel) (err error) {
+	if err = a.db.QueryRowContext(ctx, RepoInsertAccountBlock, req.BlockerID, req.BlockedID).Scan(&req.ID, &req.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *accountBlockRepo) DeleteAccountBlock(ctx context.Context, blockerID, blockedID int64) (err error) {
+	if _, err = a.db.ExecContext(ctx, RepoDeleteAccountBlock, blockerID, blockedID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// IsAccountBlocked report a block in either direction
+func (a *accountBlockRepo) IsAccountBlocked(ctx context.Context, accountID, otherAccountID int64) (blocked bool, err error) {
+	if err = a.db.QueryRowContext(ctx, RepoIsAccountBlocked, accountID, otherAccountID).Scan(&blocked); err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
@@ -0,0 +1,16 @@
+package repo
+
+var (
+	// account_effect, times are utc like the ones written by the services.
+	// A new effect starts when the running or queued one of the same type ends.
+	RepoInsertAccountEffect = `
+	INSERT INTO account_effect (account_id, premium_package_id, effect_type, starts_at, ends_at)
+	SELECT $1::int, $2::int, $3::varchar, queue.starts_at, queue.starts_at + make_interval(secs => $4)
+		FROM (SELECT GREATEST(now() AT TIME ZONE 'UTC', MAX(ends_at)) AS starts_at
+			FROM account_effect WHERE account_id = $1 AND effect_type = $3) queue
+	RETURNING id, starts_at, ends_at, created_at;`
+	RepoGetListAccountEffectByAccountID = `
+	SELECT id, account_id, premium_package_id, effect_type, starts_at, ends_at, created_at
+		FROM account_effect WHERE account_id = $1 AND ends_at > now() AT TIME ZONE 'UTC'
+	ORDER BY starts_at ASC, id ASC;`
+)
@@ -0,0 +1,38 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type accountEffectRepo struct {
+	db *sqlx.DB
+}
+
+func NewAccountEffectRepo(db *sqlx.DB) interfaces.IAccountEffectRepo {
+	return &accountEffectRepo{db: db}
+}
+
+// InsertAccountEffect queue the effect after the latest one of the same type, req gets its start and end.
+// The caller holds the account row lock, so two purchases of the same account can not get the same slot.
+func (a *accountEffectRepo) InsertAccountEffect(ctx context.Context, trx *sql.Tx, req *model.AccountEffectBaseModel, duration time.Duration) (err error) {
+	if err = trx.QueryRowContext(ctx, RepoInsertAccountEffect, req.AccountID, req.PremiumPackageID, req.EffectType, duration.Seconds()).
+		Scan(&req.ID, &req.StartsAt, &req.EndsAt, &req.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetListAccountEffectByAccountID running and queued effects, the ended ones are left out
+func (a *accountEffectRepo) GetListAccountEffectByAccountID(ctx context.Context, accountID int64) (output []model.AccountEffectBaseModel, err error) {
+	if err = a.db.SelectContext(ctx, &output, RepoGetListAccountEffectByAccountID, accountID); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
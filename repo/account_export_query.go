@@ -0,0 +1,46 @@
+package repo
+
+var (
+	// account_export
+	RepoInsertAccountExport = `
+	INSERT INTO account_export (account_id, status)
+		VALUES ($1, $2) RETURNING id, export_uid, created_at;`
+	RepoGetLatestAccountExportByAccountID = `
+	SELECT ae.id, ae.export_uid, ae.account_id, ae.status, ae.file_path, ae.error, ae.started_at, ae.completed_at,
+	       ae.created_at, account.account_mask_id
+		FROM account_export ae
+		INNER JOIN account ON account.id = ae.account_id
+		WHERE ae.account_id = $1
+	ORDER BY ae.id DESC LIMIT 1;`
+	RepoGetAccountExportByUID = `
+	SELECT ae.id, ae.export_uid, ae.account_id, ae.status, ae.file_path, ae.error, ae.started_at, ae.completed_at,
+	       ae.created_at, account.account_mask_id
+		FROM account_export ae
+		INNER JOIN account ON account.id = ae.account_id
+		WHERE ae.export_uid = $1;`
+
+	// SKIP LOCKED lets several workers claim different jobs, a job stuck in PROCESSING
+	// past the timeout belongs to a worker that died and is claimed again
+	RepoClaimAccountExport = `
+	WITH job AS (
+		SELECT id FROM account_export
+		WHERE status = 'PENDING' OR (status = 'PROCESSING' AND started_at < $1)
+		ORDER BY id ASC LIMIT 1 FOR UPDATE SKIP LOCKED)
+	UPDATE account_export ae SET status = 'PROCESSING', started_at = $2
+		FROM job, account
+		WHERE ae.id = job.id AND account.id = ae.account_id
+	RETURNING ae.id, ae.export_uid, ae.account_id, ae.status, ae.file_path, ae.error, ae.started_at, ae.completed_at,
+	       ae.created_at, account.account_mask_id;`
+	RepoCompleteAccountExport = `
+	UPDATE account_export SET status = $2, file_path = $3, error = $4, completed_at = $5
+		WHERE id = $1 AND status = 'PROCESSING';`
+	RepoClearExpiredAccountExport = `
+	WITH expired AS (
+		SELECT id, file_path FROM account_export
+		WHERE status = 'DONE' AND file_path <> '' AND completed_at < $1 FOR UPDATE SKIP LOCKED)
+	UPDATE account_export ae SET file_path = ''
+		FROM expired WHERE ae.id = expired.id
+	RETURNING expired.file_path;`
+	RepoDeleteAccountExportByAccountID = `
+	DELETE FROM account_export WHERE account_id = $1 RETURNING file_path;`
+)
@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type accountExportRepo struct {
+	db *sqlx.DB
+}
+
+func NewAccountExportRepo(db *sqlx.DB) interfaces.IAccountExportRepo {
+	return &accountExportRepo{db: db}
+}
+
+func (a *accountExportRepo) InsertAccountExport(ctx context.Context, req *model.AccountExportBaseModel) (err error) {
+	if err = a.db.QueryRowContext(ctx, RepoInsertAccountExport, req.AccountID, req.Status).
+		Scan(&req.ID, &req.ExportUID, &req.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *accountExportRepo) GetLatestAccountExportByAccountID(ctx context.Context, accountID int64) (output model.AccountExportBaseModel, err error) {
+	if err = a.db.GetContext(ctx, &output, RepoGetLatestAccountExportByAccountID, accountID); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+func (a *accountExportRepo) GetAccountExportByUID(ctx context.Context, exportUID string) (output model.AccountExportBaseModel, err error) {
+	if err = a.db.GetContext(ctx, &output, RepoGetAccountExportByUID, exportUID); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+// ClaimAccountExport mark the oldest queued job as processing, return sql.ErrNoRows when the queue is empty
+func (a *accountExportRepo) ClaimAccountExport(ctx context.Context, staleBefore time.Time) (output model.AccountExportBaseModel, err error) {
+	if err = a.db.GetContext(ctx, &output, RepoClaimAccountExport, staleBefore, time.Now().UTC()); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+// CompleteAccountExport return sql.ErrNoRows when the job is no longer processing, e.g. the account was deleted meanwhile
+func (a *accountExportRepo) CompleteAccountExport(ctx context.Context, req *model.AccountExportBaseModel) (err error) {
+	res, err := a.db.ExecContext(ctx, RepoCompleteAccountExport, req.ID, req.Status, req.FilePath, req.Error, req.CompletedAt)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ClearExpiredAccountExport detach archives finished before completedBefore and return their paths so the files can be removed
+func (a *accountExportRepo) ClearExpiredAccountExport(ctx context.Context, completedBefore time.Time) (filePaths []string, err error) {
+	if err = a.db.SelectContext(ctx, &filePaths, RepoClearExpiredAccountExport, completedBefore); err != nil {
+		return nil, err
+	}
+
+	return filePaths, nil
+}
+
+// DeleteAccountExportByAccountID remove every export of the account and return the stored paths so the files can be removed
+func (a *accountExportRepo) DeleteAccountExportByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) (filePaths []string, err error) {
+	rows, err := trx.QueryContext(ctx, RepoDeleteAccountExportByAccountID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var filePath string
+		if err = rows.Scan(&filePath); err != nil {
+			return nil, err
+		}
+
+		if filePath != "" {
+			filePaths = append(filePaths, filePath)
+		}
+	}
+
+	return filePaths, rows.Err()
+}
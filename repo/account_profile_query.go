@@ -0,0 +1,46 @@
+package repo
+
+var (
+	// account_photo
+	RepoGetListAccountPhotoByAccountID = `
+	SELECT id, account_id, url, position, created_at
+		FROM account_photo WHERE account_id = $1
+	ORDER BY position ASC, id ASC;`
+	RepoInsertAccountPhoto = `
+	INSERT INTO account_photo (account_id, url, position) VALUES %s;`
+	RepoDeleteAccountPhotoByAccountID = `
+	DELETE FROM account_photo WHERE account_id = $1;`
+
+	// account_interest
+	RepoGetListAccountInterestByAccountID = `
+	SELECT interest FROM account_interest WHERE account_id = $1 ORDER BY interest ASC;`
+	RepoInsertAccountInterest = `
+	INSERT INTO account_interest (account_id, interest) VALUES %s
+	ON CONFLICT (account_id, interest) DO NOTHING;`
+	RepoDeleteAccountInterestByAccountID = `
+	DELETE FROM account_interest WHERE account_id = $1;`
+
+	// profile_view
+	RepoInsertProfileView = `
+	INSERT INTO profile_view (viewer_id, viewed_id)
+		VALUES ($1, $2)
+	ON CONFLICT (viewed_id, viewer_id, view_date) DO NOTHING RETURNING id, created_at;`
+	// visitors of an account, deleted viewers and blocked pairs are left out
+	RepoProfileVisitorFrom = `
+		FROM profile_view pv
+		INNER JOIN account viewer ON viewer.id = pv.viewer_id
+		WHERE pv.viewed_id = ? AND viewer.is_hidden IS FALSE
+		AND (viewer.status IN ('ACTIVE', 'WARNED') OR (viewer.status = 'SUSPENDED' AND viewer.suspended_until <= now()))
+		AND NOT EXISTS (
+			SELECT 1 FROM account_block
+			WHERE (account_block.blocker_id = pv.viewed_id AND account_block.blocked_id = pv.viewer_id)
+			   OR (account_block.blocked_id = pv.viewed_id AND account_block.blocker_id = pv.viewer_id)) `
+	RepoGetListProfileVisitor = `
+	SELECT pv.id, pv.created_at, viewer.account_mask_id, viewer.type, viewer.name, viewer.user_name, viewer.is_verified` +
+		RepoProfileVisitorFrom + `
+	%s %s %s;`
+	RepoCountProfileVisitor = `
+	SELECT COUNT(*)` + RepoProfileVisitorFrom + `;`
+	RepoDeleteProfileViewByAccountID = `
+	DELETE FROM profile_view WHERE viewer_id = $1 OR viewed_id = $1;`
+)
@@ -0,0 +1,139 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+type accountProfileRepo struct {
+	db *sqlx.DB
+}
+
+func NewAccountProfileRepo(db *sqlx.DB) interfaces.IAccountProfileRepo {
+	return &accountProfileRepo{db: db}
+}
+
+func (a *accountProfileRepo) GetListAccountPhotoByAccountID(ctx context.Context, accountID int64) (output []model.AccountPhotoBaseModel, err error) {
+	if err = a.db.SelectContext(ctx, &output, RepoGetListAccountPhotoByAccountID, accountID); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// InsertAccountPhoto insert the photos in one statement
+func (a *accountProfileRepo) InsertAccountPhoto(ctx context.Context, req []model.AccountPhotoBaseModel) (err error) {
+	if len(req) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(req))
+	inputArgs := make([]interface{}, 0, len(req)*3)
+	for _, photo := range req {
+		values = append(values, "(?, ?, ?)")
+		inputArgs = append(inputArgs, photo.AccountID, photo.URL, photo.Position)
+	}
+
+	query := fmt.Sprintf(RepoInsertAccountPhoto, strings.Join(values, ", "))
+	if _, err = a.db.ExecContext(ctx, a.db.Rebind(query), inputArgs...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *accountProfileRepo) GetListAccountInterestByAccountID(ctx context.Context, accountID int64) (output []string, err error) {
+	if err = a.db.SelectContext(ctx, &output, RepoGetListAccountInterestByAccountID, accountID); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// InsertAccountInterest is idempotent, an interest the account already has is kept
+func (a *accountProfileRepo) InsertAccountInterest(ctx context.Context, accountID int64, interests []string) (err error) {
+	if len(interests) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(interests))
+	inputArgs := make([]interface{}, 0, len(interests)*2)
+	for _, interest := range interests {
+		values = append(values, "(?, ?)")
+		inputArgs = append(inputArgs, accountID, interest)
+	}
+
+	query := fmt.Sprintf(RepoInsertAccountInterest, strings.Join(values, ", "))
+	if _, err = a.db.ExecContext(ctx, a.db.Rebind(query), inputArgs...); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteAccountProfileByAccountID remove the photos, interests and profile views of a deleted account
+func (a *accountProfileRepo) DeleteAccountProfileByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) (err error) {
+	for _, query := range []string{RepoDeleteAccountPhotoByAccountID, RepoDeleteAccountInterestByAccountID, RepoDeleteProfileViewByAccountID} {
+		if _, err = trx.ExecContext(ctx, query, accountID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InsertProfileView record the first view of the day, req.ID stays 0 when the viewer was already recorded today
+func (a *accountProfileRepo) InsertProfileView(ctx context.Context, req *model.ProfileViewBaseModel) (err error) {
+	err = a.db.QueryRowContext(ctx, RepoInsertProfileView, req.ViewerID, req.ViewedID).Scan(&req.ID, &req.CreatedAt)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	return nil
+}
+
+// GetListProfileVisitorPagination newest visit first
+func (a *accountProfileRepo) GetListProfileVisitorPagination(ctx context.Context, req model.ListProfileVisitorRequest) (output []model.ProfileVisitorBaseModel, err error) {
+	var (
+		condition, offsetLimit, orderBy string
+		inputArgs                       = []interface{}{req.ViewedID}
+	)
+
+	orderBy = `ORDER BY pv.id DESC`
+	if req.CursorID != 0 && req.Direction == utils.DirectionNext {
+		condition += `AND pv.id < ? `
+		inputArgs = append(inputArgs, req.CursorID)
+	}
+
+	if req.CursorID != 0 && req.Direction == utils.DirectionPrev {
+		condition += `AND pv.id > ? `
+		inputArgs = append(inputArgs, req.CursorID)
+		orderBy = `ORDER BY pv.id ASC`
+	}
+
+	if req.Limit != 0 {
+		offsetLimit = fmt.Sprintf("LIMIT %d", req.Limit)
+	}
+
+	query := fmt.Sprintf(RepoGetListProfileVisitor, condition, orderBy, offsetLimit)
+	if err = a.db.SelectContext(ctx, &output, a.db.Rebind(query), inputArgs...); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (a *accountProfileRepo) CountProfileVisitor(ctx context.Context, viewedID int64) (total int64, err error) {
+	if err = a.db.GetContext(ctx, &total, a.db.Rebind(RepoCountProfileVisitor), viewedID); err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
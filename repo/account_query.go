@@ -2,24 +2,101 @@ package repo
 
 var (
 	RepoFindOneAccountByAccountUserName = `
-	SELECT id, account_mask_id, type, name, user_name, password, created_at, created_by, updated_at, updated_by
+	SELECT id, account_mask_id, type, role, status, suspended_until, reactivate_status, is_hidden, deleted_at, name, user_name, password, is_verified, email, phone, email_verified_at, phone_verified_at,
+	       bio, birth_date, latitude, longitude, hide_age, hide_distance, hide_visits, created_at, created_by, updated_at, updated_by
 		FROM account where user_name = $1;`
 
 	RepoInsertAccount = `
-	INSERT INTO account (type, name, user_name, password, created_by)
-	    		VALUES ($1, $2, $3, $4, $5) RETURNING id,account_mask_id;`
+	INSERT INTO account (type, name, user_name, password, email, phone, bio, birth_date, latitude, longitude, created_by)
+	    		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) RETURNING id,account_mask_id;`
 
-	RepoUpdateAccount = `
-	UPDATE account SET type = $2, name = $3, user_name = $4, updated_by = $5,  is_verified = $6, updated_at = now()
+	RepoUpdateAccountType = `
+	UPDATE account SET type = $2, updated_by = $3, updated_at = now()
+	WHERE id = $1 ;`
+
+	RepoUpdateAccountVerified = `
+	UPDATE account SET is_verified = true, updated_by = $2, updated_at = now()
+	WHERE id = $1 ;`
+
+	RepoLockAccount = `
+	SELECT id FROM account WHERE id = $1 FOR UPDATE;`
+
+	RepoUpdateAccountContact = `
+	UPDATE account SET email = $2, phone = $3, email_verified_at = $4, phone_verified_at = $5, updated_by = $6, updated_at = now()
+	WHERE id = $1 ;`
+
+	RepoUpdateAccountPassword = `
+	UPDATE account SET password = $2, updated_by = $3, updated_at = now()
+	WHERE id = $1 ;`
+
+	RepoUpdateAccountStatus = `
+	UPDATE account SET status = $2, suspended_until = $3, reactivate_status = $4, updated_by = $5, updated_at = now()
+	WHERE id = $1 ;`
+
+	RepoUpdateAccountDiscovery = `
+	UPDATE account SET is_hidden = $2, updated_by = $3, updated_at = now()
+	WHERE id = $1 ;`
+
+	RepoUpdateAccountPrivacy = `
+	UPDATE account SET hide_age = $2, hide_distance = $3, hide_visits = $4, updated_by = $5, updated_at = now()
+	WHERE id = $1 ;`
+
+	// the row is kept so swipe logs and purchases keep their foreign keys, only the personal data is dropped
+	RepoAnonymizeAccount = `
+	UPDATE account SET name = $2, user_name = $3, password = '', email = NULL, phone = NULL, email_verified_at = NULL,
+		phone_verified_at = NULL, bio = NULL, birth_date = NULL, latitude = NULL, longitude = NULL,
+		is_verified = false, is_hidden = true, status = 'DELETED', suspended_until = NULL, reactivate_status = NULL,
+		deleted_at = $4, updated_by = $5, updated_at = now()
 	WHERE id = $1 ;`
 
 	RepoFindOneAccountByAccountMaskID = `
-	SELECT id, account_mask_id, type, name, user_name, is_verified, created_at, created_by, updated_at, updated_by
+	SELECT id, account_mask_id, type, role, status, suspended_until, reactivate_status, is_hidden, deleted_at, name, user_name, is_verified, email, phone, email_verified_at, phone_verified_at,
+	       bio, birth_date, latitude, longitude, hide_age, hide_distance, hide_visits, created_at, created_by, updated_at, updated_by
 		FROM account where account_mask_id = $1;`
 
 	RepoGetListAccountNewMatchPagination = `
 	SELECT id, account_mask_id, type, name, user_name, is_verified, created_at, created_by, updated_at, updated_by
 		FROM account WHERE id NOT IN (
 		SELECT swipee_id FROM user_swipe_log WHERE user_swipe_log.swipee_id=account.id AND DATE(created_at) = (CURRENT_TIMESTAMP)::DATE)
+		AND is_hidden IS FALSE
+		AND (status IN ('ACTIVE', 'WARNED') OR (status = 'SUSPENDED' AND suspended_until <= now()))
 	%s %s %s;`
+
+	// an incognito account is only shown to the accounts it liked
+	RepoConditionExcludeIncognitoAccount = `AND NOT EXISTS (
+		SELECT 1 FROM account_effect
+		WHERE account_effect.account_id = account.id AND account_effect.effect_type = 'INCOGNITO'
+		  AND account_effect.starts_at <= now() AT TIME ZONE 'UTC' AND account_effect.ends_at > now() AT TIME ZONE 'UTC'
+		  AND NOT EXISTS (
+			SELECT 1 FROM user_swipe_log
+			INNER JOIN account viewer ON viewer.account_mask_id = ?
+			WHERE user_swipe_log.swiper_id = account.id AND user_swipe_log.swipee_id = viewer.id
+			  AND user_swipe_log.swipe_type = 'LIKE')) `
+
+	// the INCOGNITO effect of account $1 is running and it did not like account $2
+	RepoIsAccountIncognitoFor = `
+	SELECT EXISTS (
+		SELECT 1 FROM account_effect
+		WHERE account_effect.account_id = $1 AND account_effect.effect_type = 'INCOGNITO'
+		  AND account_effect.starts_at <= now() AT TIME ZONE 'UTC' AND account_effect.ends_at > now() AT TIME ZONE 'UTC'
+		  AND NOT EXISTS (
+			SELECT 1 FROM user_swipe_log
+			WHERE user_swipe_log.swiper_id = $1 AND user_swipe_log.swipee_id = $2 AND user_swipe_log.swipe_type = 'LIKE'));`
+
+	// boosted accounts within ? km of the viewer, by the haversine distance of their last known locations
+	RepoConditionBoostedNearbyAccount = `EXISTS (
+		SELECT 1 FROM account_effect
+		INNER JOIN account viewer ON viewer.account_mask_id = ?
+		WHERE account_effect.account_id = account.id AND account_effect.effect_type = 'BOOST'
+		  AND account_effect.starts_at <= now() AT TIME ZONE 'UTC' AND account_effect.ends_at > now() AT TIME ZONE 'UTC'
+		  AND 2 * 6371 * asin(LEAST(1, sqrt(power(sin(radians(account.latitude - viewer.latitude) / 2), 2)
+			+ cos(radians(viewer.latitude)) * cos(radians(account.latitude))
+			* power(sin(radians(account.longitude - viewer.longitude) / 2), 2)))) <= ?) `
+
+	// blocked pairs are hidden in both directions
+	RepoConditionExcludeBlockedAccount = `AND NOT EXISTS (
+		SELECT 1 FROM account_block
+		INNER JOIN account viewer ON viewer.account_mask_id = ?
+		WHERE (account_block.blocker_id = viewer.id AND account_block.blocked_id = account.id)
+		   OR (account_block.blocked_id = viewer.id AND account_block.blocker_id = account.id)) `
 )
@@ -8,6 +8,7 @@ import (
 	"github.com/dwiangraeni/dealls/model"
 	"github.com/dwiangraeni/dealls/utils"
 	"github.com/jmoiron/sqlx"
+	"strings"
 )
 
 type user struct {
@@ -20,7 +21,9 @@ func NewAccountRepo(db *sqlx.DB) interfaces.IAccountRepo {
 
 func (u *user) FindOneAccountByAccountUserName(ctx context.Context, userName string) (output model.AccountBaseModel, err error) {
 	if err = u.db.QueryRowContext(ctx, RepoFindOneAccountByAccountUserName, userName).
-		Scan(&output.ID, &output.AccountMaskID, &output.Type, &output.Name, &output.UserName, &output.Password,
+		Scan(&output.ID, &output.AccountMaskID, &output.Type, &output.Role, &output.Status, &output.SuspendedUntil, &output.ReactivateStatus, &output.IsHidden, &output.DeletedAt, &output.Name, &output.UserName, &output.Password,
+			&output.IsVerified, &output.Email, &output.Phone, &output.EmailVerifiedAt, &output.PhoneVerifiedAt,
+			&output.Bio, &output.BirthDate, &output.Latitude, &output.Longitude, &output.HideAge, &output.HideDistance, &output.HideVisits,
 			&output.CreatedAt, &output.CreatedBy, &output.UpdatedAt, &output.UpdatedBy); err != nil {
 		return output, err
 	}
@@ -29,7 +32,8 @@ func (u *user) FindOneAccountByAccountUserName(ctx context.Context, userName str
 
 func (u *user) InsertAccount(ctx context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error) {
 	if err := u.db.QueryRowContext(ctx, RepoInsertAccount, account.Type, account.Name, account.UserName,
-		account.Password, account.CreatedBy).Scan(&account.ID, &account.AccountMaskID); err != nil {
+		account.Password, account.Email, account.Phone, account.Bio, account.BirthDate, account.Latitude, account.Longitude,
+		account.CreatedBy).Scan(&account.ID, &account.AccountMaskID); err != nil {
 		return account, err
 	}
 
@@ -37,7 +41,65 @@ func (u *user) InsertAccount(ctx context.Context, account model.AccountBaseModel
 }
 
 func (u *user) UpdateAccountType(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
-	if _, err := trx.ExecContext(ctx, RepoUpdateAccount, account.ID, account.Type, account.Name, account.UserName, account.UpdatedBy, account.IsVerified); err != nil {
+	if _, err := trx.ExecContext(ctx, RepoUpdateAccountType, account.ID, account.Type, account.UpdatedBy); err != nil {
+		return account, err
+	}
+	return account, nil
+}
+
+func (u *user) UpdateAccountVerified(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	if _, err := trx.ExecContext(ctx, RepoUpdateAccountVerified, account.ID, account.UpdatedBy); err != nil {
+		return account, err
+	}
+	account.IsVerified = true
+	return account, nil
+}
+
+// LockAccount hold the account row until trx ends, serialising the purchases of one account
+func (u *user) LockAccount(ctx context.Context, trx *sql.Tx, accountID int64) error {
+	var id int64
+	return trx.QueryRowContext(ctx, RepoLockAccount, accountID).Scan(&id)
+}
+
+func (u *user) UpdateAccountContact(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	if _, err := trx.ExecContext(ctx, RepoUpdateAccountContact, account.ID, account.Email, account.Phone,
+		account.EmailVerifiedAt, account.PhoneVerifiedAt, account.UpdatedBy); err != nil {
+		return account, err
+	}
+	return account, nil
+}
+
+func (u *user) UpdateAccountPassword(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	if _, err := trx.ExecContext(ctx, RepoUpdateAccountPassword, account.ID, account.Password, account.UpdatedBy); err != nil {
+		return account, err
+	}
+	return account, nil
+}
+
+func (u *user) UpdateAccountStatus(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	if _, err := trx.ExecContext(ctx, RepoUpdateAccountStatus, account.ID, account.Status, account.SuspendedUntil, account.ReactivateStatus, account.UpdatedBy); err != nil {
+		return account, err
+	}
+	return account, nil
+}
+
+func (u *user) UpdateAccountDiscovery(ctx context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	if _, err := u.db.ExecContext(ctx, RepoUpdateAccountDiscovery, account.ID, account.IsHidden, account.UpdatedBy); err != nil {
+		return account, err
+	}
+	return account, nil
+}
+
+func (u *user) UpdateAccountPrivacy(ctx context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	if _, err := u.db.ExecContext(ctx, RepoUpdateAccountPrivacy, account.ID, account.HideAge, account.HideDistance, account.HideVisits, account.UpdatedBy); err != nil {
+		return account, err
+	}
+	return account, nil
+}
+
+func (u *user) AnonymizeAccount(ctx context.Context, trx *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+	if _, err := trx.ExecContext(ctx, RepoAnonymizeAccount, account.ID, account.Name, account.UserName, account.DeletedAt,
+		account.UpdatedBy); err != nil {
 		return account, err
 	}
 	return account, nil
@@ -45,7 +107,9 @@ func (u *user) UpdateAccountType(ctx context.Context, trx *sql.Tx, account model
 
 func (u *user) FindOneAccountByAccountMaskID(ctx context.Context, accountMaskID string) (output model.AccountBaseModel, err error) {
 	if err = u.db.QueryRowContext(ctx, RepoFindOneAccountByAccountMaskID, accountMaskID).
-		Scan(&output.ID, &output.AccountMaskID, &output.Type, &output.Name, &output.UserName, &output.IsVerified,
+		Scan(&output.ID, &output.AccountMaskID, &output.Type, &output.Role, &output.Status, &output.SuspendedUntil, &output.ReactivateStatus, &output.IsHidden, &output.DeletedAt, &output.Name, &output.UserName, &output.IsVerified,
+			&output.Email, &output.Phone, &output.EmailVerifiedAt, &output.PhoneVerifiedAt,
+			&output.Bio, &output.BirthDate, &output.Latitude, &output.Longitude, &output.HideAge, &output.HideDistance, &output.HideVisits,
 			&output.CreatedAt, &output.CreatedBy, &output.UpdatedAt, &output.UpdatedBy); err != nil {
 		return output, err
 	}
@@ -61,9 +125,14 @@ func (u *user) GetListAccountNewMatchPagination(ctx context.Context, req model.P
 
 	orderBy = `ORDER BY id DESC`
 	// Set condition
-	if req.AccountMaskID != "" {
-		condition += `AND account_mask_id != ? `
-		inputArgs = append(inputArgs, req.AccountMaskID)
+	condition, inputArgs = newMatchCondition(req)
+
+	// the boosted accounts served on top of the first page, boosted accounts beyond the slots stay in the pages
+	if len(req.ExcludeIDs) > 0 {
+		condition += `AND id NOT IN (?` + strings.Repeat(`, ?`, len(req.ExcludeIDs)-1) + `) `
+		for _, id := range req.ExcludeIDs {
+			inputArgs = append(inputArgs, id)
+		}
 	}
 
 	if req.CursorID != 0 && req.Direction == utils.DirectionNext {
@@ -88,3 +157,44 @@ func (u *user) GetListAccountNewMatchPagination(ctx context.Context, req model.P
 
 	return resp, nil
 }
+
+// GetListAccountBoosted accounts of the match list boosted within req.BoostRadiusKM of the viewer
+func (u *user) GetListAccountBoosted(ctx context.Context, req model.PaginationRequest) (output []model.AccountBaseModel, err error) {
+	if req.AccountMaskID == "" || req.BoostRadiusKM <= 0 {
+		return nil, nil
+	}
+
+	condition, inputArgs := newMatchCondition(req)
+	condition += `AND ` + RepoConditionBoostedNearbyAccount
+	inputArgs = append(inputArgs, req.AccountMaskID, req.BoostRadiusKM)
+
+	query := fmt.Sprintf(RepoGetListAccountNewMatchPagination, condition, `ORDER BY id DESC`, fmt.Sprintf("LIMIT %d", req.Limit))
+	if err = u.db.SelectContext(ctx, &output, u.db.Rebind(query), inputArgs...); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// IsAccountIncognitoFor report whether accountID is incognito to viewerID, an incognito account stays visible to the accounts it liked
+func (u *user) IsAccountIncognitoFor(ctx context.Context, accountID, viewerID int64) (incognito bool, err error) {
+	if err = u.db.QueryRowContext(ctx, RepoIsAccountIncognitoFor, accountID, viewerID).Scan(&incognito); err != nil {
+		return false, err
+	}
+
+	return incognito, nil
+}
+
+// newMatchCondition accounts the viewer may see: not itself, not blocked and not incognito unless it was liked by them
+func newMatchCondition(req model.PaginationRequest) (condition string, inputArgs []interface{}) {
+	if req.AccountMaskID == "" {
+		return "", nil
+	}
+
+	condition += `AND account_mask_id != ? `
+	condition += RepoConditionExcludeBlockedAccount
+	condition += RepoConditionExcludeIncognitoAccount
+	inputArgs = append(inputArgs, req.AccountMaskID, req.AccountMaskID, req.AccountMaskID)
+
+	return condition, inputArgs
+}
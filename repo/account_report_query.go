@@ -0,0 +1,28 @@
+package repo
+
+var (
+	// account_report
+	RepoInsertAccountReport = `
+	INSERT INTO account_report (reporter_id, reported_id, reason, details)
+		VALUES ($1, $2, $3, $4) RETURNING id, report_uid, status, created_at;`
+	RepoGetAccountReportByUID = `
+	SELECT ar.id, ar.report_uid, ar.reporter_id, ar.reported_id, ar.reason, ar.details, ar.status, ar.action, ar.note,
+	       ar.resolved_by, ar.resolved_at, ar.created_at, reporter.account_mask_id AS reporter_mask_id,
+	       reported.account_mask_id AS reported_mask_id, reported.user_name AS reported_user_name, reported.status AS reported_status
+		FROM account_report ar
+		INNER JOIN account reporter ON reporter.id = ar.reporter_id
+		INNER JOIN account reported ON reported.id = ar.reported_id
+		WHERE ar.report_uid = $1;`
+	RepoGetListAccountReport = `
+	SELECT ar.id, ar.report_uid, ar.reporter_id, ar.reported_id, ar.reason, ar.details, ar.status, ar.action, ar.note,
+	       ar.resolved_by, ar.resolved_at, ar.created_at, reporter.account_mask_id AS reporter_mask_id,
+	       reported.account_mask_id AS reported_mask_id, reported.user_name AS reported_user_name, reported.status AS reported_status
+		FROM account_report ar
+		INNER JOIN account reporter ON reporter.id = ar.reporter_id
+		INNER JOIN account reported ON reported.id = ar.reported_id
+		WHERE TRUE
+	%s %s %s;`
+	RepoResolveAccountReport = `
+	UPDATE account_report SET status = $2, action = $3, note = $4, resolved_by = $5, resolved_at = $6
+		WHERE id = $1 AND status = 'OPEN';`
+)
@@ -0,0 +1,91 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+type accountReportRepo struct {
+	db *sqlx.DB
+}
+
+func NewAccountReportRepo(db *sqlx.DB) interfaces.IAccountReportRepo {
+	return &accountReportRepo{db: db}
+}
+
+func (a *accountReportRepo) InsertAccountReport(ctx context.Context, req *model.AccountReportBaseModel) (err error) {
+	if err = a.db.QueryRowContext(ctx, RepoInsertAccountReport, req.ReporterID, req.ReportedID, req.Reason, req.Details).
+		Scan(&req.ID, &req.ReportUID, &req.Status, &req.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (a *accountReportRepo) GetAccountReportByUID(ctx context.Context, reportUID string) (output model.AccountReportBaseModel, err error) {
+	if err = a.db.GetContext(ctx, &output, RepoGetAccountReportByUID, reportUID); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+func (a *accountReportRepo) GetListAccountReportPagination(ctx context.Context, req model.ListAccountReportRequest) (output []model.AccountReportBaseModel, err error) {
+	var (
+		condition, offsetLimit, orderBy string
+		inputArgs                       []interface{}
+	)
+
+	// oldest report first so the queue is worked in order
+	orderBy = `ORDER BY ar.id ASC`
+	if req.Status != "" {
+		condition += `AND ar.status = ? `
+		inputArgs = append(inputArgs, req.Status)
+	}
+
+	if req.CursorID != 0 && req.Direction == utils.DirectionNext {
+		condition += `AND ar.id > ? `
+		inputArgs = append(inputArgs, req.CursorID)
+	}
+
+	if req.CursorID != 0 && req.Direction == utils.DirectionPrev {
+		condition += `AND ar.id < ? `
+		inputArgs = append(inputArgs, req.CursorID)
+		orderBy = `ORDER BY ar.id DESC`
+	}
+
+	if req.Limit != 0 {
+		offsetLimit = fmt.Sprintf("LIMIT %d", req.Limit)
+	}
+
+	query := fmt.Sprintf(RepoGetListAccountReport, condition, orderBy, offsetLimit)
+	if err = a.db.SelectContext(ctx, &output, a.db.Rebind(query), inputArgs...); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// ResolveAccountReport return sql.ErrNoRows when the report was already handled
+func (a *accountReportRepo) ResolveAccountReport(ctx context.Context, trx *sql.Tx, req *model.AccountReportBaseModel) (err error) {
+	res, err := trx.ExecContext(ctx, RepoResolveAccountReport, req.ID, req.Status, req.Action, req.Note, req.ResolvedBy, req.ResolvedAt)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,24 @@
+package repo
+
+var (
+	// credit_ledger
+	RepoInsertCreditLedger = `
+	INSERT INTO credit_ledger (account_id, credit_type, amount, reason, premium_package_id)
+		VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at;`
+
+	// the account row lock serialises the spends of one account, so two of them can not both see the same balance
+	RepoLockAccountCredit = `
+	SELECT id FROM account WHERE id = $1 FOR UPDATE;`
+
+	// nothing is inserted when the balance is lower than the amount spent
+	RepoSpendCredit = `
+	INSERT INTO credit_ledger (account_id, credit_type, amount, reason)
+	SELECT $1::int, $2::varchar, -$3::int, 'SPEND'
+		WHERE (SELECT COALESCE(SUM(amount), 0) FROM credit_ledger WHERE account_id = $1 AND credit_type = $2) >= $3
+	RETURNING id;`
+
+	RepoGetListCreditBalanceByAccountID = `
+	SELECT credit_type, SUM(amount) AS balance
+		FROM credit_ledger WHERE account_id = $1
+	GROUP BY credit_type;`
+)
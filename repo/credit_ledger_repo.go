@@ -0,0 +1,51 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type creditLedgerRepo struct {
+	db *sqlx.DB
+}
+
+func NewCreditLedgerRepo(db *sqlx.DB) interfaces.ICreditLedgerRepo {
+	return &creditLedgerRepo{db: db}
+}
+
+// InsertCreditLedger append an entry, req gets its id and created_at
+func (c *creditLedgerRepo) InsertCreditLedger(ctx context.Context, trx *sql.Tx, req *model.CreditLedgerBaseModel) (err error) {
+	if err = trx.QueryRowContext(ctx, RepoInsertCreditLedger, req.AccountID, req.CreditType, req.Amount, req.Reason, req.PremiumPackageID).
+		Scan(&req.ID, &req.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SpendCredit append a negative entry of amount, sql.ErrNoRows when the balance is too low.
+// The account row stays locked until trx ends, the action paid with the credit belongs in the same trx.
+func (c *creditLedgerRepo) SpendCredit(ctx context.Context, trx *sql.Tx, accountID int64, creditType string, amount int) (err error) {
+	var id int64
+	if err = trx.QueryRowContext(ctx, RepoLockAccountCredit, accountID).Scan(&id); err != nil {
+		return err
+	}
+
+	if err = trx.QueryRowContext(ctx, RepoSpendCredit, accountID, creditType, amount).Scan(&id); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetListCreditBalanceByAccountID balance of every credit type the account ever had
+func (c *creditLedgerRepo) GetListCreditBalanceByAccountID(ctx context.Context, accountID int64) (output []model.CreditBalance, err error) {
+	if err = c.db.SelectContext(ctx, &output, RepoGetListCreditBalanceByAccountID, accountID); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,40 @@
+package repo
+
+var (
+	// identity_verification
+	RepoInsertIdentityVerification = `
+	INSERT INTO identity_verification (account_id, selfie_path, status)
+		VALUES ($1, $2, $3) RETURNING id, verification_uid, created_at;`
+	RepoGetLatestIdentityVerificationByAccountID = `
+	SELECT iv.id, iv.verification_uid, iv.account_id, iv.selfie_path, iv.status, iv.reason, iv.reviewed_by, iv.reviewed_at,
+	       iv.created_at, account.account_mask_id, account.name, account.user_name
+		FROM identity_verification iv
+		INNER JOIN account ON account.id = iv.account_id
+		WHERE iv.account_id = $1
+	ORDER BY iv.id DESC LIMIT 1;`
+	RepoGetIdentityVerificationByUID = `
+	SELECT iv.id, iv.verification_uid, iv.account_id, iv.selfie_path, iv.status, iv.reason, iv.reviewed_by, iv.reviewed_at,
+	       iv.created_at, account.account_mask_id, account.name, account.user_name
+		FROM identity_verification iv
+		INNER JOIN account ON account.id = iv.account_id
+		WHERE iv.verification_uid = $1;`
+	RepoGetListIdentityVerification = `
+	SELECT iv.id, iv.verification_uid, iv.account_id, iv.selfie_path, iv.status, iv.reason, iv.reviewed_by, iv.reviewed_at,
+	       iv.created_at, account.account_mask_id, account.name, account.user_name
+		FROM identity_verification iv
+		INNER JOIN account ON account.id = iv.account_id
+		WHERE TRUE
+	%s %s %s;`
+	RepoReviewIdentityVerification = `
+	UPDATE identity_verification SET status = $2, reason = $3, reviewed_by = $4, reviewed_at = $5
+		WHERE id = $1 AND status = 'PENDING';`
+	RepoClearSelfieByAccountID = `
+	WITH selfie AS (
+		SELECT id, selfie_path FROM identity_verification
+		WHERE account_id = $1 AND selfie_path <> '' FOR UPDATE)
+	UPDATE identity_verification iv SET selfie_path = '',
+		status = CASE WHEN iv.status = 'PENDING' THEN 'REJECTED' ELSE iv.status END,
+		reason = CASE WHEN iv.status = 'PENDING' THEN 'account deleted' ELSE iv.reason END
+		FROM selfie WHERE iv.id = selfie.id
+	RETURNING selfie.selfie_path;`
+)
@@ -0,0 +1,118 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+type identityVerificationRepo struct {
+	db *sqlx.DB
+}
+
+func NewIdentityVerificationRepo(db *sqlx.DB) interfaces.IIdentityVerificationRepo {
+	return &identityVerificationRepo{db: db}
+}
+
+func (i *identityVerificationRepo) InsertIdentityVerification(ctx context.Context, req *model.IdentityVerificationBaseModel) (err error) {
+	if err = i.db.QueryRowContext(ctx, RepoInsertIdentityVerification, req.AccountID, req.SelfiePath, req.Status).
+		Scan(&req.ID, &req.VerificationUID, &req.CreatedAt); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (i *identityVerificationRepo) GetLatestIdentityVerificationByAccountID(ctx context.Context, accountID int64) (output model.IdentityVerificationBaseModel, err error) {
+	if err = i.db.GetContext(ctx, &output, RepoGetLatestIdentityVerificationByAccountID, accountID); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+func (i *identityVerificationRepo) GetIdentityVerificationByUID(ctx context.Context, verificationUID string) (output model.IdentityVerificationBaseModel, err error) {
+	if err = i.db.GetContext(ctx, &output, RepoGetIdentityVerificationByUID, verificationUID); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+func (i *identityVerificationRepo) GetListIdentityVerificationPagination(ctx context.Context, req model.ListIdentityVerificationRequest) (output []model.IdentityVerificationBaseModel, err error) {
+	var (
+		condition, offsetLimit, orderBy string
+		inputArgs                       []interface{}
+	)
+
+	// oldest submission first so the queue is reviewed in order
+	orderBy = `ORDER BY iv.id ASC`
+	if req.Status != "" {
+		condition += `AND iv.status = ? `
+		inputArgs = append(inputArgs, req.Status)
+	}
+
+	if req.CursorID != 0 && req.Direction == utils.DirectionNext {
+		condition += `AND iv.id > ? `
+		inputArgs = append(inputArgs, req.CursorID)
+	}
+
+	if req.CursorID != 0 && req.Direction == utils.DirectionPrev {
+		condition += `AND iv.id < ? `
+		inputArgs = append(inputArgs, req.CursorID)
+		orderBy = `ORDER BY iv.id DESC`
+	}
+
+	if req.Limit != 0 {
+		offsetLimit = fmt.Sprintf("LIMIT %d", req.Limit)
+	}
+
+	query := fmt.Sprintf(RepoGetListIdentityVerification, condition, orderBy, offsetLimit)
+	if err = i.db.SelectContext(ctx, &output, i.db.Rebind(query), inputArgs...); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// ReviewIdentityVerification return sql.ErrNoRows when the submission was already reviewed
+func (i *identityVerificationRepo) ReviewIdentityVerification(ctx context.Context, trx *sql.Tx, req *model.IdentityVerificationBaseModel) (err error) {
+	res, err := trx.ExecContext(ctx, RepoReviewIdentityVerification, req.ID, req.Status, req.Reason, req.ReviewedBy, req.ReviewedAt)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+// ClearSelfieByAccountID detach every selfie of the account and return the stored paths so the files can be removed
+func (i *identityVerificationRepo) ClearSelfieByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) (selfiePaths []string, err error) {
+	rows, err := trx.QueryContext(ctx, RepoClearSelfieByAccountID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var selfiePath string
+		if err = rows.Scan(&selfiePath); err != nil {
+			return nil, err
+		}
+		selfiePaths = append(selfiePaths, selfiePath)
+	}
+
+	return selfiePaths, rows.Err()
+}
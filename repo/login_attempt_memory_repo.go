@@ -0,0 +1,89 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"sync"
+	"time"
+)
+
+type loginAttemptMemoryRepo struct {
+	mu       sync.Mutex
+	attempts map[string]model.LoginAttemptBaseModel
+	calls    int
+}
+
+// NewLoginAttemptMemoryRepo login attempts kept in process, only correct with a single replica.
+func NewLoginAttemptMemoryRepo() interfaces.ILoginAttemptRepo {
+	return &loginAttemptMemoryRepo{attempts: make(map[string]model.LoginAttemptBaseModel)}
+}
+
+func (l *loginAttemptMemoryRepo) GetLoginAttempt(ctx context.Context, key string) (model.LoginAttemptBaseModel, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	attempt, ok := l.attempts[key]
+	if !ok {
+		return attempt, sql.ErrNoRows
+	}
+
+	return attempt, nil
+}
+
+func (l *loginAttemptMemoryRepo) IncrementLoginAttempt(ctx context.Context, key string, window time.Duration) (model.LoginAttemptBaseModel, error) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.calls++
+	if l.calls%memoryBucketSweepEvery == 0 {
+		l.sweep(now, window)
+	}
+
+	attempt, ok := l.attempts[key]
+	if !ok || attempt.LastFailedAt.Before(now.Add(-window)) {
+		attempt = model.LoginAttemptBaseModel{Key: key, LockedUntil: attempt.LockedUntil}
+	}
+
+	attempt.FailedCount++
+	attempt.LastFailedAt = now
+	l.attempts[key] = attempt
+
+	return attempt, nil
+}
+
+func (l *loginAttemptMemoryRepo) LockLoginAttempt(ctx context.Context, key string, lockedUntil time.Time) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	attempt, ok := l.attempts[key]
+	if !ok {
+		return nil
+	}
+
+	attempt.LockedUntil = sql.NullTime{Time: lockedUntil, Valid: true}
+	l.attempts[key] = attempt
+
+	return nil
+}
+
+func (l *loginAttemptMemoryRepo) ResetLoginAttempt(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.attempts, key)
+
+	return nil
+}
+
+// sweep drop attempts that are neither locked nor inside the window anymore
+func (l *loginAttemptMemoryRepo) sweep(now time.Time, window time.Duration) {
+	for key, attempt := range l.attempts {
+		if attempt.LastFailedAt.Before(now.Add(-window)) && attempt.LockedUntil.Time.Before(now) {
+			delete(l.attempts, key)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package repo
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type loginAttemptRepo struct {
+	db *sqlx.DB
+}
+
+func NewLoginAttemptRepo(db *sqlx.DB) interfaces.ILoginAttemptRepo {
+	return &loginAttemptRepo{db: db}
+}
+
+func (l *loginAttemptRepo) GetLoginAttempt(ctx context.Context, key string) (output model.LoginAttemptBaseModel, err error) {
+	if err = l.db.GetContext(ctx, &output, RepoGetLoginAttempt, key); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+func (l *loginAttemptRepo) IncrementLoginAttempt(ctx context.Context, key string, window time.Duration) (output model.LoginAttemptBaseModel, err error) {
+	if err = l.db.GetContext(ctx, &output, RepoIncrementLoginAttempt, key, window.Seconds()); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+func (l *loginAttemptRepo) LockLoginAttempt(ctx context.Context, key string, lockedUntil time.Time) error {
+	_, err := l.db.ExecContext(ctx, RepoLockLoginAttempt, key, lockedUntil)
+	return err
+}
+
+func (l *loginAttemptRepo) ResetLoginAttempt(ctx context.Context, key string) error {
+	_, err := l.db.ExecContext(ctx, RepoResetLoginAttempt, key)
+	return err
+}
@@ -0,0 +1,24 @@
+package repo
+
+var (
+	// account_otp
+	RepoInsertOTP = `
+	INSERT INTO account_otp (account_id, purpose, recipient, code_hash, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;`
+	RepoInvalidateOTP = `
+	UPDATE account_otp SET consumed_at = now()
+		WHERE account_id = $1 AND purpose = $2 AND consumed_at IS NULL;`
+	RepoGetLatestOTP = `
+	SELECT id, account_id, purpose, recipient, code_hash, attempts, expires_at, consumed_at, created_at
+		FROM account_otp
+		WHERE account_id = $1 AND purpose = $2
+	ORDER BY id DESC LIMIT 1;`
+	RepoIncrementOTPAttempt = `
+	UPDATE account_otp SET attempts = attempts + 1
+		WHERE id = $1 AND attempts < $2 RETURNING attempts;`
+	RepoConsumeOTP = `
+	UPDATE account_otp SET consumed_at = now()
+		WHERE id = $1 AND consumed_at IS NULL;`
+	RepoDeleteOTPByAccountID = `
+	DELETE FROM account_otp WHERE account_id = $1;`
+)
@@ -0,0 +1,78 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/jmoiron/sqlx"
+)
+
+type otpRepo struct {
+	db *sqlx.DB
+}
+
+func NewOTPRepo(db *sqlx.DB) interfaces.IOTPRepo {
+	return &otpRepo{db: db}
+}
+
+func (o *otpRepo) InsertOTP(ctx context.Context, trx *sql.Tx, req *model.OTPBaseModel) (err error) {
+	if err = trx.QueryRowContext(ctx, RepoInsertOTP, req.AccountID, req.Purpose, req.Recipient, req.CodeHash, req.ExpiresAt,
+		req.CreatedAt).Scan(&req.ID); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *otpRepo) InvalidateOTP(ctx context.Context, trx *sql.Tx, accountID int64, purpose string) (err error) {
+	if _, err = trx.ExecContext(ctx, RepoInvalidateOTP, accountID, purpose); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (o *otpRepo) GetLatestOTP(ctx context.Context, accountID int64, purpose string) (output model.OTPBaseModel, err error) {
+	if err = o.db.GetContext(ctx, &output, RepoGetLatestOTP, accountID, purpose); err != nil {
+		return output, err
+	}
+
+	return output, nil
+}
+
+// IncrementOTPAttempt return sql.ErrNoRows when the code already used maxAttempts guesses
+func (o *otpRepo) IncrementOTPAttempt(ctx context.Context, id int64, maxAttempts int) (attempts int, err error) {
+	if err = o.db.QueryRowContext(ctx, RepoIncrementOTPAttempt, id, maxAttempts).Scan(&attempts); err != nil {
+		return attempts, err
+	}
+
+	return attempts, nil
+}
+
+// ConsumeOTP return sql.ErrNoRows when the code was already used by a concurrent request
+func (o *otpRepo) ConsumeOTP(ctx context.Context, trx *sql.Tx, id int64) (err error) {
+	res, err := trx.ExecContext(ctx, RepoConsumeOTP, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (o *otpRepo) DeleteOTPByAccountID(ctx context.Context, trx *sql.Tx, accountID int64) (err error) {
+	if _, err = trx.ExecContext(ctx, RepoDeleteOTPByAccountID, accountID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -3,13 +3,25 @@ package repo
 var (
 	// premium package
 	RepoGetListPremiumPackage = `
-	SELECT "id", "package_uid", "title", "description", "price", "is_active", "created_at", "updated_at",
+	SELECT "id", "package_uid", "title", "description", "price", "is_active", "credit_type", "credit_amount", "created_at", "updated_at",
 	"created_by", "updated_by" FROM premium_package WHERE is_active IS TRUE 
 	%s %s %s;`
 	RepoGetPremiumPackageByPackageUID = `
-	SELECT "id", "package_uid", "title", "description", "price", "is_active", "created_at", "updated_at",
+	SELECT "id", "package_uid", "title", "description", "price", "is_active", "credit_type", "credit_amount", "created_at", "updated_at",
 	"created_by", "updated_by" FROM premium_package WHERE package_uid = $1;`
 
+	// the package is created once per title, the existing one is returned on later runs
+	RepoEnsurePremiumPackage = `
+	WITH existing AS (
+		SELECT "id", "package_uid" FROM premium_package WHERE title = $1 ORDER BY id ASC LIMIT 1),
+	inserted AS (
+		INSERT INTO premium_package ("title", "description", "price", "is_active", "credit_type", "credit_amount", "created_by")
+		SELECT $1, $2, $3, $4, $5, $6, $7 WHERE NOT EXISTS (SELECT 1 FROM existing)
+		RETURNING "id", "package_uid")
+	SELECT "id", "package_uid" FROM inserted
+	UNION ALL
+	SELECT "id", "package_uid" FROM existing;`
+
 	// premium package user
 	RepoGetPremiumPackageUserByAccountID = `
 	SELECT "premium_package_user"."id", "premium_package_user"."premium_package_id", "premium_package_user"."account_id", "premium_package_user"."purchased_date" FROM premium_package_user 
@@ -26,4 +38,23 @@ var (
 	FROM premium_package_user
 	INNER JOIN premium_package ON premium_package_user.premium_package_id = premium_package.id
 	WHERE premium_package.title = $1 AND account_id = $2;`
+
+	// consumable packages are bought as account effects or credit top ups
+	RepoGetListPurchaseByAccountID = `
+	SELECT package_uid, title, price, purchased_date FROM (
+		SELECT premium_package.package_uid, premium_package.title, premium_package.price, premium_package_user.purchased_date
+		FROM premium_package_user
+		INNER JOIN premium_package ON premium_package_user.premium_package_id = premium_package.id
+		WHERE premium_package_user.account_id = $1
+		UNION ALL
+		SELECT premium_package.package_uid, premium_package.title, premium_package.price, account_effect.created_at
+		FROM account_effect
+		INNER JOIN premium_package ON account_effect.premium_package_id = premium_package.id
+		WHERE account_effect.account_id = $1
+		UNION ALL
+		SELECT premium_package.package_uid, premium_package.title, premium_package.price, credit_ledger.created_at
+		FROM credit_ledger
+		INNER JOIN premium_package ON credit_ledger.premium_package_id = premium_package.id
+		WHERE credit_ledger.account_id = $1 AND credit_ledger.reason = 'PURCHASE') purchase
+	ORDER BY purchased_date ASC;`
 )
@@ -83,3 +83,21 @@ func (p *premiumPackageRepo) GetPremiumPackageUserByTitleAndAccountID(ctx contex
 
 	return output, nil
 }
+
+func (p *premiumPackageRepo) GetListPurchaseByAccountID(ctx context.Context, accountID int64) (output []model.AccountExportPurchase, err error) {
+	if err = p.db.SelectContext(ctx, &output, RepoGetListPurchaseByAccountID, accountID); err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+func (p *premiumPackageRepo) EnsurePremiumPackage(ctx context.Context, req *model.PremiumPackageBaseModel) (err error) {
+	if err = p.db.QueryRowContext(ctx, RepoEnsurePremiumPackage, req.Title, req.Description, req.Price, req.IsActive,
+		req.CreditType, req.CreditAmount, req.CreatedBy).
+		Scan(&req.ID, &req.PackageUID); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package repo
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"math"
+	"sync"
+	"time"
+)
+
+const memoryBucketSweepEvery = 1024
+
+type memoryBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	burst     float64
+	rate      float64
+}
+
+type rateLimitMemoryRepo struct {
+	mu      sync.Mutex
+	buckets map[string]*memoryBucket
+	calls   int
+}
+
+// NewRateLimitMemoryRepo token buckets kept in process, only correct with a single replica.
+func NewRateLimitMemoryRepo() interfaces.IRateLimitRepo {
+	return &rateLimitMemoryRepo{buckets: make(map[string]*memoryBucket)}
+}
+
+func (r *rateLimitMemoryRepo) Allow(ctx context.Context, key string, limit model.RateLimit) (resp model.RateLimitResult, err error) {
+	now := time.Now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.calls++
+	if r.calls%memoryBucketSweepEvery == 0 {
+		r.sweep(now)
+	}
+
+	bucket, ok := r.buckets[key]
+	if !ok {
+		bucket = &memoryBucket{tokens: float64(limit.Burst), updatedAt: now}
+		r.buckets[key] = bucket
+	}
+	bucket.burst = float64(limit.Burst)
+	bucket.rate = limit.Rate
+	bucket.refill(now)
+
+	if bucket.tokens < 1 {
+		resp.RetryAfter = retryAfter(bucket.tokens, limit.Rate)
+		return resp, nil
+	}
+
+	bucket.tokens--
+	resp.Allowed = true
+	return resp, nil
+}
+
+func (b *memoryBucket) refill(now time.Time) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.updatedAt).Seconds()*b.rate)
+	b.updatedAt = now
+}
+
+// sweep drop full buckets, they behave the same as a missing one
+func (r *rateLimitMemoryRepo) sweep(now time.Time) {
+	for key, bucket := range r.buckets {
+		bucket.refill(now)
+		if bucket.tokens >= bucket.burst {
+			delete(r.buckets, key)
+		}
+	}
+}
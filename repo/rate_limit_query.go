@@ -0,0 +1,33 @@
+package repo
+
+var (
+	// rate_limit_bucket
+	RepoTakeRateLimitToken = `
+	INSERT INTO rate_limit_bucket AS bucket (key, tokens, updated_at)
+		VALUES ($1, $3 - 1, now())
+	ON CONFLICT (key) DO UPDATE SET
+		tokens = LEAST($3, bucket.tokens + EXTRACT(EPOCH FROM (now() - bucket.updated_at)) * $2) - 1,
+		updated_at = now()
+	WHERE LEAST($3, bucket.tokens + EXTRACT(EPOCH FROM (now() - bucket.updated_at)) * $2) >= 1
+	RETURNING tokens;`
+	RepoGetRateLimitTokens = `
+	SELECT LEAST($3, tokens + EXTRACT(EPOCH FROM (now() - updated_at)) * $2)
+		FROM rate_limit_bucket WHERE key = $1;`
+
+	// login_attempt
+	RepoGetLoginAttempt = `
+	SELECT key, failed_count, locked_until, last_failed_at
+		FROM login_attempt WHERE key = $1;`
+	RepoIncrementLoginAttempt = `
+	INSERT INTO login_attempt AS attempt (key, failed_count, last_failed_at)
+		VALUES ($1, 1, now())
+	ON CONFLICT (key) DO UPDATE SET
+		failed_count = CASE WHEN attempt.last_failed_at < now() - make_interval(secs => $2)
+			THEN 1 ELSE attempt.failed_count + 1 END,
+		last_failed_at = now()
+	RETURNING key, failed_count, locked_until, last_failed_at;`
+	RepoLockLoginAttempt = `
+	UPDATE login_attempt SET locked_until = $2 WHERE key = $1;`
+	RepoResetLoginAttempt = `
+	DELETE FROM login_attempt WHERE key = $1;`
+)
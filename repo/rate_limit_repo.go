@@ -0,0 +1,50 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"time"
+)
+
+type rateLimitRepo struct {
+	db *sqlx.DB
+}
+
+// NewRateLimitRepo token buckets shared by every replica through postgres.
+func NewRateLimitRepo(db *sqlx.DB) interfaces.IRateLimitRepo {
+	return &rateLimitRepo{db: db}
+}
+
+func (r *rateLimitRepo) Allow(ctx context.Context, key string, limit model.RateLimit) (resp model.RateLimitResult, err error) {
+	var tokens float64
+	err = r.db.QueryRowContext(ctx, RepoTakeRateLimitToken, key, limit.Rate, limit.Burst).Scan(&tokens)
+	if err == nil {
+		resp.Allowed = true
+		return resp, nil
+	}
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		return resp, err
+	}
+
+	// bucket is empty, the update was skipped
+	if err = r.db.QueryRowContext(ctx, RepoGetRateLimitTokens, key, limit.Rate, limit.Burst).Scan(&tokens); err != nil {
+		return resp, err
+	}
+
+	resp.RetryAfter = retryAfter(tokens, limit.Rate)
+	return resp, nil
+}
+
+func retryAfter(tokens, rate float64) time.Duration {
+	if rate <= 0 {
+		return time.Hour
+	}
+
+	return time.Duration(math.Ceil((1-tokens)/rate*1000)) * time.Millisecond
+}
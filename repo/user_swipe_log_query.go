@@ -3,13 +3,33 @@ package repo
 var (
 	// user_swipe_log
 	RepoInsertUserSwipeLog = `
-	INSERT INTO user_swipe_log (swiper_id, swipee_id, swipe_type)
-		VALUES ($1, $2, $3) RETURNING id;`
+	INSERT INTO user_swipe_log (swiper_id, swipee_id, swipe_type, super_like)
+		VALUES ($1, $2, $3, $4) RETURNING id;`
+	RepoInsertUserSwipeLogHistory = `
+	INSERT INTO user_swipe_log (swiper_id, swipee_id, swipe_type, created_at)
+		VALUES %s;`
 	RepoGetUserSwipeLogBySwiperIDAndSwpeeID = `
 	SELECT id, swiper_id, swipee_id, swipe_type, created_at
 		FROM user_swipe_log
 		WHERE swiper_id = $1 AND swipee_id = $2 AND DATE(created_at) = (CURRENT_TIMESTAMP)::DATE
 	LIMIT 1;`
+	RepoGetListUserSwipeLogBySwiperID = `
+	SELECT swipee.account_mask_id AS swipee_mask_id, user_swipe_log.swipe_type, user_swipe_log.super_like, user_swipe_log.created_at
+		FROM user_swipe_log
+		INNER JOIN account swipee ON swipee.id = user_swipe_log.swipee_id
+		WHERE user_swipe_log.swiper_id = $1
+	ORDER BY user_swipe_log.id ASC;`
+
+	// a match is a LIKE in both directions, matched when the second LIKE happened
+	RepoGetListMatchByAccountID = `
+	SELECT other.account_mask_id, other.name, GREATEST(MIN(liked.created_at), MIN(liked_back.created_at)) AS matched_at
+		FROM user_swipe_log liked
+		INNER JOIN user_swipe_log liked_back ON liked_back.swiper_id = liked.swipee_id AND liked_back.swipee_id = liked.swiper_id
+			AND liked_back.swipe_type = 'LIKE'
+		INNER JOIN account other ON other.id = liked.swipee_id
+		WHERE liked.swiper_id = $1 AND liked.swipe_type = 'LIKE'
+	GROUP BY other.id, other.account_mask_id, other.name
+	ORDER BY matched_at ASC;`
 
 	// swipe_count
 	RepoGetSwipeCountByAccountMaskID = `
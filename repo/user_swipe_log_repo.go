@@ -2,9 +2,12 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"github.com/dwiangraeni/dealls/interfaces"
 	"github.com/dwiangraeni/dealls/model"
 	"github.com/jmoiron/sqlx"
+	"strings"
 )
 
 type userSwipeLog struct {
@@ -15,8 +18,9 @@ func NewUserSwipeLogRepo(db *sqlx.DB) interfaces.IUserSwipeLogRepo {
 	return &userSwipeLog{db: db}
 }
 
-func (u *userSwipeLog) InsertUserSwipeLog(ctx context.Context, req model.UserSwipeLogBaseModel) (model.UserSwipeLogBaseModel, error) {
-	if _, err := u.db.ExecContext(ctx, RepoInsertUserSwipeLog, req.SwiperID, req.SwipeeID, req.SwipeType); err != nil {
+// InsertUserSwipeLog insert the swipe in trx, the transaction of the credit it may spend
+func (u *userSwipeLog) InsertUserSwipeLog(ctx context.Context, trx *sql.Tx, req model.UserSwipeLogBaseModel) (model.UserSwipeLogBaseModel, error) {
+	if _, err := trx.ExecContext(ctx, RepoInsertUserSwipeLog, req.SwiperID, req.SwipeeID, req.SwipeType, req.SuperLike); err != nil {
 		return req, err
 	}
 	return req, nil
@@ -37,3 +41,37 @@ func (u *userSwipeLog) GetUserSwipeLogBySwiperIDAndSwpeeID(ctx context.Context,
 	}
 	return resp, err
 }
+
+func (u *userSwipeLog) GetListUserSwipeLogBySwiperID(ctx context.Context, swiperID int64) (output []model.AccountExportSwipe, err error) {
+	if err = u.db.SelectContext(ctx, &output, RepoGetListUserSwipeLogBySwiperID, swiperID); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+func (u *userSwipeLog) GetListMatchByAccountID(ctx context.Context, accountID int64) (output []model.AccountExportMatch, err error) {
+	if err = u.db.SelectContext(ctx, &output, RepoGetListMatchByAccountID, accountID); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// InsertUserSwipeLogHistory insert past swipes with their own created_at in one statement
+func (u *userSwipeLog) InsertUserSwipeLogHistory(ctx context.Context, req []model.UserSwipeLogBaseModel) (err error) {
+	if len(req) == 0 {
+		return nil
+	}
+
+	values := make([]string, 0, len(req))
+	inputArgs := make([]interface{}, 0, len(req)*4)
+	for _, swipe := range req {
+		values = append(values, "(?, ?, ?, ?)")
+		inputArgs = append(inputArgs, swipe.SwiperID, swipe.SwipeeID, swipe.SwipeType, swipe.CreatedAt)
+	}
+
+	query := fmt.Sprintf(RepoInsertUserSwipeLogHistory, strings.Join(values, ", "))
+	if _, err = u.db.ExecContext(ctx, u.db.Rebind(query), inputArgs...); err != nil {
+		return err
+	}
+	return nil
+}
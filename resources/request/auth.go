@@ -1,12 +1,26 @@
 package request
 
 type LoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username  string `json:"username"`
+	Password  string `json:"password"`
+	IPAddress string `json:"-"`
 }
 
 type RegisterRequest struct {
-	Name     string `json:"name"`
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Name     string `json:"name" valid:"required~name is required,runelength(1|45)~name must be at most 45 characters"`
+	Username string `json:"username" valid:"required~username is required,length(3|30)~username must be 3 to 30 characters,username~username may only contain letters digits dot or underscore"`
+	Password string `json:"password" valid:"required~password is required"`
+	Email    string `json:"email" valid:"optional,email~email is not valid,length(3|255)~email must be at most 255 characters"`
+	Phone    string `json:"phone" valid:"optional,phone~phone must be in international format"`
+}
+
+type ForgotPasswordRequest struct {
+	Username string `json:"username" valid:"required~username is required"`
+	Channel  string `json:"channel" valid:"optional,in(email|phone)~channel must be email or phone"`
+}
+
+type ResetPasswordRequest struct {
+	Username    string `json:"username" valid:"required~username is required"`
+	Code        string `json:"code" valid:"required~code is required,numeric~code must be numeric"`
+	NewPassword string `json:"new_password" valid:"required~new_password is required"`
 }
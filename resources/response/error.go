@@ -0,0 +1,162 @@
+package response
+
+import (
+	"errors"
+	"github.com/asaskevich/govalidator"
+	"net/http"
+	"time"
+)
+
+// Stable machine readable error codes, returned in the `code` field of every error response.
+// Clients must branch on the code, the message is meant for humans and may change.
+const (
+	CodeBadRequest       = "BAD_REQUEST"
+	CodeInvalidBody      = "INVALID_BODY"
+	CodeBodyTooLarge     = "BODY_TOO_LARGE"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInvalidCursor    = "INVALID_CURSOR"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeTokenInvalid     = "TOKEN_INVALID"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeConflict         = "CONFLICT"
+	CodeTooManyRequests  = "TOO_MANY_REQUESTS"
+	CodeInternal         = "INTERNAL"
+
+	// api versioning
+	CodeClientUpgradeRequired = "CLIENT_UPGRADE_REQUIRED"
+	CodeAPIVersionRetired     = "API_VERSION_RETIRED"
+
+	// auth
+	CodeInvalidCredentials = "INVALID_CREDENTIALS"
+	CodeDuplicateData      = "DUPLICATE_DATA"
+	CodeInvalidOTP         = "INVALID_OTP"
+
+	// account status
+	CodeAccountDeactivated = "ACCOUNT_DEACTIVATED"
+	CodeAccountSuspended   = "ACCOUNT_SUSPENDED"
+	CodeAccountBanned      = "ACCOUNT_BANNED"
+	CodeInvalidPassword    = "INVALID_PASSWORD"
+
+	// swipe and premium package
+	CodeSwipeLimitReached       = "SWIPE_LIMIT_REACHED"
+	CodeAlreadySwiped           = "ALREADY_SWIPED"
+	CodePackageAlreadyPurchased = "PACKAGE_ALREADY_PURCHASED"
+	CodeInsufficientCredit      = "INSUFFICIENT_CREDIT"
+
+	// contact and identity verification
+	CodeContactNotSet            = "CONTACT_NOT_SET"
+	CodeContactAlreadyVerified   = "CONTACT_ALREADY_VERIFIED"
+	CodeVerificationNotPurchased = "VERIFICATION_NOT_PURCHASED"
+	CodeAlreadyVerified          = "ALREADY_VERIFIED"
+	CodeVerificationPending      = "VERIFICATION_PENDING"
+	CodeVerificationReviewed     = "VERIFICATION_REVIEWED"
+	CodeRejectReasonRequired     = "REJECT_REASON_REQUIRED"
+	CodeInvalidSelfie            = "INVALID_SELFIE"
+	CodeSelfieTooLarge           = "SELFIE_TOO_LARGE"
+
+	// moderation
+	CodeCannotTargetSelf    = "CANNOT_TARGET_SELF"
+	CodeAccountUnavailable  = "ACCOUNT_UNAVAILABLE"
+	CodeReportAlreadyClosed = "REPORT_ALREADY_CLOSED"
+	CodeActionNotApplicable = "ACTION_NOT_APPLICABLE"
+
+	// account export
+	CodeExportInProgress = "EXPORT_IN_PROGRESS"
+	CodeExportNotReady   = "EXPORT_NOT_READY"
+	CodeExportExpired    = "EXPORT_EXPIRED"
+)
+
+// internalMessage the only message an unexpected error is reported with, the cause stays in the logs
+const internalMessage = "error internal"
+
+// Error an error that knows how it is reported, implemented by utils.AppError.
+type Error interface {
+	error
+	ErrorCode() string
+	HTTPStatus() int
+	ErrorDetails() map[string]string
+}
+
+// retryable errors that tell the client when to come back, e.g. utils.RateLimitedError
+type retryable interface {
+	RetryDelay() time.Duration
+}
+
+// HandleAppError the single mapping from a service or handler error to the error response.
+// Validation errors become 400 VALIDATION_FAILED with the failing fields, errors implementing Error
+// are reported with their own status, code and message, anything else is a 500 that leaks nothing.
+func HandleAppError(resp http.ResponseWriter, err error) {
+	var (
+		validationErrs govalidator.Errors
+		appErr         Error
+		retry          retryable
+	)
+
+	if errors.As(err, &validationErrs) {
+		writeError(resp, http.StatusBadRequest, ResponseWrapper{
+			Code:    CodeValidationFailed,
+			Message: "invalid parameters, please check your input",
+			Errors:  govalidator.ErrorsByField(err),
+		})
+		return
+	}
+
+	if !errors.As(err, &appErr) {
+		HandleErrorWithCode(resp, http.StatusInternalServerError, CodeInternal, internalMessage)
+		return
+	}
+
+	if errors.As(err, &retry) {
+		setRetryAfter(resp, retry.RetryDelay())
+	}
+
+	message := appErr.Error()
+	if appErr.HTTPStatus() >= http.StatusInternalServerError {
+		message = internalMessage
+	}
+
+	writeError(resp, appErr.HTTPStatus(), ResponseWrapper{
+		Code:    appErr.ErrorCode(),
+		Message: message,
+		Errors:  appErr.ErrorDetails(),
+	})
+}
+
+// HandleErrorWithCode respond an error with an explicit code, for callers that cannot build an Error.
+func HandleErrorWithCode(resp http.ResponseWriter, status int, code, msg string) {
+	writeError(resp, status, ResponseWrapper{Code: code, Message: msg})
+}
+
+// HandleUpgradeRequired respond 426 to an app build older than minVersion.
+func HandleUpgradeRequired(resp http.ResponseWriter, minVersion string) {
+	writeError(resp, http.StatusUpgradeRequired, ResponseWrapper{
+		Code:    CodeClientUpgradeRequired,
+		Message: "this app version is no longer supported, please update the app",
+		Errors:  map[string]string{"min_version": minVersion},
+	})
+}
+
+// codeFromStatus default code of the responses written without one
+func codeFromStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeBadRequest
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	}
+
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+
+	return CodeBadRequest
+}
@@ -2,14 +2,19 @@ package response
 
 import (
 	"encoding/json"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 type ResponseWrapper struct {
-	Data     interface{} `json:"data"`
-	Message  string      `json:"message"`
-	Success  bool        `json:"success"`
-	MetaData interface{} `json:"_metadata,omitempty"`
+	Data     interface{}       `json:"data"`
+	Message  string            `json:"message"`
+	Success  bool              `json:"success"`
+	Code     string            `json:"code,omitempty"`
+	MetaData interface{}       `json:"_metadata,omitempty"`
+	Errors   map[string]string `json:"errors,omitempty"`
 }
 
 func HandleSuccess(resp http.ResponseWriter, data interface{}, metaData ...map[string]interface{}) {
@@ -33,18 +38,28 @@ func HandleSuccess(resp http.ResponseWriter, data interface{}, metaData ...map[s
 	resp.Write(jsonData)
 }
 
+// HandleError respond an error with the default code of status.
 func HandleError(resp http.ResponseWriter, status int, msg string) {
-	errs := ResponseWrapper{
-		Success: false,
-		Message: msg,
-	}
+	writeError(resp, status, ResponseWrapper{Code: codeFromStatus(status), Message: msg})
+}
+
+// HandleTooManyRequests respond 429 with Retry-After in whole seconds.
+func HandleTooManyRequests(resp http.ResponseWriter, retryAfter time.Duration, msg string) {
+	setRetryAfter(resp, retryAfter)
+	HandleError(resp, http.StatusTooManyRequests, msg)
+}
+
+func writeError(resp http.ResponseWriter, status int, errs ResponseWrapper) {
+	errs.Success = false
 
 	resp.Header().Set("Content-Type", "application/json")
 	resp.WriteHeader(status)
 
-	err := json.NewEncoder(resp).Encode(errs)
-	if err != nil {
-		resp.WriteHeader(http.StatusInternalServerError)
+	if err := json.NewEncoder(resp).Encode(errs); err != nil {
 		resp.Write([]byte("Ooops, something error"))
 	}
 }
+
+func setRetryAfter(resp http.ResponseWriter, retryAfter time.Duration) {
+	resp.Header().Set("Retry-After", strconv.Itoa(int(math.Max(1, math.Ceil(retryAfter.Seconds())))))
+}
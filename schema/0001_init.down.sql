@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS "premium_package_user";
+DROP TABLE IF EXISTS "premium_package";
+
+DROP TRIGGER IF EXISTS trigger_update_swipe_count ON "user_swipe_log";
+DROP FUNCTION IF EXISTS update_swipe_count();
+DROP TABLE IF EXISTS "user_swipe_log";
+
+DROP TRIGGER IF EXISTS set_timestamp_last_update ON "swipe_count";
+DROP FUNCTION IF EXISTS trigger_set_timestamp_last_update();
+DROP TABLE IF EXISTS "swipe_count";
+
+DROP TABLE IF EXISTS "account";
+
+DROP TYPE IF EXISTS "swipe_type";
+DROP TYPE IF EXISTS "account_type";
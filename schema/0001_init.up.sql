@@ -10,13 +10,8 @@ CREATE TYPE "swipe_type" AS ENUM (
   'PASS'
 );
 
--- create function to generate uuid
-CREATE
-OR REPLACE FUNCTION uuid_generate_v4()
- RETURNS uuid
- LANGUAGE c
- PARALLEL SAFE STRICT
-AS '$libdir/uuid-ossp', $function$uuid_generate_v4$function$;
+-- uuid_generate_v4 comes from the uuid-ossp extension
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
 
 -- create table account
 CREATE TABLE "account"
@@ -44,11 +39,12 @@ CREATE TABLE "swipe_count"
     "account_id"        int       NOT NULL,
     "total_swipe_a_day" int       NOT NULL,
     "total_swipe"       int       NOT NULL,
-    "last_updated_at"   timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+    "last_updated_at"   timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
 );
 
 -- create unique index on swipe_count
-CREATE UNIQUE INDEX ON "swipe_count_account_id_unique" USING BTREE ("account_id");
+CREATE UNIQUE INDEX "swipe_count_account_id_unique" ON "swipe_count" USING BTREE ("account_id");
 
 -- create foreign key
 ALTER TABLE "swipe_count"
@@ -80,7 +76,8 @@ CREATE TABLE "user_swipe_log"
     "swiper_id"  int        NOT NULL,
     "swipee_id"  int        NOT NULL,
     "swipe_type" swipe_type NOT NULL,
-    "created_at" timestamp  NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+    "created_at" timestamp  NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
 );
 
 ALTER TABLE "user_swipe_log"
@@ -141,7 +138,8 @@ CREATE TABLE "premium_package"
     "created_at"  timestamp    NOT NULL DEFAULT (CURRENT_TIMESTAMP),
     "created_by"  varchar(225) NOT NULL,
     "updated_at"  timestamp    NOT NULL DEFAULT (CURRENT_TIMESTAMP),
-    "updated_by"  varchar(225)
+    "updated_by"  varchar(225),
+    PRIMARY KEY ("id")
 );
 
 CREATE TABLE "premium_package_user"
@@ -149,7 +147,8 @@ CREATE TABLE "premium_package_user"
     "id"                 SERIAL    NOT NULL,
     "premium_package_id" int       NOT NULL,
     "account_id"         int       NOT NULL,
-    "purchased_date"      timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP)
+    "purchased_date"     timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
 );
 
 ALTER TABLE "premium_package_user"
@@ -157,4 +156,4 @@ ALTER TABLE "premium_package_user"
 ALTER TABLE "premium_package_user"
     ADD CONSTRAINT "fk_premium_package_user_account_id" FOREIGN KEY ("account_id") REFERENCES "account" ("id");
 
-CREATE UNIQUE INDEX IF NOT EXISTS premium_package_user_account_id_premium_package_id_unique_idx ON premium_package_user (premium_package_id,account_id);
\ No newline at end of file
+CREATE UNIQUE INDEX IF NOT EXISTS premium_package_user_account_id_premium_package_id_unique_idx ON premium_package_user (premium_package_id,account_id);
@@ -0,0 +1,18 @@
+-- create table rate_limit_bucket, token bucket state shared by every replica
+CREATE TABLE "rate_limit_bucket"
+(
+    "key"        varchar(255)     NOT NULL,
+    "tokens"     double precision NOT NULL,
+    "updated_at" timestamp        NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("key")
+);
+
+-- create table login_attempt, failed logins per username and per ip
+CREATE TABLE "login_attempt"
+(
+    "key"            varchar(255) NOT NULL,
+    "failed_count"   int          NOT NULL DEFAULT 0,
+    "locked_until"   timestamp,
+    "last_failed_at" timestamp    NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("key")
+);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "account_otp";
+DROP TYPE IF EXISTS "otp_purpose";
+
+DROP INDEX IF EXISTS "account_phone_unique_idx";
+DROP INDEX IF EXISTS "account_email_unique_idx";
+
+ALTER TABLE "account"
+    DROP COLUMN IF EXISTS "phone_verified_at",
+    DROP COLUMN IF EXISTS "email_verified_at",
+    DROP COLUMN IF EXISTS "phone",
+    DROP COLUMN IF EXISTS "email";
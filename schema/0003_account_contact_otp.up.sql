@@ -0,0 +1,37 @@
+-- add contact columns to account
+ALTER TABLE "account"
+    ADD COLUMN "email"             varchar(255),
+    ADD COLUMN "phone"             varchar(20),
+    ADD COLUMN "email_verified_at" timestamp,
+    ADD COLUMN "phone_verified_at" timestamp;
+
+-- create unique index on account contact
+CREATE UNIQUE INDEX "account_email_unique_idx" ON "account" (lower("email")) WHERE "email" IS NOT NULL;
+CREATE UNIQUE INDEX "account_phone_unique_idx" ON "account" ("phone") WHERE "phone" IS NOT NULL;
+
+-- create enum otp_purpose
+CREATE TYPE "otp_purpose" AS ENUM (
+  'VERIFY_EMAIL',
+  'VERIFY_PHONE',
+  'RESET_PASSWORD'
+);
+
+-- create table account_otp, codes are stored as hmac only
+CREATE TABLE "account_otp"
+(
+    "id"           SERIAL       NOT NULL,
+    "account_id"   int          NOT NULL,
+    "purpose"      otp_purpose  NOT NULL,
+    "recipient"    varchar(255) NOT NULL,
+    "code_hash"    varchar(64)  NOT NULL,
+    "attempts"     int          NOT NULL DEFAULT 0,
+    "expires_at"   timestamp    NOT NULL,
+    "consumed_at"  timestamp,
+    "created_at"   timestamp    NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "account_otp"
+    ADD CONSTRAINT "fk_account_otp_account_id" FOREIGN KEY ("account_id") REFERENCES "account" ("id");
+
+CREATE INDEX "account_otp_account_id_purpose_idx" ON "account_otp" ("account_id", "purpose");
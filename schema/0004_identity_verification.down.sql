@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS "identity_verification";
+DROP TYPE IF EXISTS "identity_verification_status";
+
+ALTER TABLE "account"
+    DROP COLUMN IF EXISTS "role";
+DROP TYPE IF EXISTS "account_role";
@@ -0,0 +1,40 @@
+-- create enum account_role
+CREATE TYPE "account_role" AS ENUM (
+  'USER',
+  'ADMIN'
+);
+
+-- add role column to account
+ALTER TABLE "account"
+    ADD COLUMN "role" account_role NOT NULL DEFAULT 'USER';
+
+-- create enum identity_verification_status
+CREATE TYPE "identity_verification_status" AS ENUM (
+  'PENDING',
+  'APPROVED',
+  'REJECTED'
+);
+
+-- create table identity_verification, one row per selfie submission
+CREATE TABLE "identity_verification"
+(
+    "id"               SERIAL                       NOT NULL,
+    "verification_uid" uuid UNIQUE                  NOT NULL DEFAULT (uuid_generate_v4()),
+    "account_id"       int                          NOT NULL,
+    "selfie_path"      varchar(255)                 NOT NULL,
+    "status"           identity_verification_status NOT NULL DEFAULT 'PENDING',
+    "reason"           text,
+    "reviewed_by"      varchar(225),
+    "reviewed_at"      timestamp,
+    "created_at"       timestamp                    NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "identity_verification"
+    ADD CONSTRAINT "fk_identity_verification_account_id" FOREIGN KEY ("account_id") REFERENCES "account" ("id");
+
+-- an account can only have one submission waiting for review
+CREATE UNIQUE INDEX "identity_verification_account_id_pending_unique_idx" ON "identity_verification" ("account_id")
+    WHERE "status" = 'PENDING';
+
+CREATE INDEX "identity_verification_status_idx" ON "identity_verification" ("status", "id");
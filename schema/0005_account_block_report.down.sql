@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "account_report";
+DROP TYPE IF EXISTS "moderation_action";
+DROP TYPE IF EXISTS "report_status";
+DROP TYPE IF EXISTS "report_reason";
+
+DROP TABLE IF EXISTS "account_block";
+
+ALTER TABLE "account"
+    DROP COLUMN IF EXISTS "suspended_until",
+    DROP COLUMN IF EXISTS "status";
+DROP TYPE IF EXISTS "account_status";
@@ -0,0 +1,81 @@
+-- create enum account_status
+CREATE TYPE "account_status" AS ENUM (
+  'ACTIVE',
+  'WARNED',
+  'SUSPENDED',
+  'BANNED'
+);
+
+-- add moderation status to account, suspended_until is NULL for an indefinite suspension
+ALTER TABLE "account"
+    ADD COLUMN "status"          account_status NOT NULL DEFAULT 'ACTIVE',
+    ADD COLUMN "suspended_until" timestamp;
+
+-- create table account_block, a block hides both accounts from each other
+CREATE TABLE "account_block"
+(
+    "id"         SERIAL    NOT NULL,
+    "blocker_id" int       NOT NULL,
+    "blocked_id" int       NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "account_block"
+    ADD CONSTRAINT "fk_account_block_blocker_id" FOREIGN KEY ("blocker_id") REFERENCES "account" ("id");
+ALTER TABLE "account_block"
+    ADD CONSTRAINT "fk_account_block_blocked_id" FOREIGN KEY ("blocked_id") REFERENCES "account" ("id");
+
+CREATE UNIQUE INDEX "account_block_blocker_id_blocked_id_unique_idx" ON "account_block" ("blocker_id", "blocked_id");
+CREATE INDEX "account_block_blocked_id_idx" ON "account_block" ("blocked_id");
+
+-- create enum report_reason
+CREATE TYPE "report_reason" AS ENUM (
+  'SPAM',
+  'FAKE_PROFILE',
+  'INAPPROPRIATE_CONTENT',
+  'HARASSMENT',
+  'UNDERAGE',
+  'SCAM',
+  'OTHER'
+);
+
+-- create enum report_status
+CREATE TYPE "report_status" AS ENUM (
+  'OPEN',
+  'RESOLVED',
+  'DISMISSED'
+);
+
+-- create enum moderation_action
+CREATE TYPE "moderation_action" AS ENUM (
+  'WARN',
+  'SUSPEND',
+  'BAN'
+);
+
+-- create table account_report, the admin moderation queue
+CREATE TABLE "account_report"
+(
+    "id"          SERIAL            NOT NULL,
+    "report_uid"  uuid UNIQUE       NOT NULL DEFAULT (uuid_generate_v4()),
+    "reporter_id" int               NOT NULL,
+    "reported_id" int               NOT NULL,
+    "reason"      report_reason     NOT NULL,
+    "details"     text,
+    "status"      report_status     NOT NULL DEFAULT 'OPEN',
+    "action"      moderation_action,
+    "note"        text,
+    "resolved_by" varchar(225),
+    "resolved_at" timestamp,
+    "created_at"  timestamp         NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "account_report"
+    ADD CONSTRAINT "fk_account_report_reporter_id" FOREIGN KEY ("reporter_id") REFERENCES "account" ("id");
+ALTER TABLE "account_report"
+    ADD CONSTRAINT "fk_account_report_reported_id" FOREIGN KEY ("reported_id") REFERENCES "account" ("id");
+
+CREATE INDEX "account_report_status_idx" ON "account_report" ("status", "id");
+CREATE INDEX "account_report_reported_id_idx" ON "account_report" ("reported_id");
@@ -0,0 +1,21 @@
+-- enum values cannot be dropped, rebuild account_status without the lifecycle states
+UPDATE "account" SET "status" = 'BANNED' WHERE "status" = 'DELETED';
+UPDATE "account" SET "status" = 'ACTIVE' WHERE "status" = 'DEACTIVATED';
+
+ALTER TABLE "account"
+    ALTER COLUMN "status" DROP DEFAULT;
+ALTER TYPE "account_status" RENAME TO "account_status_old";
+CREATE TYPE "account_status" AS ENUM (
+  'ACTIVE',
+  'WARNED',
+  'SUSPENDED',
+  'BANNED'
+);
+ALTER TABLE "account"
+    ALTER COLUMN "status" TYPE account_status USING "status"::text::account_status,
+    ALTER COLUMN "status" SET DEFAULT 'ACTIVE';
+DROP TYPE "account_status_old";
+
+ALTER TABLE "account"
+    DROP COLUMN IF EXISTS "deleted_at",
+    DROP COLUMN IF EXISTS "is_hidden";
@@ -0,0 +1,8 @@
+-- extend account_status with the self-service lifecycle states
+ALTER TYPE "account_status" ADD VALUE IF NOT EXISTS 'DEACTIVATED';
+ALTER TYPE "account_status" ADD VALUE IF NOT EXISTS 'DELETED';
+
+-- hide from discovery toggle and deletion timestamp, deleted rows are anonymized but kept for foreign keys
+ALTER TABLE "account"
+    ADD COLUMN "is_hidden"  bool NOT NULL DEFAULT false,
+    ADD COLUMN "deleted_at" timestamp;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "user_swipe_log_swiper_id_idx";
+
+DROP TABLE IF EXISTS "account_export";
+DROP TYPE IF EXISTS "account_export_status";
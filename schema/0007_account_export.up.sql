@@ -0,0 +1,34 @@
+-- create enum account_export_status
+CREATE TYPE "account_export_status" AS ENUM (
+  'PENDING',
+  'PROCESSING',
+  'DONE',
+  'FAILED'
+);
+
+-- create table account_export, one row per personal data export job
+CREATE TABLE "account_export"
+(
+    "id"           SERIAL                NOT NULL,
+    "export_uid"   uuid UNIQUE           NOT NULL DEFAULT (uuid_generate_v4()),
+    "account_id"   int                   NOT NULL,
+    "status"       account_export_status NOT NULL DEFAULT 'PENDING',
+    "file_path"    varchar(255)          NOT NULL DEFAULT '',
+    "error"        text,
+    "started_at"   timestamp,
+    "completed_at" timestamp,
+    "created_at"   timestamp             NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "account_export"
+    ADD CONSTRAINT "fk_account_export_account_id" FOREIGN KEY ("account_id") REFERENCES "account" ("id");
+
+-- an account can only have one export queued or running
+CREATE UNIQUE INDEX "account_export_account_id_active_unique_idx" ON "account_export" ("account_id")
+    WHERE "status" IN ('PENDING', 'PROCESSING');
+
+CREATE INDEX "account_export_status_idx" ON "account_export" ("status", "id");
+
+-- swipe history is read per swiper for the export
+CREATE INDEX IF NOT EXISTS "user_swipe_log_swiper_id_idx" ON "user_swipe_log" ("swiper_id");
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "profile_view";
+DROP TABLE IF EXISTS "account_interest";
+DROP TABLE IF EXISTS "account_photo";
+
+ALTER TABLE "account"
+    DROP COLUMN IF EXISTS "hide_distance",
+    DROP COLUMN IF EXISTS "hide_age",
+    DROP COLUMN IF EXISTS "longitude",
+    DROP COLUMN IF EXISTS "latitude",
+    DROP COLUMN IF EXISTS "birth_date",
+    DROP COLUMN IF EXISTS "bio";
@@ -0,0 +1,53 @@
+-- profile card fields, hide_age and hide_distance are privacy settings of the owner
+ALTER TABLE "account"
+    ADD COLUMN "bio"           varchar(500),
+    ADD COLUMN "birth_date"    date,
+    ADD COLUMN "latitude"      double precision,
+    ADD COLUMN "longitude"     double precision,
+    ADD COLUMN "hide_age"      bool NOT NULL DEFAULT false,
+    ADD COLUMN "hide_distance" bool NOT NULL DEFAULT false;
+
+-- create table account_photo, position 0 is the main photo
+CREATE TABLE "account_photo"
+(
+    "id"         SERIAL       NOT NULL,
+    "account_id" int          NOT NULL,
+    "url"        varchar(500) NOT NULL,
+    "position"   int          NOT NULL DEFAULT 0,
+    "created_at" timestamp    NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "account_photo"
+    ADD CONSTRAINT "fk_account_photo_account_id" FOREIGN KEY ("account_id") REFERENCES "account" ("id");
+
+CREATE INDEX "account_photo_account_id_position_idx" ON "account_photo" ("account_id", "position");
+
+-- create table account_interest
+CREATE TABLE "account_interest"
+(
+    "account_id" int         NOT NULL,
+    "interest"   varchar(50) NOT NULL,
+    PRIMARY KEY ("account_id", "interest")
+);
+
+ALTER TABLE "account_interest"
+    ADD CONSTRAINT "fk_account_interest_account_id" FOREIGN KEY ("account_id") REFERENCES "account" ("id");
+
+-- create table profile_view, one row per profile viewed by another account
+CREATE TABLE "profile_view"
+(
+    "id"         SERIAL    NOT NULL,
+    "viewer_id"  int       NOT NULL,
+    "viewed_id"  int       NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "profile_view"
+    ADD CONSTRAINT "fk_profile_view_viewer_id" FOREIGN KEY ("viewer_id") REFERENCES "account" ("id");
+ALTER TABLE "profile_view"
+    ADD CONSTRAINT "fk_profile_view_viewed_id" FOREIGN KEY ("viewed_id") REFERENCES "account" ("id");
+
+CREATE INDEX "profile_view_viewed_id_created_at_idx" ON "profile_view" ("viewed_id", "created_at");
+CREATE INDEX "profile_view_viewer_id_idx" ON "profile_view" ("viewer_id");
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "profile_view_viewed_id_viewer_id_view_date_key";
+
+ALTER TABLE "profile_view"
+    DROP COLUMN IF EXISTS "view_date";
+
+ALTER TABLE "account"
+    DROP COLUMN IF EXISTS "incognito";
@@ -0,0 +1,18 @@
+-- incognito accounts browse profiles without showing up in the visitors list
+ALTER TABLE "account"
+    ADD COLUMN "incognito" bool NOT NULL DEFAULT false;
+
+-- a viewer is counted once per day, repeated views on the same day are not recorded again
+ALTER TABLE "profile_view"
+    ADD COLUMN "view_date" date NOT NULL DEFAULT (CURRENT_DATE);
+
+UPDATE "profile_view" SET "view_date" = "created_at"::date;
+
+DELETE FROM "profile_view" pv
+    USING "profile_view" earlier
+    WHERE earlier."viewed_id" = pv."viewed_id"
+      AND earlier."viewer_id" = pv."viewer_id"
+      AND earlier."view_date" = pv."view_date"
+      AND earlier."id" < pv."id";
+
+CREATE UNIQUE INDEX "profile_view_viewed_id_viewer_id_view_date_key" ON "profile_view" ("viewed_id", "viewer_id", "view_date");
@@ -0,0 +1,21 @@
+-- create table account_effect, a time boxed effect bought with a consumable package (BOOST, INCOGNITO).
+-- A purchase while the same effect is running is queued after it, so effects of one type never overlap.
+CREATE TABLE "account_effect"
+(
+    "id"                 SERIAL      NOT NULL,
+    "account_id"         int         NOT NULL,
+    "premium_package_id" int         NOT NULL,
+    "effect_type"        varchar(20) NOT NULL,
+    "starts_at"          timestamp   NOT NULL,
+    "ends_at"            timestamp   NOT NULL,
+    "created_at"         timestamp   NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "account_effect"
+    ADD CONSTRAINT "fk_account_effect_account_id" FOREIGN KEY ("account_id") REFERENCES "account" ("id");
+ALTER TABLE "account_effect"
+    ADD CONSTRAINT "fk_account_effect_premium_package_id" FOREIGN KEY ("premium_package_id") REFERENCES "premium_package" ("id");
+
+CREATE INDEX "account_effect_account_id_effect_type_ends_at_idx" ON "account_effect" ("account_id", "effect_type", "ends_at");
+CREATE INDEX "account_effect_effect_type_ends_at_idx" ON "account_effect" ("effect_type", "ends_at");
@@ -0,0 +1,14 @@
+ALTER TABLE "user_swipe_log"
+    DROP COLUMN IF EXISTS "super_like";
+
+DELETE FROM "account_effect" WHERE "premium_package_id" IS NULL;
+ALTER TABLE "account_effect"
+    ALTER COLUMN "premium_package_id" SET NOT NULL;
+
+DROP TABLE IF EXISTS "credit_ledger";
+DROP FUNCTION IF EXISTS reject_credit_ledger_change();
+
+ALTER TABLE "premium_package"
+    DROP CONSTRAINT IF EXISTS "premium_package_credit_check",
+    DROP COLUMN IF EXISTS "credit_amount",
+    DROP COLUMN IF EXISTS "credit_type";
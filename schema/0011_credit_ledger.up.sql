@@ -0,0 +1,51 @@
+-- a credit package grants credit_amount credits of credit_type on every purchase (SUPER_LIKE, BOOST, SWIPE)
+ALTER TABLE "premium_package"
+    ADD COLUMN "credit_type"   varchar(20),
+    ADD COLUMN "credit_amount" int NOT NULL DEFAULT 0,
+    ADD CONSTRAINT "premium_package_credit_check" CHECK (("credit_type" IS NULL) = ("credit_amount" = 0));
+
+-- BOOST was bought one effect at a time, it now grants one boost credit that is spent to start the effect
+UPDATE "premium_package" SET "credit_type" = 'BOOST', "credit_amount" = 1 WHERE "title" = 'BOOST';
+
+-- create table credit_ledger, append only: a purchase adds a positive entry, an action spends a negative one.
+-- The balance of a credit type is the sum of its entries.
+CREATE TABLE "credit_ledger"
+(
+    "id"                 SERIAL      NOT NULL,
+    "account_id"         int         NOT NULL,
+    "credit_type"        varchar(20) NOT NULL,
+    "amount"             int         NOT NULL CHECK ("amount" <> 0),
+    "reason"             varchar(20) NOT NULL,
+    "premium_package_id" int,
+    "created_at"         timestamp   NOT NULL DEFAULT (CURRENT_TIMESTAMP),
+    PRIMARY KEY ("id")
+);
+
+ALTER TABLE "credit_ledger"
+    ADD CONSTRAINT "fk_credit_ledger_account_id" FOREIGN KEY ("account_id") REFERENCES "account" ("id");
+ALTER TABLE "credit_ledger"
+    ADD CONSTRAINT "fk_credit_ledger_premium_package_id" FOREIGN KEY ("premium_package_id") REFERENCES "premium_package" ("id");
+
+CREATE INDEX "credit_ledger_account_id_credit_type_idx" ON "credit_ledger" ("account_id", "credit_type");
+
+CREATE
+OR REPLACE FUNCTION reject_credit_ledger_change() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    RAISE EXCEPTION 'credit_ledger is append only';
+END;
+$$;
+
+CREATE TRIGGER credit_ledger_append_only
+    BEFORE UPDATE OR DELETE
+    ON credit_ledger
+    FOR EACH ROW EXECUTE FUNCTION reject_credit_ledger_change();
+
+-- a boost started with a credit is not tied to a purchase
+ALTER TABLE "account_effect"
+    ALTER COLUMN "premium_package_id" DROP NOT NULL;
+
+-- a super like is a LIKE paid with a SUPER_LIKE credit
+ALTER TABLE "user_swipe_log"
+    ADD COLUMN "super_like" bool NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+ALTER TABLE "account"
+    RENAME COLUMN "hide_visits" TO "incognito";
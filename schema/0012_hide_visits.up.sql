@@ -0,0 +1,3 @@
+-- the free privacy flag is renamed so it is not mistaken for the paid INCOGNITO effect
+ALTER TABLE "account"
+    RENAME COLUMN "incognito" TO "hide_visits";
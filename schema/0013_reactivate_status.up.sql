@@ -0,0 +1,4 @@
+-- status a deactivated account gets back on its next login, it keeps a warning or a suspension
+-- given before or during the deactivation from being dropped by the reactivation
+ALTER TABLE "account"
+    ADD COLUMN "reactivate_status" "account_status";
@@ -0,0 +1,8 @@
+package schema
+
+import "embed"
+
+// Migrations <version>_<name>.up.sql and .down.sql files applied by the migrate command
+//
+//go:embed *.sql
+var Migrations embed.FS
@@ -0,0 +1,296 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/utils"
+	"math/rand"
+	"time"
+)
+
+// CreatedBy marks every row written by the seeder
+const CreatedBy = "seed"
+
+// swipeBatchSize rows per insert statement, well below the postgres parameter limit
+const swipeBatchSize = 1000
+
+var (
+	firstNames = []string{"Adi", "Ayu", "Bima", "Citra", "Dewi", "Eka", "Fajar", "Gita", "Hana", "Indra",
+		"Joko", "Kartika", "Lestari", "Made", "Nanda", "Putri", "Rizky", "Sari", "Tono", "Wulan"}
+	lastNames = []string{"Pratama", "Saputra", "Wijaya", "Kusuma", "Lestari", "Hidayat", "Santoso", "Putra",
+		"Nugroho", "Permata", "Siregar", "Halim", "Gunawan", "Setiawan", "Rahayu", "Utami"}
+
+	interests = []string{"coffee", "hiking", "music", "movies", "travel", "cooking", "football", "reading", "photography",
+		"gaming", "yoga", "art", "diving", "cycling", "karaoke"}
+
+	// seeded accounts live around Jakarta, so distances on the profile card look real
+	centerLatitude, centerLongitude = -6.2, 106.8
+
+	defaultPackages = []model.PremiumPackageBaseModel{
+		{Title: model.PremiumPackageSwipe, Description: "Unlimited swipes a day", Price: 49000, IsActive: true, CreatedBy: CreatedBy},
+		{Title: model.PremiumPackageVerified, Description: "Verified badge after an identity check", Price: 29000, IsActive: true, CreatedBy: CreatedBy},
+		{Title: model.PremiumPackageVisitors, Description: "See who viewed your profile", Price: 19000, IsActive: true, CreatedBy: CreatedBy},
+		{Title: model.PremiumPackageIncognito, Description: "Only people you liked see you for a day", Price: 25000, IsActive: true, CreatedBy: CreatedBy},
+		{Title: model.PremiumPackageBoost, Description: "3 boosts, each puts you first in the match list of people nearby for 30 minutes", Price: 39000, IsActive: true,
+			CreditType: sql.NullString{String: model.CreditTypeBoost, Valid: true}, CreditAmount: 3, CreatedBy: CreatedBy},
+		{Title: model.PremiumPackageSuperLike, Description: "5 super likes", Price: 25000, IsActive: true,
+			CreditType: sql.NullString{String: model.CreditTypeSuperLike, Valid: true}, CreditAmount: 5, CreatedBy: CreatedBy},
+		{Title: model.PremiumPackageSwipePack, Description: "20 swipes on top of the daily quota", Price: 15000, IsActive: true,
+			CreditType: sql.NullString{String: model.CreditTypeSwipe, Valid: true}, CreditAmount: 20, CreatedBy: CreatedBy},
+	}
+)
+
+type Options struct {
+	Accounts         int
+	SwipesPerAccount int
+	LikeRatio        float64 // share of swipes that are LIKE
+	PremiumRatio     float64 // share of accounts that bought the SWIPE package
+	HistoryDays      int     // swipes are spread over this many days before today
+	Seed             int64
+}
+
+type PlanAccount struct {
+	Account   model.AccountBaseModel
+	Packages  []string // titles of the purchased packages
+	Photos    []string // urls, the first one is the main photo
+	Interests []string
+}
+
+type PlanSwipe struct {
+	Swiper    int // index in Plan.Accounts
+	Swipee    int
+	SwipeType string
+	At        time.Time
+}
+
+// Plan everything the seeder writes, the same options and day always give the same plan
+type Plan struct {
+	Packages []model.PremiumPackageBaseModel
+	Accounts []PlanAccount
+	Swipes   []PlanSwipe
+}
+
+type Result struct {
+	AccountsCreated int
+	AccountsSkipped int
+	Packages        int
+	Purchases       int
+	Swipes          int
+}
+
+// NewPlan generate the fake data from opts.Seed. Swipes are dated before today so seeded
+// accounts keep their daily swipe quota.
+func NewPlan(opts Options, now time.Time) Plan {
+	rng := rand.New(rand.NewSource(opts.Seed))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	historyDays := opts.HistoryDays
+	if historyDays <= 0 {
+		historyDays = 1
+	}
+
+	plan := Plan{Packages: append([]model.PremiumPackageBaseModel(nil), defaultPackages...)}
+	for i := 0; i < opts.Accounts; i++ {
+		first, last := firstNames[rng.Intn(len(firstNames))], lastNames[rng.Intn(len(lastNames))]
+		userName := fmt.Sprintf("seed_user_%04d", i+1)
+
+		account := PlanAccount{Account: model.AccountBaseModel{
+			Type:      model.AccountTypeFree,
+			Name:      first + " " + last,
+			UserName:  userName,
+			Email:     sql.NullString{String: userName + "@example.com", Valid: true},
+			Phone:     sql.NullString{String: fmt.Sprintf("+62812%07d", i+1), Valid: true},
+			Bio:       sql.NullString{String: "Hi, I am " + first, Valid: true},
+			BirthDate: sql.NullTime{Time: today.AddDate(-18-rng.Intn(28), 0, -rng.Intn(365)), Valid: true},
+			Latitude:  sql.NullFloat64{Float64: centerLatitude + (rng.Float64()-0.5)*0.6, Valid: true},
+			Longitude: sql.NullFloat64{Float64: centerLongitude + (rng.Float64()-0.5)*0.6, Valid: true},
+			CreatedBy: CreatedBy,
+		}}
+
+		for photo, photos := 0, 1+rng.Intn(3); photo < photos; photo++ {
+			account.Photos = append(account.Photos, fmt.Sprintf("https://cdn.example.com/seed/%s/%d.jpg", userName, photo+1))
+		}
+		for _, interest := range rng.Perm(len(interests))[:2+rng.Intn(3)] {
+			account.Interests = append(account.Interests, interests[interest])
+		}
+
+		if rng.Float64() < opts.PremiumRatio {
+			account.Account.Type = model.AccountTypePremium
+			account.Packages = []string{model.PremiumPackageSwipe}
+		}
+
+		plan.Accounts = append(plan.Accounts, account)
+	}
+
+	for swiper := range plan.Accounts {
+		targets := rng.Perm(len(plan.Accounts))
+		swiped := 0
+		for _, swipee := range targets {
+			if swiped >= opts.SwipesPerAccount {
+				break
+			}
+			if swipee == swiper {
+				continue
+			}
+
+			swipeType := "PASS"
+			if rng.Float64() < opts.LikeRatio {
+				swipeType = "LIKE"
+			}
+
+			at := today.AddDate(0, 0, -1-rng.Intn(historyDays)).Add(time.Duration(rng.Intn(24*60*60)) * time.Second)
+			plan.Swipes = append(plan.Swipes, PlanSwipe{Swiper: swiper, Swipee: swipee, SwipeType: swipeType, At: at})
+			swiped++
+		}
+	}
+
+	return plan
+}
+
+type Seeder interface {
+	Apply(ctx context.Context, plan Plan, password string) (Result, error)
+}
+
+type seeder struct {
+	accountRepo        interfaces.IAccountRepo
+	accountProfileRepo interfaces.IAccountProfileRepo
+	premiumPackageRepo interfaces.IPremiumPackageRepo
+	userSwipeLogRepo   interfaces.IUserSwipeLogRepo
+	transactionRepo    interfaces.ITransactionRepo
+	utilsPass          utils.PasswordHasher
+}
+
+func NewSeeder(accountRepo interfaces.IAccountRepo,
+	accountProfileRepo interfaces.IAccountProfileRepo,
+	premiumPackageRepo interfaces.IPremiumPackageRepo,
+	userSwipeLogRepo interfaces.IUserSwipeLogRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	utilsPass utils.PasswordHasher) Seeder {
+	return &seeder{
+		accountRepo:        accountRepo,
+		accountProfileRepo: accountProfileRepo,
+		premiumPackageRepo: premiumPackageRepo,
+		userSwipeLogRepo:   userSwipeLogRepo,
+		transactionRepo:    transactionRepo,
+		utilsPass:          utilsPass,
+	}
+}
+
+// Apply write the plan. Accounts that already exist are kept as they are and their swipes are
+// not inserted again, so running the same seed twice does not duplicate data.
+func (s *seeder) Apply(ctx context.Context, plan Plan, password string) (result Result, err error) {
+	// every seeded account shares the password, hashing it once keeps large seeds fast
+	hash, err := s.utilsPass.GeneratePassword(password)
+	if err != nil {
+		return result, err
+	}
+
+	packageIDs := make(map[string]int64)
+	for _, pkg := range plan.Packages {
+		if err = s.premiumPackageRepo.EnsurePremiumPackage(ctx, &pkg); err != nil {
+			return result, fmt.Errorf("failed to create package %s: %w", pkg.Title, err)
+		}
+		packageIDs[pkg.Title] = pkg.ID
+		result.Packages++
+	}
+
+	accountIDs := make([]int64, len(plan.Accounts))
+	created := make([]bool, len(plan.Accounts))
+	for i, planAccount := range plan.Accounts {
+		existing, err := s.accountRepo.FindOneAccountByAccountUserName(ctx, planAccount.Account.UserName)
+		if err == nil {
+			accountIDs[i] = existing.ID
+			result.AccountsSkipped++
+			continue
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return result, fmt.Errorf("failed to find account %s: %w", planAccount.Account.UserName, err)
+		}
+
+		account := planAccount.Account
+		account.Password = hash
+		if account, err = s.accountRepo.InsertAccount(ctx, account); err != nil {
+			return result, fmt.Errorf("failed to create account %s: %w", account.UserName, err)
+		}
+		accountIDs[i] = account.ID
+		created[i] = true
+		result.AccountsCreated++
+
+		if err = s.profile(ctx, account.ID, planAccount); err != nil {
+			return result, fmt.Errorf("failed to create profile of %s: %w", account.UserName, err)
+		}
+
+		if err = s.purchase(ctx, account.ID, planAccount.Packages, packageIDs); err != nil {
+			return result, fmt.Errorf("failed to purchase packages for %s: %w", account.UserName, err)
+		}
+		result.Purchases += len(planAccount.Packages)
+	}
+
+	var batch []model.UserSwipeLogBaseModel
+	for _, swipe := range plan.Swipes {
+		if !created[swipe.Swiper] {
+			continue
+		}
+
+		batch = append(batch, model.UserSwipeLogBaseModel{
+			SwiperID:  accountIDs[swipe.Swiper],
+			SwipeeID:  accountIDs[swipe.Swipee],
+			SwipeType: swipe.SwipeType,
+			CreatedAt: swipe.At.Format("2006-01-02 15:04:05"),
+		})
+
+		if len(batch) == swipeBatchSize {
+			if err = s.userSwipeLogRepo.InsertUserSwipeLogHistory(ctx, batch); err != nil {
+				return result, fmt.Errorf("failed to insert swipes: %w", err)
+			}
+			result.Swipes += len(batch)
+			batch = batch[:0]
+		}
+	}
+
+	if err = s.userSwipeLogRepo.InsertUserSwipeLogHistory(ctx, batch); err != nil {
+		return result, fmt.Errorf("failed to insert swipes: %w", err)
+	}
+	result.Swipes += len(batch)
+
+	return result, nil
+}
+
+func (s *seeder) profile(ctx context.Context, accountID int64, planAccount PlanAccount) error {
+	photos := make([]model.AccountPhotoBaseModel, len(planAccount.Photos))
+	for i, url := range planAccount.Photos {
+		photos[i] = model.AccountPhotoBaseModel{AccountID: accountID, URL: url, Position: i}
+	}
+
+	if err := s.accountProfileRepo.InsertAccountPhoto(ctx, photos); err != nil {
+		return err
+	}
+
+	return s.accountProfileRepo.InsertAccountInterest(ctx, accountID, planAccount.Interests)
+}
+
+func (s *seeder) purchase(ctx context.Context, accountID int64, titles []string, packageIDs map[string]int64) error {
+	if len(titles) == 0 {
+		return nil
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, title := range titles {
+		if err = s.premiumPackageRepo.InsertPremiumPackageUser(ctx, tx, &model.PremiumPackageUserBaseModel{
+			PremiumPackageID: packageIDs[title],
+			AccountID:        accountID,
+		}); err != nil {
+			s.transactionRepo.RollbackTrx(ctx, tx)
+			return err
+		}
+	}
+
+	return s.transactionRepo.CommitTrx(ctx, tx)
+}
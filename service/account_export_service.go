@@ -0,0 +1,420 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
+	"github.com/dwiangraeni/dealls/utils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"io"
+	"log/slog"
+	"path"
+	"time"
+)
+
+type serviceAccountExportCtx struct {
+	accountRepo        interfaces.IAccountRepo
+	userSwipeLogRepo   interfaces.IUserSwipeLogRepo
+	premiumPackageRepo interfaces.IPremiumPackageRepo
+	accountProfileRepo interfaces.IAccountProfileRepo
+	accountExportRepo  interfaces.IAccountExportRepo
+	storage            interfaces.IFileStorage
+	policy             model.AccountExportPolicy
+	wake               chan struct{}
+}
+
+func NewAccountExportService(accountRepo interfaces.IAccountRepo,
+	userSwipeLogRepo interfaces.IUserSwipeLogRepo,
+	premiumPackageRepo interfaces.IPremiumPackageRepo,
+	accountProfileRepo interfaces.IAccountProfileRepo,
+	accountExportRepo interfaces.IAccountExportRepo,
+	storage interfaces.IFileStorage,
+	policy model.AccountExportPolicy) interfaces.IAccountExportService {
+	return &serviceAccountExportCtx{
+		accountRepo:        accountRepo,
+		userSwipeLogRepo:   userSwipeLogRepo,
+		premiumPackageRepo: premiumPackageRepo,
+		accountProfileRepo: accountProfileRepo,
+		accountExportRepo:  accountExportRepo,
+		storage:            storage,
+		policy:             policy,
+		wake:               make(chan struct{}, 1),
+	}
+}
+
+// RequestAccountExport queue a new export job, the archive is built by Run in the background
+func (s *serviceAccountExportCtx) RequestAccountExport(ctx context.Context, accountMaskID string) (resp model.AccountExportResponse, err error) {
+	var (
+		eventName = "serviceAccountExportCtx.RequestAccountExport"
+		logger    = slog.With("event", eventName, "account_mask_id", accountMaskID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	latest, err := s.accountExportRepo.GetLatestAccountExportByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.ErrorContext(ctx, "failed to get latest account export", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if latest.Status == model.AccountExportPending || latest.Status == model.AccountExportProcessing {
+		return resp, utils.ErrExportInProgress
+	}
+
+	export := model.AccountExportBaseModel{
+		AccountID:     account.ID,
+		Status:        model.AccountExportPending,
+		AccountMaskID: account.AccountMaskID,
+	}
+	if err = s.accountExportRepo.InsertAccountExport(ctx, &export); err != nil {
+		logger.ErrorContext(ctx, "failed to insert account export", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	// start right away instead of waiting for the next poll
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	return s.accountExportResponse(export), nil
+}
+
+// GetAccountExportStatus latest export job of the account
+func (s *serviceAccountExportCtx) GetAccountExportStatus(ctx context.Context, accountMaskID string) (resp model.AccountExportResponse, err error) {
+	var (
+		eventName = "serviceAccountExportCtx.GetAccountExportStatus"
+		logger    = slog.With("event", eventName, "account_mask_id", accountMaskID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	export, err := s.accountExportRepo.GetLatestAccountExportByAccountID(ctx, account.ID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get latest account export", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	return s.accountExportResponse(export), nil
+}
+
+// OpenAccountExport open a finished archive, exports of other accounts are reported as not found
+func (s *serviceAccountExportCtx) OpenAccountExport(ctx context.Context, accountMaskID, exportUID string) (archive io.ReadCloser, err error) {
+	var (
+		eventName = "serviceAccountExportCtx.OpenAccountExport"
+		logger    = slog.With("event", eventName, "account_mask_id", accountMaskID, "export_uid", exportUID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	export, err := s.accountExportRepo.GetAccountExportByUID(ctx, exportUID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get account export", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, utils.ErrDataNotFound
+		}
+		return nil, utils.ErrInternal
+	}
+
+	if export.AccountMaskID != accountMaskID {
+		return nil, utils.ErrDataNotFound
+	}
+
+	switch s.accountExportResponse(export).Status {
+	case model.AccountExportDone:
+	case model.AccountExportExpired:
+		return nil, utils.ErrExportExpired
+	default:
+		return nil, utils.ErrExportNotReady
+	}
+
+	archive, err = s.storage.Open(ctx, export.FilePath)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to open archive", "error", err)
+		return nil, utils.ErrInternal
+	}
+
+	return archive, nil
+}
+
+// Run process queued exports until ctx is done, waking up every poll interval or when a job is requested
+func (s *serviceAccountExportCtx) Run(ctx context.Context) {
+	pollInterval := s.policy.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = time.Minute
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			processed, err := s.ProcessAccountExport(ctx)
+			if err != nil || !processed {
+				break
+			}
+		}
+		s.clearExpiredAccountExport(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// ProcessAccountExport claim the oldest queued job and build its archive, processed is false when the queue is empty
+func (s *serviceAccountExportCtx) ProcessAccountExport(ctx context.Context) (processed bool, err error) {
+	var (
+		eventName = "serviceAccountExportCtx.ProcessAccountExport"
+		logger    = slog.With("event", eventName)
+	)
+
+	export, err := s.accountExportRepo.ClaimAccountExport(ctx, time.Now().UTC().Add(-s.policy.ProcessingTimeout))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		logger.ErrorContext(ctx, "failed to claim account export", "error", err)
+		return false, utils.ErrInternal
+	}
+	logger = logger.With("export_uid", export.ExportUID)
+
+	// the span starts once a job is claimed, an empty poll every few seconds is not worth a trace
+	ctx, span := tracing.Start(ctx, eventName, trace.WithAttributes(attribute.String("export_uid", export.ExportUID)))
+	defer span.End()
+
+	name := path.Join("export", export.AccountMaskID, export.ExportUID+".zip")
+	export.Status = model.AccountExportDone
+	export.FilePath = name
+	if err = s.writeArchive(ctx, name, export); err != nil {
+		logger.ErrorContext(ctx, "failed to write archive", "error", err)
+		export.Status = model.AccountExportFailed
+		export.FilePath = ""
+		export.Error = sql.NullString{String: "failed to build the archive, request a new export", Valid: true}
+	}
+	export.CompletedAt = sql.NullTime{Time: time.Now().UTC(), Valid: true}
+
+	if err = s.accountExportRepo.CompleteAccountExport(ctx, &export); err != nil {
+		logger.ErrorContext(ctx, "failed to complete account export", "error", err)
+		// the job was removed or claimed again meanwhile, nothing points to the archive anymore
+		if export.FilePath != "" {
+			if errDelete := s.storage.Delete(ctx, export.FilePath); errDelete != nil {
+				logger.ErrorContext(ctx, "failed to delete archive", "path", export.FilePath, "error", errDelete)
+			}
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			return true, nil
+		}
+		return true, utils.ErrInternal
+	}
+
+	return true, nil
+}
+
+// writeArchive stream profile, swipes, matches and purchases as json files of one zip
+func (s *serviceAccountExportCtx) writeArchive(ctx context.Context, name string, export model.AccountExportBaseModel) error {
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, export.AccountMaskID)
+	if err != nil {
+		return err
+	}
+
+	photos, err := s.accountProfileRepo.GetListAccountPhotoByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	interests, err := s.accountProfileRepo.GetListAccountInterestByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	swipes, err := s.userSwipeLogRepo.GetListUserSwipeLogBySwiperID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	matches, err := s.userSwipeLogRepo.GetListMatchByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	purchases, err := s.premiumPackageRepo.GetListPurchaseByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name string
+		data interface{}
+	}{
+		{"profile.json", accountExportProfile(account, photos, interests)},
+		{"swipes.json", nonNil(swipes)},
+		{"matches.json", nonNil(matches)},
+		{"purchases.json", nonNil(purchases)},
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		archive := zip.NewWriter(writer)
+		for _, file := range files {
+			entry, err := archive.Create(file.name)
+			if err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+
+			encoder := json.NewEncoder(entry)
+			encoder.SetIndent("", "  ")
+			if err = encoder.Encode(file.data); err != nil {
+				writer.CloseWithError(err)
+				return
+			}
+		}
+		writer.CloseWithError(archive.Close())
+	}()
+
+	err = s.storage.Save(ctx, name, reader)
+	// unblock the writer when the storage gave up early
+	reader.CloseWithError(io.ErrClosedPipe)
+	return err
+}
+
+func (s *serviceAccountExportCtx) clearExpiredAccountExport(ctx context.Context) {
+	var (
+		eventName = "serviceAccountExportCtx.clearExpiredAccountExport"
+		logger    = slog.With("event", eventName)
+	)
+
+	if s.policy.TTL <= 0 {
+		return
+	}
+
+	filePaths, err := s.accountExportRepo.ClearExpiredAccountExport(ctx, time.Now().UTC().Add(-s.policy.TTL))
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to clear expired account export", "error", err)
+		return
+	}
+
+	for _, filePath := range filePaths {
+		if err = s.storage.Delete(ctx, filePath); err != nil {
+			logger.ErrorContext(ctx, "failed to delete archive", "path", filePath, "error", err)
+		}
+	}
+}
+
+func (s *serviceAccountExportCtx) accountExportResponse(export model.AccountExportBaseModel) model.AccountExportResponse {
+	resp := model.AccountExportResponse{
+		ExportUID: export.ExportUID,
+		Status:    export.Status,
+		Error:     export.Error.String,
+		CreatedAt: export.CreatedAt,
+	}
+
+	if export.CompletedAt.Valid {
+		completedAt := export.CompletedAt.Time
+		resp.CompletedAt = &completedAt
+	}
+
+	if export.Status == model.AccountExportDone {
+		if s.policy.TTL > 0 {
+			expiresAt := export.CompletedAt.Time.Add(s.policy.TTL)
+			resp.ExpiresAt = &expiresAt
+			if !time.Now().UTC().Before(expiresAt) {
+				resp.Status = model.AccountExportExpired
+			}
+		}
+
+		if export.FilePath == "" {
+			resp.Status = model.AccountExportExpired
+		}
+	}
+
+	return resp
+}
+
+func accountExportProfile(account model.AccountBaseModel, photos []model.AccountPhotoBaseModel, interests []string) model.AccountExportProfile {
+	profile := model.AccountExportProfile{
+		AccountMaskID: account.AccountMaskID,
+		Type:          account.Type,
+		Role:          account.Role,
+		Status:        account.Status,
+		Name:          account.Name,
+		UserName:      account.UserName,
+		IsVerified:    account.IsVerified,
+		IsHidden:      account.IsHidden,
+		Email:         account.Email.String,
+		Phone:         account.Phone.String,
+		Bio:           account.Bio.String,
+		HideAge:       account.HideAge,
+		HideDistance:  account.HideDistance,
+		HideVisits:    account.HideVisits,
+		Photos:        make([]model.AccountPhotoResponse, len(photos)),
+		Interests:     nonNil(interests),
+		CreatedAt:     account.CreatedAt,
+		UpdatedAt:     account.UpdatedAt,
+	}
+
+	for i, photo := range photos {
+		profile.Photos[i] = model.AccountPhotoResponse{URL: photo.URL, Position: photo.Position}
+	}
+
+	if account.BirthDate.Valid {
+		profile.BirthDate = account.BirthDate.Time.Format(time.DateOnly)
+	}
+
+	if account.HasLocation() {
+		profile.Latitude = &account.Latitude.Float64
+		profile.Longitude = &account.Longitude.Float64
+	}
+
+	if account.EmailVerifiedAt.Valid {
+		profile.EmailVerifiedAt = &account.EmailVerifiedAt.Time
+	}
+
+	if account.PhoneVerifiedAt.Valid {
+		profile.PhoneVerifiedAt = &account.PhoneVerifiedAt.Time
+	}
+
+	return profile
+}
+
+// nonNil keep empty lists as [] instead of null in the archive
+func nonNil[T any](rows []T) []T {
+	if rows == nil {
+		return []T{}
+	}
+	return rows
+}
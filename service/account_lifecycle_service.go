@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/asaskevich/govalidator"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
+	"github.com/dwiangraeni/dealls/utils"
+	"log/slog"
+	"time"
+)
+
+type serviceAccountLifecycleCtx struct {
+	accountRepo              interfaces.IAccountRepo
+	otpRepo                  interfaces.IOTPRepo
+	identityVerificationRepo interfaces.IIdentityVerificationRepo
+	accountExportRepo        interfaces.IAccountExportRepo
+	accountProfileRepo       interfaces.IAccountProfileRepo
+	transactionRepo          interfaces.ITransactionRepo
+	selfieStorage            interfaces.IFileStorage
+	exportStorage            interfaces.IFileStorage
+	utilsPass                utils.PasswordHasher
+}
+
+func NewAccountLifecycleService(accountRepo interfaces.IAccountRepo,
+	otpRepo interfaces.IOTPRepo,
+	identityVerificationRepo interfaces.IIdentityVerificationRepo,
+	accountExportRepo interfaces.IAccountExportRepo,
+	accountProfileRepo interfaces.IAccountProfileRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	selfieStorage interfaces.IFileStorage,
+	exportStorage interfaces.IFileStorage,
+	utilsPass utils.PasswordHasher) interfaces.IAccountLifecycleService {
+	return &serviceAccountLifecycleCtx{
+		accountRepo:              accountRepo,
+		otpRepo:                  otpRepo,
+		identityVerificationRepo: identityVerificationRepo,
+		accountExportRepo:        accountExportRepo,
+		accountProfileRepo:       accountProfileRepo,
+		transactionRepo:          transactionRepo,
+		selfieStorage:            selfieStorage,
+		exportStorage:            exportStorage,
+		utilsPass:                utilsPass,
+	}
+}
+
+// UpdateDiscovery hide or show the account in other users' match list
+func (s *serviceAccountLifecycleCtx) UpdateDiscovery(ctx context.Context, req model.UpdateDiscoveryRequest) (resp model.AccountSettingResponse, err error) {
+	var (
+		eventName = "serviceAccountLifecycleCtx.UpdateDiscovery"
+		logger    = slog.With("event", eventName, "req", req)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	account.IsHidden = req.Hidden
+	account.UpdatedBy = sql.NullString{String: account.UserName, Valid: true}
+	if _, err = s.accountRepo.UpdateAccountDiscovery(ctx, account); err != nil {
+		logger.ErrorContext(ctx, "failed to update account discovery", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	return accountSettingResponse(account), nil
+}
+
+// DeactivateAccount pause the account, it is hidden and its tokens stop working until the user logs in again
+func (s *serviceAccountLifecycleCtx) DeactivateAccount(ctx context.Context, accountMaskID string) (resp model.AccountSettingResponse, err error) {
+	var (
+		eventName = "serviceAccountLifecycleCtx.DeactivateAccount"
+		logger    = slog.With("event", eventName, "account_mask_id", accountMaskID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	// a warning is not cleared by the deactivation, the next login gives it back
+	account.ReactivateStatus = sql.NullString{String: account.EffectiveStatus(time.Now().UTC()), Valid: true}
+	account.Status = model.AccountStatusDeactivated
+	account.SuspendedUntil = sql.NullTime{}
+	account.UpdatedBy = sql.NullString{String: account.UserName, Valid: true}
+	if _, err = s.accountRepo.UpdateAccountStatus(ctx, tx, account); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to update account status", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	return accountSettingResponse(account), nil
+}
+
+// DeleteAccount anonymize the account after the password is confirmed. The row stays so swipe logs
+// and purchases keep their foreign keys, contact and profile data, codes, selfies and exports are removed.
+func (s *serviceAccountLifecycleCtx) DeleteAccount(ctx context.Context, req model.DeleteAccountRequest) (err error) {
+	var (
+		eventName = "serviceAccountLifecycleCtx.DeleteAccount"
+		logger    = slog.With("event", eventName, "account_mask_id", req.AccountMaskID)
+		now       = time.Now().UTC()
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return err
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.ErrDataNotFound
+		}
+		return utils.ErrInternal
+	}
+
+	// the mask id lookup does not load the password hash
+	credential, err := s.accountRepo.FindOneAccountByAccountUserName(ctx, account.UserName)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by username", "error", err)
+		return utils.ErrInternal
+	}
+
+	if !s.utilsPass.CheckPasswordHash(req.Password, credential.Password) {
+		return utils.ErrInvalidPassword
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return utils.ErrInternal
+	}
+
+	account.Name = model.DeletedAccountName
+	account.UserName = "deleted-" + account.AccountMaskID
+	account.DeletedAt = sql.NullTime{Time: now, Valid: true}
+	account.UpdatedBy = sql.NullString{String: account.UserName, Valid: true}
+	if _, err = s.accountRepo.AnonymizeAccount(ctx, tx, account); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to anonymize account", "error", err)
+		return utils.ErrInternal
+	}
+
+	if err = s.otpRepo.DeleteOTPByAccountID(ctx, tx, account.ID); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to delete otp", "error", err)
+		return utils.ErrInternal
+	}
+
+	if err = s.accountProfileRepo.DeleteAccountProfileByAccountID(ctx, tx, account.ID); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to delete account profile", "error", err)
+		return utils.ErrInternal
+	}
+
+	selfiePaths, err := s.identityVerificationRepo.ClearSelfieByAccountID(ctx, tx, account.ID)
+	if err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to clear selfie", "error", err)
+		return utils.ErrInternal
+	}
+
+	exportPaths, err := s.accountExportRepo.DeleteAccountExportByAccountID(ctx, tx, account.ID)
+	if err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to delete account export", "error", err)
+		return utils.ErrInternal
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return utils.ErrInternal
+	}
+
+	// files are removed after commit, a leftover file is only logged since the row no longer points to it
+	for _, selfiePath := range selfiePaths {
+		if err = s.selfieStorage.Delete(ctx, selfiePath); err != nil {
+			logger.ErrorContext(ctx, "failed to delete selfie", "path", selfiePath, "error", err)
+		}
+	}
+
+	for _, exportPath := range exportPaths {
+		if err = s.exportStorage.Delete(ctx, exportPath); err != nil {
+			logger.ErrorContext(ctx, "failed to delete archive", "path", exportPath, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func accountSettingResponse(account model.AccountBaseModel) model.AccountSettingResponse {
+	return model.AccountSettingResponse{
+		AccountMaskID: account.AccountMaskID,
+		Status:        account.Status,
+		Hidden:        account.IsHidden,
+	}
+}
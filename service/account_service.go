@@ -2,57 +2,105 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/asaskevich/govalidator"
 	"github.com/dwiangraeni/dealls/interfaces"
 	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
 	"github.com/dwiangraeni/dealls/utils"
-	"log"
+	"log/slog"
+	"math"
+	"time"
 )
 
 type serviceAccountCtx struct {
-	accountRepo interfaces.IAccountRepo
-	hashCursor  utils.HashInterface
+	accountRepo        interfaces.IAccountRepo
+	accountBlockRepo   interfaces.IAccountBlockRepo
+	accountProfileRepo interfaces.IAccountProfileRepo
+	premiumPackageRepo interfaces.IPremiumPackageRepo
+	effectPolicy       model.AccountEffectPolicy
+	metrics            interfaces.IMetrics
+	cursorCodec        utils.CursorCodec
 }
 
-func NewAccountService(accountRepo interfaces.IAccountRepo) interfaces.IAccountService {
-	return &serviceAccountCtx{accountRepo: accountRepo,
-		hashCursor: utils.InitHash(utils.ConstCursorHashSalt, utils.ConstHashLength)}
+func NewAccountService(accountRepo interfaces.IAccountRepo,
+	accountBlockRepo interfaces.IAccountBlockRepo,
+	accountProfileRepo interfaces.IAccountProfileRepo,
+	premiumPackageRepo interfaces.IPremiumPackageRepo,
+	effectPolicy model.AccountEffectPolicy,
+	metrics interfaces.IMetrics,
+	cursorCodec utils.CursorCodec) interfaces.IAccountService {
+	return &serviceAccountCtx{
+		accountRepo:        accountRepo,
+		accountBlockRepo:   accountBlockRepo,
+		accountProfileRepo: accountProfileRepo,
+		premiumPackageRepo: premiumPackageRepo,
+		effectPolicy:       effectPolicy,
+		metrics:            metrics,
+		cursorCodec:        cursorCodec,
+	}
 }
 
+// GetListAccountNewMatchPagination accounts to swipe on. Up to BoostSlots boosted accounts near the viewer are put on
+// top of the first page and left out of every page, incognito accounts only show up for the accounts they liked.
 func (s *serviceAccountCtx) GetListAccountNewMatchPagination(ctx context.Context, req model.PaginationRequest) (resp model.ListAccountPagination, err error) {
 	var (
-		eventName = "serviceAccountCtx.GetListAccountNewMatchPagination"
-		logFields = map[string]interface{}{
-			"_event": eventName,
-			"req": map[string]interface{}{
-				"req": req,
-			},
-		}
+		eventName              = "serviceAccountCtx.GetListAccountNewMatchPagination"
+		logger                 = slog.With("event", eventName, "req", req)
 		actualLimit            = req.Limit
 		loadMore               bool
 		dataCursor             []int
 		prevCursor, nextCursor string
+		filter                 = "account|" + req.AccountMaskID
+		boosted                []model.AccountBaseModel
 	)
 
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
 	// validate req
 	if _, err = govalidator.ValidateStruct(req); err != nil {
-		log.Printf("%s: error validate request: %v", logFields, err)
+		logger.WarnContext(ctx, "error validate request", "error", err)
 		return resp, err
 	}
 
 	if req.Cursor != "" {
-		req.CursorID = s.hashCursor.DecodePublicID(req.Cursor)
+		var cursor utils.Cursor
+		if cursor, err = s.cursorCodec.Decode(req.Cursor, filter); err != nil {
+			logger.WarnContext(ctx, "error decode cursor", "error", err)
+			return resp, utils.ErrInvalidCursor
+		}
+		req.CursorID, req.Direction = cursor.ID, cursor.Direction
+	}
+
+	if s.effectPolicy.BoostRadiusKM > 0 && s.effectPolicy.BoostSlots > 0 {
+		// the later pages load the boosted accounts too, to leave out the same ones the first page put on top
+		boostReq := req
+		boostReq.BoostRadiusKM = s.effectPolicy.BoostRadiusKM
+		boostReq.Limit = s.effectPolicy.BoostSlots
+		if boosted, err = s.accountRepo.GetListAccountBoosted(ctx, boostReq); err != nil {
+			logger.ErrorContext(ctx, "failed to get list boosted account", "error", err)
+			return resp, utils.ErrInternal
+		}
+
+		for _, account := range boosted {
+			req.ExcludeIDs = append(req.ExcludeIDs, account.ID)
+		}
+		if req.CursorID != 0 {
+			boosted = nil
+		}
 	}
 
 	// get list order
 	req.Limit = req.Limit + 1
 	accounts, err := s.accountRepo.GetListAccountNewMatchPagination(ctx, req)
 	if err != nil {
-		log.Printf("%s: failed to get list account with err: %s", logFields, err.Error())
+		logger.ErrorContext(ctx, "failed to get list account", "error", err)
 		return resp, utils.ErrInternal
 	}
 
-	if len(accounts) == 0 {
+	if len(accounts) == 0 && len(boosted) == 0 {
 		return resp, nil
 	}
 
@@ -61,24 +109,308 @@ func (s *serviceAccountCtx) GetListAccountNewMatchPagination(ctx context.Context
 		accounts = accounts[:actualLimit]
 	}
 
-	accountList := make([]model.AccountResponse, len(accounts))
-	dataCursor = make([]int, len(accounts))
+	accountList := make([]model.AccountResponse, 0, len(boosted)+len(accounts))
+	for _, account := range boosted {
+		accountList = append(accountList, accountResponse(account, true))
+	}
 
+	dataCursor = make([]int, len(accounts))
 	for i, account := range accounts {
 		dataCursor[i] = int(account.ID)
+		accountList = append(accountList, accountResponse(account, false))
+	}
+
+	// the cursors only follow the regular accounts, the boosted ones are not part of the pages
+	if len(dataCursor) > 0 {
+		prevCursorID, nextCursorID := utils.GetPaginationCursor(dataCursor, req.Direction == utils.DirectionPrev)
+		nextCursor = s.cursorCodec.Encode(nextCursorID, utils.DirectionNext, filter)
+		prevCursor = s.cursorCodec.Encode(prevCursorID, utils.DirectionPrev, filter)
+	}
+	if !loadMore && req.Direction != utils.DirectionPrev {
+		nextCursor = ""
+	}
+
+	if req.CursorID == 0 || (!loadMore && req.Direction == utils.DirectionPrev) {
+		prevCursor = ""
+	}
+
+	resp.Data = accountList
+	resp.LoadMore = loadMore
+	resp.NextCursor = nextCursor
+	resp.PrevCursor = prevCursor
+	resp.Limit = actualLimit
+
+	return resp, nil
+}
+
+// GetAccountStatus effective moderation status checked by RequireAccountToken, empty when the account no longer exists
+func (s *serviceAccountCtx) GetAccountStatus(ctx context.Context, accountMaskID string) (status string, err error) {
+	ctx, span := tracing.Start(ctx, "serviceAccountCtx.GetAccountStatus")
+	defer span.End()
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+
+		slog.ErrorContext(ctx, "error find account", "event", "serviceAccountCtx.GetAccountStatus", "account_mask_id", accountMaskID, "error", err)
+		return "", utils.ErrInternal
+	}
+
+	return account.EffectiveStatus(time.Now().UTC()), nil
+}
+
+// GetAccountProfile profile card of an account. Blocked pairs, hidden, moderated and incognito accounts are reported as
+// unavailable, fields the owner hides are left out and views by other accounts are recorded unless the viewer hides its
+// visits or is incognito to the target.
+func (s *serviceAccountCtx) GetAccountProfile(ctx context.Context, req model.GetAccountProfileRequest) (resp model.AccountProfileResponse, err error) {
+	var (
+		eventName = "serviceAccountCtx.GetAccountProfile"
+		logger    = slog.With("event", eventName, "req", req)
+		now       = time.Now().UTC()
+		self      = req.AccountMaskID == req.TargetAccountMaskID
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	viewer, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	target := viewer
+	if !self {
+		if target, err = s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.TargetAccountMaskID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				logger.InfoContext(ctx, "account not found")
+				return resp, utils.ErrDataNotFound
+			}
+			logger.ErrorContext(ctx, "failed to find target account by account mask", "error", err)
+			return resp, utils.ErrInternal
+		}
+
+		// the same answer for a hidden or a blocked account, so a block can not be told apart
+		if !target.IsProfileVisible(now) {
+			logger.InfoContext(ctx, "profile is not visible")
+			return resp, utils.ErrAccountBlocked
+		}
+
+		blocked, err := s.accountBlockRepo.IsAccountBlocked(ctx, viewer.ID, target.ID)
+		if err != nil {
+			logger.ErrorContext(ctx, "error check account block", "error", err)
+			return resp, utils.ErrInternal
+		}
+
+		if blocked {
+			logger.InfoContext(ctx, "account is blocked")
+			return resp, utils.ErrAccountBlocked
+		}
+
+		// the INCOGNITO effect keeps the account visible only to the accounts it liked
+		incognito, err := s.accountRepo.IsAccountIncognitoFor(ctx, target.ID, viewer.ID)
+		if err != nil {
+			logger.ErrorContext(ctx, "error check target incognito", "error", err)
+			return resp, utils.ErrInternal
+		}
+
+		if incognito {
+			logger.InfoContext(ctx, "account is incognito")
+			return resp, utils.ErrAccountBlocked
+		}
+	}
+
+	photos, err := s.accountProfileRepo.GetListAccountPhotoByAccountID(ctx, target.ID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get account photos", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	interests, err := s.accountProfileRepo.GetListAccountInterestByAccountID(ctx, target.ID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get account interests", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	resp = accountProfileResponse(viewer, target, photos, interests, self, now)
+
+	if !self && !viewer.HideVisits {
+		s.recordProfileView(ctx, logger, viewer, target)
+	}
+
+	return resp, nil
+}
+
+// recordProfileView the profile is served even when the view can not be recorded
+func (s *serviceAccountCtx) recordProfileView(ctx context.Context, logger *slog.Logger, viewer, target model.AccountBaseModel) {
+	// an incognito viewer only shows up as a visitor of the accounts it liked
+	incognito, err := s.accountRepo.IsAccountIncognitoFor(ctx, viewer.ID, target.ID)
+	if err != nil {
+		logger.ErrorContext(ctx, "error check viewer incognito", "error", err)
+		return
+	}
+	if incognito {
+		return
+	}
+
+	if err = s.accountProfileRepo.InsertProfileView(ctx, &model.ProfileViewBaseModel{
+		ViewerID: viewer.ID,
+		ViewedID: target.ID,
+	}); err != nil {
+		logger.ErrorContext(ctx, "failed to insert profile view", "error", err)
+		return
+	}
+
+	s.metrics.ProfileViewed()
+}
+
+// UpdateProfilePrivacy hide or show the age and distance on the profile card, or stop recording the account's views
+func (s *serviceAccountCtx) UpdateProfilePrivacy(ctx context.Context, req model.UpdateProfilePrivacyRequest) (resp model.ProfilePrivacyResponse, err error) {
+	var (
+		eventName = "serviceAccountCtx.UpdateProfilePrivacy"
+		logger    = slog.With("event", eventName, "req", req)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	if req.HideAge != nil {
+		account.HideAge = *req.HideAge
+	}
+	if req.HideDistance != nil {
+		account.HideDistance = *req.HideDistance
+	}
+	if req.HideVisits != nil {
+		account.HideVisits = *req.HideVisits
+	}
+	account.UpdatedBy = sql.NullString{String: account.UserName, Valid: true}
+	if _, err = s.accountRepo.UpdateAccountPrivacy(ctx, account); err != nil {
+		logger.ErrorContext(ctx, "failed to update account privacy", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	return model.ProfilePrivacyResponse{
+		AccountMaskID: account.AccountMaskID,
+		HideAge:       account.HideAge,
+		HideDistance:  account.HideDistance,
+		HideVisits:    account.HideVisits,
+	}, nil
+}
+
+// GetListProfileVisitorPagination accounts that viewed the profile, newest visit first. The list needs the VISITORS
+// package, other accounts only get the number of visits.
+func (s *serviceAccountCtx) GetListProfileVisitorPagination(ctx context.Context, req model.ListProfileVisitorRequest) (resp model.ListProfileVisitorPagination, err error) {
+	var (
+		eventName              = "serviceAccountCtx.GetListProfileVisitorPagination"
+		logger                 = slog.With("event", eventName, "req", req)
+		actualLimit            = req.Limit
+		loadMore               bool
+		dataCursor             []int
+		prevCursor, nextCursor string
+		filter                 = "profile_visitor|" + req.AccountMaskID
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
 
-		accountList[i] = model.AccountResponse{
-			AccountMaskID: account.AccountMaskID,
-			Type:          account.Type,
-			Name:          account.Name,
-			UserName:      account.UserName,
-			IsVerified:    account.IsVerified,
+	resp.Data = []model.ProfileVisitorResponse{}
+	resp.Limit = actualLimit
+	if resp.Total, err = s.accountProfileRepo.CountProfileVisitor(ctx, account.ID); err != nil {
+		logger.ErrorContext(ctx, "failed to count profile visitors", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if _, err = s.premiumPackageRepo.GetPremiumPackageUserByTitleAndAccountID(ctx, model.PremiumPackageVisitors, account.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.InfoContext(ctx, "visitors package not purchased, only the count is shown")
+			return resp, nil
+		}
+		logger.ErrorContext(ctx, "failed to get visitors package", "error", err)
+		return resp, utils.ErrInternal
+	}
+	resp.Unlocked = true
+
+	if req.Cursor != "" {
+		var cursor utils.Cursor
+		if cursor, err = s.cursorCodec.Decode(req.Cursor, filter); err != nil {
+			logger.WarnContext(ctx, "error decode cursor", "error", err)
+			return resp, utils.ErrInvalidCursor
+		}
+		req.CursorID, req.Direction = cursor.ID, cursor.Direction
+	}
+
+	req.ViewedID = account.ID
+	req.Limit = req.Limit + 1
+	visitors, err := s.accountProfileRepo.GetListProfileVisitorPagination(ctx, req)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get list profile visitor", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if len(visitors) == 0 {
+		return resp, nil
+	}
+
+	if len(visitors) > actualLimit {
+		loadMore = true
+		visitors = visitors[:actualLimit]
+	}
+
+	resp.Data = make([]model.ProfileVisitorResponse, len(visitors))
+	dataCursor = make([]int, len(visitors))
+	for i, visitor := range visitors {
+		dataCursor[i] = int(visitor.ID)
+		resp.Data[i] = model.ProfileVisitorResponse{
+			AccountMaskID: visitor.AccountMaskID,
+			Type:          visitor.Type,
+			Name:          visitor.Name,
+			UserName:      visitor.UserName,
+			IsVerified:    visitor.IsVerified,
+			ViewedAt:      visitor.ViewedAt,
 		}
 	}
 
 	prevCursorID, nextCursorID := utils.GetPaginationCursor(dataCursor, req.Direction == utils.DirectionPrev)
-	nextCursor = s.hashCursor.EncodePublicID(nextCursorID)
-	prevCursor = s.hashCursor.EncodePublicID(prevCursorID)
+	nextCursor = s.cursorCodec.Encode(nextCursorID, utils.DirectionNext, filter)
+	prevCursor = s.cursorCodec.Encode(prevCursorID, utils.DirectionPrev, filter)
 	if !loadMore && req.Direction != utils.DirectionPrev {
 		nextCursor = ""
 	}
@@ -87,11 +419,52 @@ func (s *serviceAccountCtx) GetListAccountNewMatchPagination(ctx context.Context
 		prevCursor = ""
 	}
 
-	resp.Data = accountList
 	resp.LoadMore = loadMore
 	resp.NextCursor = nextCursor
 	resp.PrevCursor = prevCursor
-	resp.Limit = actualLimit
 
 	return resp, nil
 }
+
+func accountResponse(account model.AccountBaseModel, boosted bool) model.AccountResponse {
+	return model.AccountResponse{
+		AccountMaskID: account.AccountMaskID,
+		Type:          account.Type,
+		Name:          account.Name,
+		UserName:      account.UserName,
+		IsVerified:    account.IsVerified,
+		IsBoosted:     boosted,
+	}
+}
+
+// accountProfileResponse the card of target as seen by viewer, the owner always sees every field of its own card
+func accountProfileResponse(viewer, target model.AccountBaseModel, photos []model.AccountPhotoBaseModel, interests []string,
+	self bool, now time.Time) model.AccountProfileResponse {
+	resp := model.AccountProfileResponse{
+		AccountMaskID: target.AccountMaskID,
+		Type:          target.Type,
+		Name:          target.Name,
+		UserName:      target.UserName,
+		IsVerified:    target.IsVerified,
+		Bio:           target.Bio.String,
+		Photos:        make([]model.AccountPhotoResponse, len(photos)),
+		Interests:     nonNil(interests),
+	}
+
+	for i, photo := range photos {
+		resp.Photos[i] = model.AccountPhotoResponse{URL: photo.URL, Position: photo.Position}
+	}
+
+	if age, ok := target.AgeAt(now); ok && (self || !target.HideAge) {
+		resp.Age = &age
+	}
+
+	if !self && !target.HideDistance && viewer.HasLocation() && target.HasLocation() {
+		// rounded up to whole kilometers, an exact distance would let a viewer locate the account
+		distance := int(math.Ceil(utils.DistanceKM(viewer.Latitude.Float64, viewer.Longitude.Float64,
+			target.Latitude.Float64, target.Longitude.Float64)))
+		resp.DistanceKM = &distance
+	}
+
+	return resp
+}
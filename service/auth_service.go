@@ -2,61 +2,206 @@ package service
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"github.com/asaskevich/govalidator"
 	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/metrics"
+	"github.com/dwiangraeni/dealls/middleware"
 	"github.com/dwiangraeni/dealls/model"
 	"github.com/dwiangraeni/dealls/resources/request"
 	"github.com/dwiangraeni/dealls/resources/response"
+	"github.com/dwiangraeni/dealls/tracing"
 	"github.com/dwiangraeni/dealls/utils"
-	"log"
+	"log/slog"
 	"strings"
 	"time"
 )
 
 type serviceAuthCtx struct {
-	accountRepo interfaces.IAccountRepo
-	publicKey   string
-	privateKey  string
-	utilsPass   utils.PasswordHasher
+	accountRepo      interfaces.IAccountRepo
+	loginAttemptRepo interfaces.ILoginAttemptRepo
+	transactionRepo  interfaces.ITransactionRepo
+	otpService       interfaces.IOTPService
+	keySet           middleware.KeySet
+	utilsPass        utils.PasswordHasher
+	passwordPolicy   utils.PasswordPolicy
+	loginPolicy      model.LoginAttemptPolicy
+	metrics          interfaces.IMetrics
 }
 
 func NewAuthService(
 	accountRepo interfaces.IAccountRepo,
-	publicKey string,
-	privateKey string,
+	loginAttemptRepo interfaces.ILoginAttemptRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	otpService interfaces.IOTPService,
+	keySet middleware.KeySet,
 	utilsPass utils.PasswordHasher,
+	passwordPolicy utils.PasswordPolicy,
+	loginPolicy model.LoginAttemptPolicy,
+	metrics interfaces.IMetrics,
 ) interfaces.IAuthService {
 	return &serviceAuthCtx{
-		accountRepo: accountRepo,
-		publicKey:   publicKey,
-		privateKey:  privateKey,
-		utilsPass:   utilsPass,
+		accountRepo:      accountRepo,
+		loginAttemptRepo: loginAttemptRepo,
+		transactionRepo:  transactionRepo,
+		otpService:       otpService,
+		keySet:           keySet,
+		utilsPass:        utilsPass,
+		passwordPolicy:   passwordPolicy,
+		loginPolicy:      loginPolicy,
+		metrics:          metrics,
 	}
 }
 
 func (s *serviceAuthCtx) Login(ctx context.Context, form request.LoginRequest) (*response.LoginResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceAuthCtx.Login")
+	defer span.End()
+
+	attemptKeys := loginAttemptKeys(form)
+	for _, key := range attemptKeys {
+		attempt, err := s.loginAttemptRepo.GetLoginAttempt(ctx, key)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			slog.ErrorContext(ctx, "error when get login attempt", "error", err)
+			return nil, utils.ErrInternal
+		}
+
+		if retryAfter := time.Until(attempt.LockedUntil.Time); attempt.LockedUntil.Valid && retryAfter > 0 {
+			slog.InfoContext(ctx, "login locked", "key", key, "locked_until", attempt.LockedUntil.Time)
+			s.metrics.LoginFailed(metrics.LoginFailedLocked)
+			return nil, &utils.RateLimitedError{RetryAfter: retryAfter}
+		}
+	}
+
 	data, err := s.accountRepo.FindOneAccountByAccountUserName(ctx, form.Username)
 	if err != nil {
-		log.Printf("error when find account by username: %v", err)
-		return nil, errors.New(`invalid login`)
+		if !errors.Is(err, sql.ErrNoRows) {
+			slog.ErrorContext(ctx, "error when find account by username", "error", err)
+			return nil, utils.ErrInternal
+		}
+
+		slog.InfoContext(ctx, "login with unknown username")
+		s.registerLoginFailure(ctx, attemptKeys)
+		s.metrics.LoginFailed(metrics.LoginFailedInvalidCredentials)
+		return nil, utils.ErrInvalidCredentials
 	}
 
 	isValid := s.utilsPass.CheckPasswordHash(form.Password, data.Password)
 	if isValid {
-		token, err := s.utilsPass.GenerateToken(data, s.privateKey)
+		if err = s.loginAttemptRepo.ResetLoginAttempt(ctx, attemptKeys[0]); err != nil {
+			slog.ErrorContext(ctx, "error when reset login attempt", "error", err)
+		}
+
+		if data, err = s.checkLoginStatus(ctx, data); err != nil {
+			return nil, err
+		}
+
+		key, err := s.keySet.SigningKey()
 		if err != nil {
-			log.Println("error when generate token: ", err)
+			slog.ErrorContext(ctx, "error when get signing key", "error", err)
+			return nil, utils.ErrInternal
+		}
+
+		token, err := s.utilsPass.GenerateToken(data, key)
+		if err != nil {
+			slog.ErrorContext(ctx, "error when generate token", "error", err)
 			return nil, utils.ErrInternal
 		}
 		return &response.LoginResponse{Token: token}, err
 	}
-	return nil, errors.New(`invalid login`)
+
+	s.registerLoginFailure(ctx, attemptKeys)
+	s.metrics.LoginFailed(metrics.LoginFailedInvalidCredentials)
+	return nil, utils.ErrInvalidCredentials
+}
+
+// checkLoginStatus reject moderated or deleted accounts and reactivate a deactivated one. A deactivated
+// account is checked against the status it gets back, so a suspension given while it was deactivated holds.
+func (s *serviceAuthCtx) checkLoginStatus(ctx context.Context, data model.AccountBaseModel) (model.AccountBaseModel, error) {
+	now := time.Now().UTC()
+	reactivated := data.Reactivated()
+	switch reactivated.EffectiveStatus(now) {
+	case model.AccountStatusSuspended:
+		s.metrics.LoginFailed(metrics.LoginFailedSuspended)
+		return data, utils.ErrAccountSuspended
+	case model.AccountStatusBanned:
+		s.metrics.LoginFailed(metrics.LoginFailedBanned)
+		return data, utils.ErrAccountBanned
+	case model.AccountStatusDeleted:
+		s.metrics.LoginFailed(metrics.LoginFailedInvalidCredentials)
+		return data, utils.ErrInvalidCredentials
+	}
+
+	if data.Status != model.AccountStatusDeactivated {
+		return data, nil
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error when begin transaction", "error", err)
+		return data, utils.ErrInternal
+	}
+
+	// a suspension that ran out while the account was deactivated is lifted here
+	reactivated.Status = reactivated.EffectiveStatus(now)
+	reactivated.SuspendedUntil = sql.NullTime{}
+	reactivated.UpdatedBy = sql.NullString{String: data.UserName, Valid: true}
+	if _, err = s.accountRepo.UpdateAccountStatus(ctx, tx, reactivated); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		slog.ErrorContext(ctx, "error when reactivate account", "error", err)
+		return data, utils.ErrInternal
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		slog.ErrorContext(ctx, "error when commit transaction", "error", err)
+		return data, utils.ErrInternal
+	}
+
+	return reactivated, nil
+}
+
+// loginAttemptKeys username key first, failures are counted per username and per ip.
+func loginAttemptKeys(form request.LoginRequest) []string {
+	keys := []string{"user:" + strings.ToLower(form.Username)}
+	if form.IPAddress != "" {
+		keys = append(keys, "ip:"+form.IPAddress)
+	}
+
+	return keys
+}
+
+// registerLoginFailure count the failure and lock the key once it reaches the policy threshold.
+func (s *serviceAuthCtx) registerLoginFailure(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		attempt, err := s.loginAttemptRepo.IncrementLoginAttempt(ctx, key, s.loginPolicy.Window)
+		if err != nil {
+			slog.ErrorContext(ctx, "error when increment login attempt", "error", err)
+			continue
+		}
+
+		lockout := s.loginPolicy.LockoutFor(attempt.FailedCount)
+		if lockout == 0 {
+			continue
+		}
+
+		if err = s.loginAttemptRepo.LockLoginAttempt(ctx, key, time.Now().UTC().Add(lockout)); err != nil {
+			slog.ErrorContext(ctx, "error when lock login attempt", "error", err)
+		}
+	}
 }
 
 func (s *serviceAuthCtx) Register(ctx context.Context, form request.RegisterRequest) (*response.RegisterResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceAuthCtx.Register")
+	defer span.End()
+
+	if err := s.validateRegister(form); err != nil {
+		slog.WarnContext(ctx, "error validate register request", "error", err)
+		return nil, err
+	}
+
 	hash, err := s.utilsPass.GeneratePassword(form.Password)
 	if err != nil {
-		log.Println("error when generate password: ", err)
+		slog.ErrorContext(ctx, "error when generate password", "error", err)
 		return nil, utils.ErrInternal
 	}
 
@@ -65,6 +210,8 @@ func (s *serviceAuthCtx) Register(ctx context.Context, form request.RegisterRequ
 		Name:      form.Name,
 		UserName:  form.Username,
 		Password:  hash,
+		Email:     sql.NullString{String: strings.ToLower(form.Email), Valid: form.Email != ""},
+		Phone:     sql.NullString{String: form.Phone, Valid: form.Phone != ""},
 		CreatedAt: time.Now().UTC(),
 		CreatedBy: form.Username,
 		UpdatedAt: time.Now().UTC(),
@@ -72,7 +219,7 @@ func (s *serviceAuthCtx) Register(ctx context.Context, form request.RegisterRequ
 
 	data, err = s.accountRepo.InsertAccount(ctx, data)
 	if err != nil {
-		log.Printf("error when create account: %v", err)
+		slog.ErrorContext(ctx, "error when create account", "error", err)
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
 			return nil, utils.ErrDuplicateData
 		}
@@ -90,3 +237,151 @@ func (s *serviceAuthCtx) Register(ctx context.Context, form request.RegisterRequ
 		UpdatedBy:     data.UpdatedBy.String,
 	}, nil
 }
+
+// validateRegister struct tags and password policy, reported together as field errors.
+func (s *serviceAuthCtx) validateRegister(form request.RegisterRequest) error {
+	var errs govalidator.Errors
+	if _, err := govalidator.ValidateStruct(form); err != nil {
+		validationErrs, ok := err.(govalidator.Errors)
+		if !ok {
+			return err
+		}
+		errs = append(errs, validationErrs.Errors()...)
+	}
+
+	if _, invalid := govalidator.ErrorsByField(errs)["password"]; !invalid {
+		if err := s.passwordPolicy.Validate(form.Password, form.Username); err != nil {
+			errs = append(errs, govalidator.Error{Name: "password", Err: err, Validator: "password_policy"})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// ForgotPassword send a reset code to a verified contact, unknown usernames get the same answer.
+func (s *serviceAuthCtx) ForgotPassword(ctx context.Context, form request.ForgotPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "serviceAuthCtx.ForgotPassword")
+	defer span.End()
+
+	if _, err := govalidator.ValidateStruct(form); err != nil {
+		slog.WarnContext(ctx, "error validate forgot password request", "error", err)
+		return err
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountUserName(ctx, form.Username)
+	if err != nil {
+		slog.ErrorContext(ctx, "error when find account by username", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return utils.ErrInternal
+	}
+
+	channel, recipient := verifiedContact(account, form.Channel)
+	if recipient == "" {
+		slog.InfoContext(ctx, "account has no verified contact for password reset", "account_mask_id", account.AccountMaskID)
+		return nil
+	}
+
+	if err = s.otpService.SendOTP(ctx, account.ID, model.OTPPurposeResetPassword, channel, recipient); err != nil {
+		if errors.Is(err, utils.ErrTooManyRequests) {
+			slog.InfoContext(ctx, "password reset is cooling down", "account_mask_id", account.AccountMaskID)
+			return nil
+		}
+		return err
+	}
+
+	return nil
+}
+
+// verifiedContact prefer the requested channel, otherwise email then phone
+func verifiedContact(account model.AccountBaseModel, channel string) (string, string) {
+	email := account.Email.String
+	if !account.EmailVerifiedAt.Valid {
+		email = ""
+	}
+
+	phone := account.Phone.String
+	if !account.PhoneVerifiedAt.Valid {
+		phone = ""
+	}
+
+	switch {
+	case channel == model.ContactChannelPhone:
+		return model.ContactChannelPhone, phone
+	case channel == model.ContactChannelEmail || email != "":
+		return model.ContactChannelEmail, email
+	default:
+		return model.ContactChannelPhone, phone
+	}
+}
+
+func (s *serviceAuthCtx) ResetPassword(ctx context.Context, form request.ResetPasswordRequest) error {
+	ctx, span := tracing.Start(ctx, "serviceAuthCtx.ResetPassword")
+	defer span.End()
+
+	if _, err := govalidator.ValidateStruct(form); err != nil {
+		slog.WarnContext(ctx, "error validate reset password request", "error", err)
+		return err
+	}
+
+	if err := s.passwordPolicy.Validate(form.NewPassword, form.Username); err != nil {
+		slog.WarnContext(ctx, "error validate new password", "error", err)
+		return govalidator.Errors{govalidator.Error{Name: "new_password", Err: err, Validator: "password_policy"}}
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountUserName(ctx, form.Username)
+	if err != nil {
+		slog.ErrorContext(ctx, "error when find account by username", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.ErrInvalidOTP
+		}
+		return utils.ErrInternal
+	}
+
+	otp, err := s.otpService.VerifyOTP(ctx, account.ID, model.OTPPurposeResetPassword, form.Code)
+	if err != nil {
+		return err
+	}
+
+	hash, err := s.utilsPass.GeneratePassword(form.NewPassword)
+	if err != nil {
+		slog.ErrorContext(ctx, "error when generate password", "error", err)
+		return utils.ErrInternal
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "error begin transaction", "error", err)
+		return utils.ErrInternal
+	}
+
+	if err = s.otpService.ConsumeOTP(ctx, tx, otp); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		return err
+	}
+
+	account.Password = hash
+	account.UpdatedBy = sql.NullString{String: account.UserName, Valid: true}
+	if _, err = s.accountRepo.UpdateAccountPassword(ctx, tx, account); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		slog.ErrorContext(ctx, "error when update password", "error", err)
+		return utils.ErrInternal
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		slog.ErrorContext(ctx, "error commit transaction", "error", err)
+		return utils.ErrInternal
+	}
+
+	// the account owner proved access to a verified contact, lift any lockout
+	if err = s.loginAttemptRepo.ResetLoginAttempt(ctx, loginAttemptKeys(request.LoginRequest{Username: account.UserName})[0]); err != nil {
+		slog.ErrorContext(ctx, "error when reset login attempt", "error", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// readinessCheckTimeout keeps a probe from hanging on a database that does not answer
+const readinessCheckTimeout = 2 * time.Second
+
+type serviceHealthCtx struct {
+	db        interfaces.IDatabasePinger
+	migration interfaces.IMigrationStatus
+	draining  atomic.Bool
+}
+
+func NewHealthService(db interfaces.IDatabasePinger, migration interfaces.IMigrationStatus) interfaces.IHealthService {
+	return &serviceHealthCtx{
+		db:        db,
+		migration: migration,
+	}
+}
+
+// CheckReadiness ready when the instance is not shutting down, the database answers and every migration is applied
+func (s *serviceHealthCtx) CheckReadiness(ctx context.Context) model.Readiness {
+	var (
+		eventName = "serviceHealthCtx.CheckReadiness"
+		logger    = slog.With("event", eventName)
+		resp      = model.Readiness{Ready: true, Checks: map[string]string{}}
+	)
+
+	fail := func(check, reason string) {
+		resp.Ready = false
+		resp.Checks[check] = reason
+	}
+
+	if s.draining.Load() {
+		fail("shutdown", "shutting down")
+	} else {
+		resp.Checks["shutdown"] = "ok"
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	if err := s.db.PingContext(ctx); err != nil {
+		logger.ErrorContext(ctx, "error ping database", "error", err)
+		fail("database", "unreachable")
+		// the migration check needs the database as well
+		fail("migrations", "unknown")
+		return resp
+	}
+	resp.Checks["database"] = "ok"
+
+	statuses, err := s.migration.Status(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error get migration status", "error", err)
+		fail("migrations", "unknown")
+		return resp
+	}
+
+	var pending, modified int
+	for _, status := range statuses {
+		switch {
+		case !status.Applied:
+			pending++
+		case status.Modified:
+			modified++
+		}
+	}
+
+	switch {
+	case pending > 0:
+		fail("migrations", fmt.Sprintf("%d pending", pending))
+	case modified > 0:
+		fail("migrations", fmt.Sprintf("%d modified", modified))
+	default:
+		resp.Checks["migrations"] = "ok"
+	}
+
+	return resp
+}
+
+func (s *serviceHealthCtx) Drain() {
+	s.draining.Store(true)
+}
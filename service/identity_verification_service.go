@@ -0,0 +1,390 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"github.com/asaskevich/govalidator"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
+	"github.com/dwiangraeni/dealls/utils"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+var selfieExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+type serviceIdentityVerificationCtx struct {
+	accountRepo              interfaces.IAccountRepo
+	premiumPackageRepo       interfaces.IPremiumPackageRepo
+	identityVerificationRepo interfaces.IIdentityVerificationRepo
+	transactionRepo          interfaces.ITransactionRepo
+	storage                  interfaces.IFileStorage
+	cursorCodec              utils.CursorCodec
+	policy                   model.IdentityVerificationPolicy
+}
+
+func NewIdentityVerificationService(accountRepo interfaces.IAccountRepo,
+	premiumPackageRepo interfaces.IPremiumPackageRepo,
+	identityVerificationRepo interfaces.IIdentityVerificationRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	storage interfaces.IFileStorage,
+	policy model.IdentityVerificationPolicy,
+	cursorCodec utils.CursorCodec) interfaces.IIdentityVerificationService {
+	return &serviceIdentityVerificationCtx{
+		accountRepo:              accountRepo,
+		premiumPackageRepo:       premiumPackageRepo,
+		identityVerificationRepo: identityVerificationRepo,
+		transactionRepo:          transactionRepo,
+		storage:                  storage,
+		cursorCodec:              cursorCodec,
+		policy:                   policy,
+	}
+}
+
+// SubmitIdentityVerification store the selfie and queue it for review, only accounts
+// that bought the VERIFIED package may submit.
+func (s *serviceIdentityVerificationCtx) SubmitIdentityVerification(ctx context.Context, req model.SubmitIdentityVerificationRequest) (resp model.IdentityVerificationResponse, err error) {
+	var (
+		eventName = "serviceIdentityVerificationCtx.SubmitIdentityVerification"
+		logger    = slog.With("event", eventName, "account_mask_id", req.AccountMaskID, "size", req.Size)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	if req.Selfie == nil || req.Size <= 0 {
+		return resp, utils.ErrInvalidSelfie
+	}
+
+	if s.policy.MaxSelfieSize > 0 && req.Size > s.policy.MaxSelfieSize {
+		return resp, utils.ErrSelfieTooLarge
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	if account.IsVerified {
+		return resp, utils.ErrAlreadyVerified
+	}
+
+	if _, err = s.premiumPackageRepo.GetPremiumPackageUserByTitleAndAccountID(ctx, model.PremiumPackageVerified, account.ID); err != nil {
+		logger.ErrorContext(ctx, "failed to get verified package", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrVerificationNotPurchased
+		}
+		return resp, utils.ErrInternal
+	}
+
+	latest, err := s.identityVerificationRepo.GetLatestIdentityVerificationByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.ErrorContext(ctx, "failed to get latest identity verification", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if latest.Status == model.IdentityVerificationPending {
+		return resp, utils.ErrVerificationPending
+	}
+
+	// sniff the content instead of trusting the multipart header
+	selfie := bufio.NewReader(req.Selfie)
+	head, _ := selfie.Peek(512)
+	ext, ok := selfieExtensions[http.DetectContentType(head)]
+	if !ok {
+		return resp, utils.ErrInvalidSelfie
+	}
+
+	name, err := selfieName(account.AccountMaskID, ext)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to generate selfie name", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if err = s.storage.Save(ctx, name, selfie); err != nil {
+		logger.ErrorContext(ctx, "failed to save selfie", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	verification := model.IdentityVerificationBaseModel{
+		AccountID:     account.ID,
+		SelfiePath:    name,
+		Status:        model.IdentityVerificationPending,
+		AccountMaskID: account.AccountMaskID,
+		Name:          account.Name,
+		UserName:      account.UserName,
+	}
+
+	if err = s.identityVerificationRepo.InsertIdentityVerification(ctx, &verification); err != nil {
+		if errDelete := s.storage.Delete(ctx, name); errDelete != nil {
+			logger.ErrorContext(ctx, "failed to delete selfie", "path", name, "error", errDelete)
+		}
+
+		logger.ErrorContext(ctx, "failed to insert identity verification", "error", err)
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return resp, utils.ErrVerificationPending
+		}
+		return resp, utils.ErrInternal
+	}
+
+	return identityVerificationResponse(verification), nil
+}
+
+func (s *serviceIdentityVerificationCtx) GetIdentityVerificationStatus(ctx context.Context, accountMaskID string) (resp model.IdentityVerificationResponse, err error) {
+	var (
+		eventName = "serviceIdentityVerificationCtx.GetIdentityVerificationStatus"
+		logger    = slog.With("event", eventName, "account_mask_id", accountMaskID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	verification, err := s.identityVerificationRepo.GetLatestIdentityVerificationByAccountID(ctx, account.ID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get latest identity verification", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	return identityVerificationResponse(verification), nil
+}
+
+func (s *serviceIdentityVerificationCtx) GetListIdentityVerificationPagination(ctx context.Context, req model.ListIdentityVerificationRequest) (resp model.ListIdentityVerificationPagination, err error) {
+	var (
+		eventName              = "serviceIdentityVerificationCtx.GetListIdentityVerificationPagination"
+		logger                 = slog.With("event", eventName, "req", req)
+		actualLimit            = req.Limit
+		loadMore               bool
+		dataCursor             []int
+		prevCursor, nextCursor string
+		filter                 = "identity_verification|" + req.Status
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	if req.Cursor != "" {
+		var cursor utils.Cursor
+		if cursor, err = s.cursorCodec.Decode(req.Cursor, filter); err != nil {
+			logger.WarnContext(ctx, "error decode cursor", "error", err)
+			return resp, utils.ErrInvalidCursor
+		}
+		req.CursorID, req.Direction = cursor.ID, cursor.Direction
+	}
+
+	req.Limit = req.Limit + 1
+	verificationList, err := s.identityVerificationRepo.GetListIdentityVerificationPagination(ctx, req)
+	if err != nil {
+		logger.ErrorContext(ctx, "error get list identity verification", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if len(verificationList) == 0 {
+		return resp, nil
+	}
+
+	if len(verificationList) > actualLimit {
+		loadMore = true
+		verificationList = verificationList[:actualLimit]
+	}
+
+	resp.Data = make([]model.IdentityVerificationResponse, len(verificationList))
+	dataCursor = make([]int, len(verificationList))
+	for i, v := range verificationList {
+		dataCursor[i] = int(v.ID)
+		resp.Data[i] = identityVerificationResponse(v)
+	}
+
+	prevCursorID, nextCursorID := utils.GetPaginationCursor(dataCursor, req.Direction == utils.DirectionPrev)
+	nextCursor = s.cursorCodec.Encode(nextCursorID, utils.DirectionNext, filter)
+	prevCursor = s.cursorCodec.Encode(prevCursorID, utils.DirectionPrev, filter)
+	if !loadMore && req.Direction != utils.DirectionPrev {
+		nextCursor = ""
+	}
+
+	if req.CursorID == 0 || (!loadMore && req.Direction == utils.DirectionPrev) {
+		prevCursor = ""
+	}
+
+	resp.LoadMore = loadMore
+	resp.NextCursor = nextCursor
+	resp.PrevCursor = prevCursor
+	resp.Limit = actualLimit
+
+	return resp, nil
+}
+
+// ReviewIdentityVerification approve or reject a pending submission, approval grants the verified badge.
+func (s *serviceIdentityVerificationCtx) ReviewIdentityVerification(ctx context.Context, req model.ReviewIdentityVerificationRequest) (resp model.IdentityVerificationResponse, err error) {
+	var (
+		eventName = "serviceIdentityVerificationCtx.ReviewIdentityVerification"
+		logger    = slog.With("event", eventName, "req", req)
+		now       = time.Now().UTC()
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	if !req.Approve && req.Reason == "" {
+		return resp, utils.ErrRejectReasonRequired
+	}
+
+	verification, err := s.identityVerificationRepo.GetIdentityVerificationByUID(ctx, req.VerificationUID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get identity verification", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	if verification.Status != model.IdentityVerificationPending {
+		return resp, utils.ErrVerificationReviewed
+	}
+
+	verification.Status = model.IdentityVerificationRejected
+	if req.Approve {
+		verification.Status = model.IdentityVerificationApproved
+	}
+	verification.Reason = sql.NullString{String: req.Reason, Valid: req.Reason != ""}
+	verification.ReviewedBy = sql.NullString{String: req.ReviewedBy, Valid: true}
+	verification.ReviewedAt = sql.NullTime{Time: now, Valid: true}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if err = s.identityVerificationRepo.ReviewIdentityVerification(ctx, tx, &verification); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to review identity verification", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrVerificationReviewed
+		}
+		return resp, utils.ErrInternal
+	}
+
+	if req.Approve {
+		// only the badge is written, a full row update would overwrite concurrent changes of the account
+		account := model.AccountBaseModel{ID: verification.AccountID, UpdatedBy: sql.NullString{String: req.ReviewedBy, Valid: true}}
+		if _, err = s.accountRepo.UpdateAccountVerified(ctx, tx, account); err != nil {
+			s.transactionRepo.RollbackTrx(ctx, tx)
+			logger.ErrorContext(ctx, "failed to verify account", "error", err)
+			return resp, utils.ErrInternal
+		}
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	return identityVerificationResponse(verification), nil
+}
+
+// OpenSelfie return the stored selfie and its content type for admin review.
+func (s *serviceIdentityVerificationCtx) OpenSelfie(ctx context.Context, verificationUID string) (io.ReadCloser, string, error) {
+	var (
+		eventName = "serviceIdentityVerificationCtx.OpenSelfie"
+		logger    = slog.With("event", eventName, "verification_uid", verificationUID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	verification, err := s.identityVerificationRepo.GetIdentityVerificationByUID(ctx, verificationUID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get identity verification", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, "", utils.ErrDataNotFound
+		}
+		return nil, "", utils.ErrInternal
+	}
+
+	selfie, err := s.storage.Open(ctx, verification.SelfiePath)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to open selfie", "error", err)
+		return nil, "", utils.ErrInternal
+	}
+
+	contentType := "application/octet-stream"
+	for ct, ext := range selfieExtensions {
+		if path.Ext(verification.SelfiePath) == ext {
+			contentType = ct
+		}
+	}
+
+	return selfie, contentType, nil
+}
+
+func selfieName(accountMaskID, ext string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return path.Join("identity", accountMaskID, hex.EncodeToString(b)+ext), nil
+}
+
+func identityVerificationResponse(v model.IdentityVerificationBaseModel) model.IdentityVerificationResponse {
+	resp := model.IdentityVerificationResponse{
+		VerificationUID: v.VerificationUID,
+		AccountMaskID:   v.AccountMaskID,
+		Name:            v.Name,
+		UserName:        v.UserName,
+		Status:          v.Status,
+		Reason:          v.Reason.String,
+		ReviewedBy:      v.ReviewedBy.String,
+		CreatedAt:       v.CreatedAt,
+	}
+
+	if v.ReviewedAt.Valid {
+		resp.ReviewedAt = &v.ReviewedAt.Time
+	}
+
+	return resp
+}
@@ -0,0 +1,394 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/asaskevich/govalidator"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
+	"github.com/dwiangraeni/dealls/utils"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type serviceModerationCtx struct {
+	accountRepo       interfaces.IAccountRepo
+	accountBlockRepo  interfaces.IAccountBlockRepo
+	accountReportRepo interfaces.IAccountReportRepo
+	transactionRepo   interfaces.ITransactionRepo
+	cursorCodec       utils.CursorCodec
+}
+
+func NewModerationService(accountRepo interfaces.IAccountRepo,
+	accountBlockRepo interfaces.IAccountBlockRepo,
+	accountReportRepo interfaces.IAccountReportRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	cursorCodec utils.CursorCodec) interfaces.IModerationService {
+	return &serviceModerationCtx{
+		accountRepo:       accountRepo,
+		accountBlockRepo:  accountBlockRepo,
+		accountReportRepo: accountReportRepo,
+		transactionRepo:   transactionRepo,
+		cursorCodec:       cursorCodec,
+	}
+}
+
+func (s *serviceModerationCtx) BlockAccount(ctx context.Context, req model.BlockAccountRequest) error {
+	var (
+		eventName = "serviceModerationCtx.BlockAccount"
+		logger    = slog.With("event", eventName, "req", req)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return err
+	}
+
+	account, target, err := s.accountPair(ctx, req.AccountMaskID, req.TargetAccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "error get account pair", "error", err)
+		return err
+	}
+
+	if err = s.accountBlockRepo.InsertAccountBlock(ctx, &model.AccountBlockBaseModel{
+		BlockerID: account.ID,
+		BlockedID: target.ID,
+	}); err != nil {
+		logger.ErrorContext(ctx, "error insert account block", "error", err)
+		return utils.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *serviceModerationCtx) UnblockAccount(ctx context.Context, req model.BlockAccountRequest) error {
+	var (
+		eventName = "serviceModerationCtx.UnblockAccount"
+		logger    = slog.With("event", eventName, "req", req)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err := govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return err
+	}
+
+	account, target, err := s.accountPair(ctx, req.AccountMaskID, req.TargetAccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "error get account pair", "error", err)
+		return err
+	}
+
+	// only the blocker can lift a block, a block by the other side stays in place
+	if err = s.accountBlockRepo.DeleteAccountBlock(ctx, account.ID, target.ID); err != nil {
+		logger.ErrorContext(ctx, "error delete account block", "error", err)
+		return utils.ErrInternal
+	}
+
+	return nil
+}
+
+func (s *serviceModerationCtx) ReportAccount(ctx context.Context, req model.ReportAccountRequest) (resp model.AccountReportResponse, err error) {
+	var (
+		eventName = "serviceModerationCtx.ReportAccount"
+		logger    = slog.With("event", eventName, "req", req)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	account, target, err := s.accountPair(ctx, req.AccountMaskID, req.TargetAccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "error get account pair", "error", err)
+		return resp, err
+	}
+
+	details := strings.TrimSpace(req.Details)
+	report := model.AccountReportBaseModel{
+		ReporterID:       account.ID,
+		ReportedID:       target.ID,
+		Reason:           req.Reason,
+		Details:          sql.NullString{String: details, Valid: details != ""},
+		ReportedMaskID:   target.AccountMaskID,
+		ReportedUserName: target.UserName,
+	}
+
+	if err = s.accountReportRepo.InsertAccountReport(ctx, &report); err != nil {
+		logger.ErrorContext(ctx, "error insert account report", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	resp = accountReportResponse(report)
+	// the reporter does not need to know how the reported account is moderated
+	resp.ReportedUserName = ""
+	resp.ReportedStatus = ""
+
+	return resp, nil
+}
+
+func (s *serviceModerationCtx) GetListAccountReportPagination(ctx context.Context, req model.ListAccountReportRequest) (resp model.ListAccountReportPagination, err error) {
+	var (
+		eventName              = "serviceModerationCtx.GetListAccountReportPagination"
+		logger                 = slog.With("event", eventName, "req", req)
+		actualLimit            = req.Limit
+		loadMore               bool
+		dataCursor             []int
+		prevCursor, nextCursor string
+		filter                 = "account_report|" + req.Status
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	if req.Cursor != "" {
+		var cursor utils.Cursor
+		if cursor, err = s.cursorCodec.Decode(req.Cursor, filter); err != nil {
+			logger.WarnContext(ctx, "error decode cursor", "error", err)
+			return resp, utils.ErrInvalidCursor
+		}
+		req.CursorID, req.Direction = cursor.ID, cursor.Direction
+	}
+
+	req.Limit = req.Limit + 1
+	reportList, err := s.accountReportRepo.GetListAccountReportPagination(ctx, req)
+	if err != nil {
+		logger.ErrorContext(ctx, "error get list account report", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if len(reportList) == 0 {
+		return resp, nil
+	}
+
+	if len(reportList) > actualLimit {
+		loadMore = true
+		reportList = reportList[:actualLimit]
+	}
+
+	resp.Data = make([]model.AccountReportResponse, len(reportList))
+	dataCursor = make([]int, len(reportList))
+	for i, v := range reportList {
+		dataCursor[i] = int(v.ID)
+		resp.Data[i] = accountReportResponse(v)
+	}
+
+	prevCursorID, nextCursorID := utils.GetPaginationCursor(dataCursor, req.Direction == utils.DirectionPrev)
+	nextCursor = s.cursorCodec.Encode(nextCursorID, utils.DirectionNext, filter)
+	prevCursor = s.cursorCodec.Encode(prevCursorID, utils.DirectionPrev, filter)
+	if !loadMore && req.Direction != utils.DirectionPrev {
+		nextCursor = ""
+	}
+
+	if req.CursorID == 0 || (!loadMore && req.Direction == utils.DirectionPrev) {
+		prevCursor = ""
+	}
+
+	resp.LoadMore = loadMore
+	resp.NextCursor = nextCursor
+	resp.PrevCursor = prevCursor
+	resp.Limit = actualLimit
+
+	return resp, nil
+}
+
+// ModerateAccountReport close an open report and apply the action to the reported account.
+// Actions never lower an account status, a banned account stays banned.
+func (s *serviceModerationCtx) ModerateAccountReport(ctx context.Context, req model.ModerateAccountReportRequest) (resp model.AccountReportResponse, err error) {
+	var (
+		eventName = "serviceModerationCtx.ModerateAccountReport"
+		logger    = slog.With("event", eventName, "req", req)
+		now       = time.Now().UTC()
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	report, err := s.accountReportRepo.GetAccountReportByUID(ctx, req.ReportUID)
+	if err != nil {
+		logger.ErrorContext(ctx, "error get account report", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	if report.Status != model.ReportStatusOpen {
+		return resp, utils.ErrReportAlreadyClosed
+	}
+
+	note := strings.TrimSpace(req.Note)
+	report.Status = model.ReportStatusResolved
+	report.Action = sql.NullString{String: req.Action, Valid: true}
+	if req.Action == model.ModerationActionDismiss {
+		report.Status = model.ReportStatusDismissed
+		report.Action = sql.NullString{}
+	}
+	report.Note = sql.NullString{String: note, Valid: note != ""}
+	report.ResolvedBy = sql.NullString{String: req.ModeratedBy, Valid: true}
+	report.ResolvedAt = sql.NullTime{Time: now, Valid: true}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if err = s.accountReportRepo.ResolveAccountReport(ctx, tx, &report); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "error resolve account report", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrReportAlreadyClosed
+		}
+		return resp, utils.ErrInternal
+	}
+
+	if report.Action.Valid {
+		account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, report.ReportedMaskID)
+		if err != nil {
+			s.transactionRepo.RollbackTrx(ctx, tx)
+			logger.ErrorContext(ctx, "error find reported account", "error", err)
+			return resp, utils.ErrInternal
+		}
+
+		// the report stays open when the action would not change the account
+		if !applyModerationAction(&account, req.Action, time.Duration(req.SuspendHours)*time.Hour, now) {
+			s.transactionRepo.RollbackTrx(ctx, tx)
+			logger.WarnContext(ctx, "moderation action not applicable", "status", account.Status, "action", req.Action)
+			return resp, utils.ErrActionNotApplicable
+		}
+
+		account.UpdatedBy = sql.NullString{String: req.ModeratedBy, Valid: true}
+		if _, err = s.accountRepo.UpdateAccountStatus(ctx, tx, account); err != nil {
+			s.transactionRepo.RollbackTrx(ctx, tx)
+			logger.ErrorContext(ctx, "error update account status", "error", err)
+			return resp, utils.ErrInternal
+		}
+		report.ReportedStatus = account.Status
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	return accountReportResponse(report), nil
+}
+
+// accountPair load the acting and the target account, they must be two different accounts.
+func (s *serviceModerationCtx) accountPair(ctx context.Context, accountMaskID, targetMaskID string) (account, target model.AccountBaseModel, err error) {
+	if accountMaskID == targetMaskID {
+		return account, target, utils.ErrCannotTargetSelf
+	}
+
+	if account, err = s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, target, utils.ErrDataNotFound
+		}
+		return account, target, utils.ErrInternal
+	}
+
+	if target, err = s.accountRepo.FindOneAccountByAccountMaskID(ctx, targetMaskID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return account, target, utils.ErrDataNotFound
+		}
+		return account, target, utils.ErrInternal
+	}
+
+	return account, target, nil
+}
+
+// applyModerationAction update the account status in place and report whether it changed.
+// BANNED and DELETED are terminal. A warning or suspension of a DEACTIVATED account goes to the status
+// it gets back on login, so an expired suspension never reactivates it without its owner.
+func applyModerationAction(account *model.AccountBaseModel, action string, suspendFor time.Duration, now time.Time) bool {
+	if account.Status == model.AccountStatusDeactivated && action != model.ModerationActionBan {
+		held := account.Reactivated()
+		if !applyModerationAction(&held, action, suspendFor, now) {
+			return false
+		}
+		account.ReactivateStatus = sql.NullString{String: held.Status, Valid: true}
+		account.SuspendedUntil = held.SuspendedUntil
+		return true
+	}
+
+	current := account.EffectiveStatus(now)
+	if current == model.AccountStatusBanned || current == model.AccountStatusDeleted {
+		return false
+	}
+
+	switch action {
+	case model.ModerationActionWarn:
+		if current != model.AccountStatusActive {
+			return false
+		}
+		account.Status = model.AccountStatusWarned
+		account.SuspendedUntil = sql.NullTime{}
+	case model.ModerationActionSuspend:
+		until := sql.NullTime{}
+		if suspendFor > 0 {
+			until = sql.NullTime{Time: now.Add(suspendFor), Valid: true}
+		}
+
+		// an indefinite suspension is never shortened by a timed one
+		if current == model.AccountStatusSuspended && (!account.SuspendedUntil.Valid ||
+			(until.Valid && until.Time.Before(account.SuspendedUntil.Time))) {
+			return false
+		}
+		account.Status = model.AccountStatusSuspended
+		account.SuspendedUntil = until
+	case model.ModerationActionBan:
+		account.Status = model.AccountStatusBanned
+		account.SuspendedUntil = sql.NullTime{}
+		account.ReactivateStatus = sql.NullString{}
+	default:
+		return false
+	}
+
+	return true
+}
+
+func accountReportResponse(r model.AccountReportBaseModel) model.AccountReportResponse {
+	resp := model.AccountReportResponse{
+		ReportUID:        r.ReportUID,
+		ReporterMaskID:   r.ReporterMaskID,
+		ReportedMaskID:   r.ReportedMaskID,
+		ReportedUserName: r.ReportedUserName,
+		ReportedStatus:   r.ReportedStatus,
+		Reason:           r.Reason,
+		Details:          r.Details.String,
+		Status:           r.Status,
+		Action:           r.Action.String,
+		Note:             r.Note.String,
+		ResolvedBy:       r.ResolvedBy.String,
+		CreatedAt:        r.CreatedAt,
+	}
+
+	if r.ResolvedAt.Valid {
+		resp.ResolvedAt = &r.ResolvedAt.Time
+	}
+
+	return resp
+}
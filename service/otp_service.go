@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
+	"github.com/dwiangraeni/dealls/utils"
+	"log/slog"
+	"time"
+)
+
+var otpSubjects = map[string]string{
+	model.OTPPurposeVerifyEmail:   "Verify your email",
+	model.OTPPurposeVerifyPhone:   "Verify your phone number",
+	model.OTPPurposeResetPassword: "Reset your password",
+}
+
+type serviceOTPCtx struct {
+	otpRepo         interfaces.IOTPRepo
+	transactionRepo interfaces.ITransactionRepo
+	notifier        interfaces.INotifier
+	otpGenerator    utils.OTPGenerator
+	policy          model.OTPPolicy
+}
+
+func NewOTPService(otpRepo interfaces.IOTPRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	notifier interfaces.INotifier,
+	otpGenerator utils.OTPGenerator,
+	policy model.OTPPolicy) interfaces.IOTPService {
+	return &serviceOTPCtx{
+		otpRepo:         otpRepo,
+		transactionRepo: transactionRepo,
+		notifier:        notifier,
+		otpGenerator:    otpGenerator,
+		policy:          policy,
+	}
+}
+
+// SendOTP replace any pending code of the same purpose and deliver a new one to recipient
+func (s *serviceOTPCtx) SendOTP(ctx context.Context, accountID int64, purpose, channel, recipient string) error {
+	var (
+		eventName = "serviceOTPCtx.SendOTP"
+		logger    = slog.With("event", eventName, "account_id", accountID, "purpose", purpose, "channel", channel)
+		now       = time.Now().UTC()
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	latest, err := s.otpRepo.GetLatestOTP(ctx, accountID, purpose)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.ErrorContext(ctx, "error get latest otp", "error", err)
+		return utils.ErrInternal
+	}
+
+	if wait := s.policy.ResendCooldown - now.Sub(latest.CreatedAt); latest.ID != 0 && wait > 0 {
+		logger.InfoContext(ctx, "otp resend is cooling down")
+		return &utils.RateLimitedError{RetryAfter: wait}
+	}
+
+	code, err := s.otpGenerator.Generate(s.policy.Length)
+	if err != nil {
+		logger.ErrorContext(ctx, "error generate otp", "error", err)
+		return utils.ErrInternal
+	}
+
+	otp := model.OTPBaseModel{
+		AccountID: accountID,
+		Purpose:   purpose,
+		Recipient: recipient,
+		CodeHash:  s.otpGenerator.Hash(purpose, accountID, code),
+		ExpiresAt: now.Add(s.policy.TTL),
+		CreatedAt: now,
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return utils.ErrInternal
+	}
+
+	if err = s.otpRepo.InvalidateOTP(ctx, tx, accountID, purpose); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "error invalidate otp", "error", err)
+		return utils.ErrInternal
+	}
+
+	if err = s.otpRepo.InsertOTP(ctx, tx, &otp); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "error insert otp", "error", err)
+		return utils.ErrInternal
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return utils.ErrInternal
+	}
+
+	notification := model.Notification{
+		Channel:   channel,
+		Recipient: recipient,
+		Subject:   otpSubjects[purpose],
+		Body: fmt.Sprintf("Your Dealls code is %s. It expires in %d minutes, never share it with anyone.",
+			code, int(s.policy.TTL.Minutes())),
+	}
+
+	if err = s.notifier.Send(ctx, notification); err != nil {
+		logger.ErrorContext(ctx, "error send otp", "error", err)
+		return utils.ErrInternal
+	}
+
+	return nil
+}
+
+// VerifyOTP check code against the latest pending otp, the caller consumes it with ConsumeOTP
+func (s *serviceOTPCtx) VerifyOTP(ctx context.Context, accountID int64, purpose, code string) (model.OTPBaseModel, error) {
+	var (
+		eventName = "serviceOTPCtx.VerifyOTP"
+		logger    = slog.With("event", eventName, "account_id", accountID, "purpose", purpose)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	otp, err := s.otpRepo.GetLatestOTP(ctx, accountID, purpose)
+	if err != nil {
+		logger.ErrorContext(ctx, "error get latest otp", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return otp, utils.ErrInvalidOTP
+		}
+		return otp, utils.ErrInternal
+	}
+
+	if otp.ConsumedAt.Valid || time.Now().UTC().After(otp.ExpiresAt) {
+		logger.InfoContext(ctx, "otp is consumed or expired")
+		return otp, utils.ErrInvalidOTP
+	}
+
+	// count the guess before comparing, concurrent guesses then cannot get past the max attempts
+	if _, err = s.otpRepo.IncrementOTPAttempt(ctx, otp.ID, s.policy.MaxAttempts); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.InfoContext(ctx, "otp is out of attempts")
+			return otp, utils.ErrInvalidOTP
+		}
+		logger.ErrorContext(ctx, "error increment otp attempt", "error", err)
+		return otp, utils.ErrInternal
+	}
+
+	if !s.otpGenerator.Compare(otp.CodeHash, purpose, accountID, code) {
+		logger.InfoContext(ctx, "otp does not match")
+		return otp, utils.ErrInvalidOTP
+	}
+
+	return otp, nil
+}
+
+func (s *serviceOTPCtx) ConsumeOTP(ctx context.Context, trx *sql.Tx, otp model.OTPBaseModel) error {
+	ctx, span := tracing.Start(ctx, "serviceOTPCtx.ConsumeOTP")
+	defer span.End()
+
+	if err := s.otpRepo.ConsumeOTP(ctx, trx, otp.ID); err != nil {
+		slog.ErrorContext(ctx, "error consume otp", "event", "serviceOTPCtx.ConsumeOTP", "otp_id", otp.ID, "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return utils.ErrInvalidOTP
+		}
+		return utils.ErrInternal
+	}
+
+	return nil
+}
@@ -7,57 +7,78 @@ import (
 	"github.com/asaskevich/govalidator"
 	"github.com/dwiangraeni/dealls/interfaces"
 	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
 	"github.com/dwiangraeni/dealls/utils"
-	"log"
+	"log/slog"
 	"strings"
+	"time"
 )
 
 type servicePremiumPackageCtx struct {
 	accountRepo        interfaces.IAccountRepo
 	premiumPackageRepo interfaces.IPremiumPackageRepo
-	hashCursor         utils.HashInterface
+	accountEffectRepo  interfaces.IAccountEffectRepo
+	creditLedgerRepo   interfaces.ICreditLedgerRepo
+	cursorCodec        utils.CursorCodec
 	transactionRepo    interfaces.ITransactionRepo
+	effectPolicy       model.AccountEffectPolicy
+	metrics            interfaces.IMetrics
 }
 
 func NewPremiumPackageService(accountRepo interfaces.IAccountRepo,
 	premiumPackageRepo interfaces.IPremiumPackageRepo,
-	transactionRepo interfaces.ITransactionRepo) interfaces.IPremiumPackageService {
+	accountEffectRepo interfaces.IAccountEffectRepo,
+	creditLedgerRepo interfaces.ICreditLedgerRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	effectPolicy model.AccountEffectPolicy,
+	metrics interfaces.IMetrics,
+	cursorCodec utils.CursorCodec) interfaces.IPremiumPackageService {
 	return &servicePremiumPackageCtx{
 		accountRepo:        accountRepo,
 		premiumPackageRepo: premiumPackageRepo,
-		hashCursor:         utils.InitHash(utils.ConstCursorHashSalt, utils.ConstHashLength),
+		accountEffectRepo:  accountEffectRepo,
+		creditLedgerRepo:   creditLedgerRepo,
+		cursorCodec:        cursorCodec,
 		transactionRepo:    transactionRepo,
+		effectPolicy:       effectPolicy,
+		metrics:            metrics,
 	}
 }
 
 func (s *servicePremiumPackageCtx) GetListPremiumPackagePagination(ctx context.Context, req model.PaginationRequest) (resp model.ListPackagePagination, err error) {
 	var (
-		eventName = "servicePremiumPackageCtx.GetListPremiumPackagePagination"
-		logFields = map[string]interface{}{
-			"_event": eventName,
-			"req":    req,
-		}
+		eventName              = "servicePremiumPackageCtx.GetListPremiumPackagePagination"
+		logger                 = slog.With("event", eventName, "req", req)
 		actualLimit            = req.Limit
 		loadMore               bool
 		dataCursor             []int
 		prevCursor, nextCursor string
+		filter                 = "premium_package"
 	)
 
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
 	// validate req
 	if _, err = govalidator.ValidateStruct(req); err != nil {
-		log.Printf("%s: error validate request: %v", logFields, err)
+		logger.WarnContext(ctx, "error validate request", "error", err)
 		return resp, err
 	}
 
 	if req.Cursor != "" {
-		req.CursorID = s.hashCursor.DecodePublicID(req.Cursor)
+		var cursor utils.Cursor
+		if cursor, err = s.cursorCodec.Decode(req.Cursor, filter); err != nil {
+			logger.WarnContext(ctx, "error decode cursor", "error", err)
+			return resp, utils.ErrInvalidCursor
+		}
+		req.CursorID, req.Direction = cursor.ID, cursor.Direction
 	}
 
 	// get list premium package
 	req.Limit = req.Limit + 1
 	packageList, err := s.premiumPackageRepo.GetListPremiumPackagePagination(ctx, req)
 	if err != nil {
-		log.Printf("%s: error get list premium package: %v", logFields, err)
+		logger.ErrorContext(ctx, "error get list premium package", "error", err)
 		return resp, utils.ErrInternal
 	}
 
@@ -73,7 +94,7 @@ func (s *servicePremiumPackageCtx) GetListPremiumPackagePagination(ctx context.C
 	// get user premium package
 	userPremiumPackage, err := s.premiumPackageRepo.GetPremiumPackageUserByAccountMaskID(ctx, req.AccountMaskID)
 	if err != nil {
-		log.Printf("%s: error get user premium package: %v", logFields, err)
+		logger.ErrorContext(ctx, "error get user premium package", "error", err)
 		return resp, utils.ErrInternal
 	}
 	listUserPremiumPackage := make([]int, len(userPremiumPackage))
@@ -87,22 +108,24 @@ func (s *servicePremiumPackageCtx) GetListPremiumPackagePagination(ctx context.C
 		dataCursor[i] = int(v.ID)
 
 		premiumPackageList[i] = model.PremiumPackageResponse{
-			PackageUID:  v.PackageUID,
-			Title:       v.Title,
-			Price:       v.Price,
-			Description: v.Description,
-			IsActive:    v.IsActive,
-			CreatedAt:   v.CreatedAt,
-			UpdatedAt:   v.UpdatedAt.Time,
-			CreatedBy:   v.CreatedBy,
-			UpdateBy:    v.UpdatedBy.String,
-			IsPurchased: utils.IsIntInSlice(listUserPremiumPackage, int(v.ID)),
+			PackageUID:   v.PackageUID,
+			Title:        v.Title,
+			Price:        v.Price,
+			Description:  v.Description,
+			IsActive:     v.IsActive,
+			CreditType:   v.CreditType.String,
+			CreditAmount: v.CreditAmount,
+			CreatedAt:    v.CreatedAt,
+			UpdatedAt:    v.UpdatedAt.Time,
+			CreatedBy:    v.CreatedBy,
+			UpdateBy:     v.UpdatedBy.String,
+			IsPurchased:  utils.IsIntInSlice(listUserPremiumPackage, int(v.ID)),
 		}
 	}
 
 	prevCursorID, nextCursorID := utils.GetPaginationCursor(dataCursor, req.Direction == utils.DirectionPrev)
-	nextCursor = s.hashCursor.EncodePublicID(nextCursorID)
-	prevCursor = s.hashCursor.EncodePublicID(prevCursorID)
+	nextCursor = s.cursorCodec.Encode(nextCursorID, utils.DirectionNext, filter)
+	prevCursor = s.cursorCodec.Encode(prevCursorID, utils.DirectionPrev, filter)
 	if !loadMore && req.Direction != utils.DirectionPrev {
 		nextCursor = ""
 	}
@@ -123,20 +146,20 @@ func (s *servicePremiumPackageCtx) GetListPremiumPackagePagination(ctx context.C
 func (s *servicePremiumPackageCtx) PremiumPackageCheckout(ctx context.Context, req model.PremiumPackageCheckoutRequest) error {
 	var (
 		eventName = "servicePremiumPackageCtx.PremiumPackageCheckout"
-		logFields = map[string]interface{}{
-			"_event": eventName,
-			"req":    req,
-		}
+		logger    = slog.With("event", eventName, "req", req)
 	)
 
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
 	if _, err := govalidator.ValidateStruct(req); err != nil {
-		log.Printf("%s: error validate request: %v", logFields, err)
+		logger.WarnContext(ctx, "error validate request", "error", err)
 		return err
 	}
 
 	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
 	if err != nil {
-		log.Printf("%s: failed to find account by account mask with err: %s", logFields, err.Error())
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
 		if errors.Is(err, sql.ErrNoRows) {
 			return utils.ErrDataNotFound
 		}
@@ -146,7 +169,7 @@ func (s *servicePremiumPackageCtx) PremiumPackageCheckout(ctx context.Context, r
 	// get premium package
 	premiumPackage, err := s.premiumPackageRepo.GetPremiumPackageByPackageUID(ctx, req.PackageUID)
 	if err != nil {
-		log.Printf("%s: failed to get premium package by package uid with err: %s", logFields, err.Error())
+		logger.ErrorContext(ctx, "failed to get premium package by package uid", "error", err)
 		if errors.Is(err, sql.ErrNoRows) {
 			return utils.ErrDataNotFound
 		}
@@ -157,10 +180,52 @@ func (s *servicePremiumPackageCtx) PremiumPackageCheckout(ctx context.Context, r
 	// begin transaction
 	tx, err := s.transactionRepo.BeginTrx(ctx)
 	if err != nil {
-		log.Printf("%s: error begin transaction: %v", logFields, err)
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
 		return utils.ErrInternal
 	}
 
+	// consumable packages can be bought again and again, every purchase tops up credits or queues an effect.
+	// They leave the account type alone, only the one time packages make an account PREMIUM.
+	duration, isEffect := s.effectPolicy.EffectDuration(premiumPackage.Title)
+	if premiumPackage.IsCredit() || isEffect {
+		// the account row is locked first, so concurrent purchases can not take the same effect slot
+		if err = s.accountRepo.LockAccount(ctx, tx, account.ID); err != nil {
+			s.transactionRepo.RollbackTrx(ctx, tx)
+			logger.ErrorContext(ctx, "failed to lock account", "error", err)
+			return utils.ErrInternal
+		}
+
+		packageID := sql.NullInt64{Int64: premiumPackage.ID, Valid: true}
+		if premiumPackage.IsCredit() {
+			err = s.creditLedgerRepo.InsertCreditLedger(ctx, tx, &model.CreditLedgerBaseModel{
+				AccountID:        account.ID,
+				CreditType:       premiumPackage.CreditType.String,
+				Amount:           premiumPackage.CreditAmount,
+				Reason:           model.CreditReasonPurchase,
+				PremiumPackageID: packageID,
+			})
+		} else {
+			err = s.accountEffectRepo.InsertAccountEffect(ctx, tx, &model.AccountEffectBaseModel{
+				AccountID:        account.ID,
+				PremiumPackageID: packageID,
+				EffectType:       premiumPackage.Title,
+			}, duration)
+		}
+		if err != nil {
+			s.transactionRepo.RollbackTrx(ctx, tx)
+			logger.ErrorContext(ctx, "failed to insert consumable purchase", "error", err)
+			return utils.ErrInternal
+		}
+
+		if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+			logger.ErrorContext(ctx, "error commit transaction", "error", err)
+			return utils.ErrInternal
+		}
+
+		s.metrics.PackageCheckedOut(premiumPackage.Title)
+		return nil
+	}
+
 	// insert user premium package
 	userPremiumPackage := model.PremiumPackageUserBaseModel{
 		PremiumPackageID: premiumPackage.ID,
@@ -169,35 +234,182 @@ func (s *servicePremiumPackageCtx) PremiumPackageCheckout(ctx context.Context, r
 
 	if err = s.premiumPackageRepo.InsertPremiumPackageUser(ctx, tx, &userPremiumPackage); err != nil {
 		s.transactionRepo.RollbackTrx(ctx, tx)
-		log.Printf("%s: failed to insert user premium package with err: %s", logFields, err.Error())
+		logger.ErrorContext(ctx, "failed to insert user premium package", "error", err)
 		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
-			return errors.New("package already purchased")
+			return utils.ErrPackageAlreadyPurchased
 		}
 
 		return utils.ErrInternal
 	}
 
-	if account.Type == model.AccountTypeFree {
-		account.Type = model.AccountTypePremium
-	}
-
-	if premiumPackage.Title == model.PremiumPackageVerified {
-		account.IsVerified = true
-	}
-
-	_, err = s.accountRepo.UpdateAccountType(ctx, tx, account)
-	if err != nil {
+	// the VERIFIED package only unlocks identity verification, the badge is granted once an admin approves the selfie
+	if err = s.upgradeAccountType(ctx, tx, account); err != nil {
 		s.transactionRepo.RollbackTrx(ctx, tx)
-		log.Printf("%s: failed to upgrade account with err: %s", logFields, err.Error())
+		logger.ErrorContext(ctx, "failed to upgrade account", "error", err)
 		return utils.ErrInternal
 	}
 
 	// commit transaction
 	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
-		log.Printf("%s: error commit transaction: %v", logFields, err)
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
 		return utils.ErrInternal
 	}
 
+	s.metrics.PackageCheckedOut(premiumPackage.Title)
 	return nil
+}
+
+// upgradeAccountType make a FREE account PREMIUM, only the type column is written
+func (s *servicePremiumPackageCtx) upgradeAccountType(ctx context.Context, tx *sql.Tx, account model.AccountBaseModel) error {
+	if account.Type != model.AccountTypeFree {
+		return nil
+	}
+
+	account.Type = model.AccountTypePremium
+	_, err := s.accountRepo.UpdateAccountType(ctx, tx, account)
+	return err
+}
+
+// GetListAccountEffect running and queued effects of the account, soonest first
+func (s *servicePremiumPackageCtx) GetListAccountEffect(ctx context.Context, accountMaskID string) (resp []model.AccountEffectResponse, err error) {
+	var (
+		eventName = "servicePremiumPackageCtx.GetListAccountEffect"
+		logger    = slog.With("event", eventName, "account_mask_id", accountMaskID)
+		now       = time.Now().UTC()
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	effects, err := s.accountEffectRepo.GetListAccountEffectByAccountID(ctx, account.ID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get list account effect", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	resp = make([]model.AccountEffectResponse, len(effects))
+	for i, effect := range effects {
+		resp[i] = model.AccountEffectResponse{
+			EffectType: effect.EffectType,
+			StartsAt:   effect.StartsAt,
+			EndsAt:     effect.EndsAt,
+			IsActive:   effect.IsActiveAt(now),
+		}
+	}
+
+	return resp, nil
+}
+
+// GetWallet credits left of the account, derived from its ledger
+func (s *servicePremiumPackageCtx) GetWallet(ctx context.Context, accountMaskID string) (resp model.WalletResponse, err error) {
+	var (
+		eventName = "servicePremiumPackageCtx.GetWallet"
+		logger    = slog.With("event", eventName, "account_mask_id", accountMaskID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	balances, err := s.creditLedgerRepo.GetListCreditBalanceByAccountID(ctx, account.ID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to get list credit balance", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	for _, balance := range balances {
+		switch balance.CreditType {
+		case model.CreditTypeSuperLike:
+			resp.SuperLike = balance.Balance
+		case model.CreditTypeBoost:
+			resp.Boost = balance.Balance
+		case model.CreditTypeSwipe:
+			resp.Swipe = balance.Balance
+		}
+	}
+
+	return resp, nil
+}
+
+// ActivateBoost spend a boost credit to queue a BOOST effect, both in one transaction
+func (s *servicePremiumPackageCtx) ActivateBoost(ctx context.Context, accountMaskID string) (resp model.AccountEffectResponse, err error) {
+	var (
+		eventName = "servicePremiumPackageCtx.ActivateBoost"
+		logger    = slog.With("event", eventName, "account_mask_id", accountMaskID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	duration, ok := s.effectPolicy.EffectDuration(model.AccountEffectBoost)
+	if !ok {
+		logger.WarnContext(ctx, "boost is not offered")
+		return resp, utils.ErrDataNotFound
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, accountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	// the spend locks the account row, the effect is queued under the same lock
+	if err = s.creditLedgerRepo.SpendCredit(ctx, tx, account.ID, model.CreditTypeBoost, 1); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		if errors.Is(err, sql.ErrNoRows) {
+			logger.InfoContext(ctx, "no boost credit left")
+			return resp, utils.ErrInsufficientCredit
+		}
+		logger.ErrorContext(ctx, "failed to spend boost credit", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	effect := model.AccountEffectBaseModel{
+		AccountID:  account.ID,
+		EffectType: model.AccountEffectBoost,
+	}
+	if err = s.accountEffectRepo.InsertAccountEffect(ctx, tx, &effect, duration); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to insert account effect", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
 
+	s.metrics.CreditSpent(model.CreditTypeBoost)
+	return model.AccountEffectResponse{
+		EffectType: effect.EffectType,
+		StartsAt:   effect.StartsAt,
+		EndsAt:     effect.EndsAt,
+		IsActive:   effect.IsActiveAt(time.Now().UTC()),
+	}, nil
 }
@@ -7,24 +7,37 @@ import (
 	"github.com/asaskevich/govalidator"
 	"github.com/dwiangraeni/dealls/interfaces"
 	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
 	"github.com/dwiangraeni/dealls/utils"
-	"log"
+	"log/slog"
 )
 
 type userSwipeLogCtx struct {
 	userSwipeLogRepo   interfaces.IUserSwipeLogRepo
 	accountRepo        interfaces.IAccountRepo
 	premiumPackageRepo interfaces.IPremiumPackageRepo
+	accountBlockRepo   interfaces.IAccountBlockRepo
+	creditLedgerRepo   interfaces.ICreditLedgerRepo
+	transactionRepo    interfaces.ITransactionRepo
+	metrics            interfaces.IMetrics
 	maxSwipeADay       int
 }
 
 func NewUserSwipeLogService(userSwipeLogRepo interfaces.IUserSwipeLogRepo,
 	accountRepo interfaces.IAccountRepo,
 	premiumPackageRepo interfaces.IPremiumPackageRepo,
+	accountBlockRepo interfaces.IAccountBlockRepo,
+	creditLedgerRepo interfaces.ICreditLedgerRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	metrics interfaces.IMetrics,
 	maxSwipeADay int) interfaces.IUserSwipeLogService {
 	return &userSwipeLogCtx{userSwipeLogRepo: userSwipeLogRepo,
 		accountRepo:        accountRepo,
 		premiumPackageRepo: premiumPackageRepo,
+		accountBlockRepo:   accountBlockRepo,
+		creditLedgerRepo:   creditLedgerRepo,
+		transactionRepo:    transactionRepo,
+		metrics:            metrics,
 		maxSwipeADay:       maxSwipeADay,
 	}
 }
@@ -32,15 +45,16 @@ func NewUserSwipeLogService(userSwipeLogRepo interfaces.IUserSwipeLogRepo,
 func (u *userSwipeLogCtx) ProcessUserSwipe(ctx context.Context, req model.UserSwipeRequest) error {
 	var (
 		eventName = "userSwipeLogCtx.ProcessUserSwipe"
-		logFields = map[string]interface{}{
-			"_event": eventName,
-			"rew":    req,
-		}
+		logger    = slog.With("event", eventName, "req", req)
+		superLike = req.SwipeType == model.SwipeTypeSuperLike
 	)
 
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
 	// validate request
 	if _, err := govalidator.ValidateStruct(req); err != nil {
-		log.Printf("%s: error validate request: %v", logFields, err)
+		logger.WarnContext(ctx, "error validate request", "error", err)
 		return err
 	}
 
@@ -48,60 +62,114 @@ func (u *userSwipeLogCtx) ProcessUserSwipe(ctx context.Context, req model.UserSw
 	// get swipe count by account mask id
 	swipeCount, err := u.userSwipeLogRepo.GetSwipeCountByAccountID(ctx, req.SwiperAccountMaskID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		log.Printf("%s: error get swipe count by account mask id: %v", logFields, err)
+		logger.ErrorContext(ctx, "error get swipe count by account mask id", "error", err)
 		return utils.ErrInternal
 	}
 
 	// get account by account mask id
 	swiperAccount, err := u.accountRepo.FindOneAccountByAccountMaskID(ctx, req.SwiperAccountMaskID)
 	if err != nil {
-		log.Printf("%s: error get account by account mask id: %v", logFields, err)
+		logger.ErrorContext(ctx, "error get account by account mask id", "error", err)
 		return utils.ErrInternal
 	}
 
 	// get premium package user swipe limit
 	premiumPackageUser, err := u.premiumPackageRepo.GetPremiumPackageUserByTitleAndAccountID(ctx, model.PremiumPackageSwipe, swiperAccount.ID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		log.Printf("%s: error get premium package user by account id: %v", logFields, err)
+		logger.ErrorContext(ctx, "error get premium package user by account id", "error", err)
 		return utils.ErrInternal
 	}
 
-	if swipeCount.TotalSwipeADay >= u.maxSwipeADay && premiumPackageUser.ID == 0 {
-		log.Printf("%s: total swipe a day is already reach the limit", logFields)
-		return errors.New("total swipe a day is already reach the limit, upgrade your account to get more swipe")
+	// a super like is paid with its own credit, over the daily quota a free account spends a swipe credit
+	spendCredit := ""
+	switch {
+	case superLike:
+		spendCredit = model.CreditTypeSuperLike
+	case swipeCount.TotalSwipeADay >= u.maxSwipeADay && premiumPackageUser.ID == 0:
+		spendCredit = model.CreditTypeSwipe
 	}
 
 	// get account by account mask id
 	swipeeAccount, err := u.accountRepo.FindOneAccountByAccountMaskID(ctx, req.SwipeeAccountMaskID)
 	if err != nil {
-		log.Printf("%s: error get account by account mask id: %v", logFields, err)
+		logger.ErrorContext(ctx, "error get account by account mask id", "error", err)
+		return utils.ErrInternal
+	}
+
+	// blocked pairs can not interact in either direction
+	blocked, err := u.accountBlockRepo.IsAccountBlocked(ctx, swiperAccount.ID, swipeeAccount.ID)
+	if err != nil {
+		logger.ErrorContext(ctx, "error check account block", "error", err)
 		return utils.ErrInternal
 	}
 
+	if blocked {
+		logger.InfoContext(ctx, "account is blocked")
+		return utils.ErrAccountBlocked
+	}
+
 	// validate swipee user
 	swipeLog, err := u.userSwipeLogRepo.GetUserSwipeLogBySwiperIDAndSwpeeID(ctx, swiperAccount.ID, swipeeAccount.ID)
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		log.Printf("%s: error get user swipe log by swiper id and swipee id: %v", logFields, err)
+		logger.ErrorContext(ctx, "error get user swipe log by swiper id and swipee id", "error", err)
 		return utils.ErrInternal
 	}
 
 	if swipeLog.ID != 0 {
-		log.Printf("%s: user already swipe this user", logFields)
-		return errors.New("user already swipe this user")
+		logger.InfoContext(ctx, "user already swipe this user")
+		return utils.ErrAlreadySwiped
+	}
+
+	tx, err := u.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return utils.ErrInternal
+	}
+
+	if spendCredit != "" {
+		if err = u.creditLedgerRepo.SpendCredit(ctx, tx, swiperAccount.ID, spendCredit, 1); err != nil {
+			u.transactionRepo.RollbackTrx(ctx, tx)
+			switch {
+			case !errors.Is(err, sql.ErrNoRows):
+				logger.ErrorContext(ctx, "error spend credit", "error", err)
+				return utils.ErrInternal
+			case superLike:
+				logger.InfoContext(ctx, "no super like credit left")
+				return utils.ErrInsufficientCredit
+			default:
+				logger.InfoContext(ctx, "total swipe a day is already reach the limit")
+				u.metrics.SwipeQuotaRejected()
+				return utils.ErrSwipeLimitReached
+			}
+		}
 	}
 
-	// insert user swipe log
+	// insert user swipe log, a super like is stored as a LIKE
 	userSwipeLog := model.UserSwipeLogBaseModel{
 		SwiperID:  swiperAccount.ID,
 		SwipeeID:  swipeeAccount.ID,
 		SwipeType: req.SwipeType,
+		SuperLike: superLike,
+	}
+	if superLike {
+		userSwipeLog.SwipeType = model.SwipeTypeLike
 	}
 
-	if _, err = u.userSwipeLogRepo.InsertUserSwipeLog(ctx, userSwipeLog); err != nil {
-		log.Printf("%s: error insert user swipe log: %v", logFields, err)
+	if _, err = u.userSwipeLogRepo.InsertUserSwipeLog(ctx, tx, userSwipeLog); err != nil {
+		u.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "error insert user swipe log", "error", err)
 		return utils.ErrInternal
 	}
 
+	if err = u.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return utils.ErrInternal
+	}
+
+	if spendCredit != "" {
+		u.metrics.CreditSpent(spendCredit)
+	}
+	u.metrics.SwipeRecorded(req.SwipeType)
 	return nil
 
 }
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/asaskevich/govalidator"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/tracing"
+	"github.com/dwiangraeni/dealls/utils"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type serviceVerificationCtx struct {
+	accountRepo     interfaces.IAccountRepo
+	otpRepo         interfaces.IOTPRepo
+	transactionRepo interfaces.ITransactionRepo
+	otpService      interfaces.IOTPService
+	otpTTL          time.Duration
+}
+
+func NewVerificationService(accountRepo interfaces.IAccountRepo,
+	otpRepo interfaces.IOTPRepo,
+	transactionRepo interfaces.ITransactionRepo,
+	otpService interfaces.IOTPService,
+	otpTTL time.Duration) interfaces.IVerificationService {
+	return &serviceVerificationCtx{
+		accountRepo:     accountRepo,
+		otpRepo:         otpRepo,
+		transactionRepo: transactionRepo,
+		otpService:      otpService,
+		otpTTL:          otpTTL,
+	}
+}
+
+// UpdateContact change email/phone, a changed contact loses its verification and pending codes
+func (s *serviceVerificationCtx) UpdateContact(ctx context.Context, req model.UpdateContactRequest) (resp model.ContactResponse, err error) {
+	var (
+		eventName = "serviceVerificationCtx.UpdateContact"
+		logger    = slog.With("event", eventName, "account_mask_id", req.AccountMaskID)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	var invalidatePurposes []string
+	if email := strings.ToLower(req.Email); email != account.Email.String {
+		account.Email = sql.NullString{String: email, Valid: email != ""}
+		account.EmailVerifiedAt = sql.NullTime{}
+		invalidatePurposes = append(invalidatePurposes, model.OTPPurposeVerifyEmail)
+	}
+
+	if req.Phone != account.Phone.String {
+		account.Phone = sql.NullString{String: req.Phone, Valid: req.Phone != ""}
+		account.PhoneVerifiedAt = sql.NullTime{}
+		invalidatePurposes = append(invalidatePurposes, model.OTPPurposeVerifyPhone)
+	}
+
+	if len(invalidatePurposes) == 0 {
+		return contactResponse(account), nil
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	account.UpdatedBy = sql.NullString{String: account.UserName, Valid: true}
+	if _, err = s.accountRepo.UpdateAccountContact(ctx, tx, account); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to update contact", "error", err)
+		if strings.Contains(err.Error(), "duplicate key value violates unique constraint") {
+			return resp, utils.ErrDuplicateData
+		}
+		return resp, utils.ErrInternal
+	}
+
+	for _, purpose := range invalidatePurposes {
+		if err = s.otpRepo.InvalidateOTP(ctx, tx, account.ID, purpose); err != nil {
+			s.transactionRepo.RollbackTrx(ctx, tx)
+			logger.ErrorContext(ctx, "failed to invalidate otp", "error", err)
+			return resp, utils.ErrInternal
+		}
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	return contactResponse(account), nil
+}
+
+func (s *serviceVerificationCtx) SendVerificationCode(ctx context.Context, req model.SendVerificationRequest) (resp model.ContactResponse, err error) {
+	var (
+		eventName = "serviceVerificationCtx.SendVerificationCode"
+		logger    = slog.With("event", eventName, "req", req)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	recipient, verified, purpose := contactOf(account, req.Channel)
+	if recipient == "" {
+		logger.InfoContext(ctx, "contact is not set", "channel", req.Channel)
+		return resp, utils.ErrContactNotSet.WithDetails(map[string]string{"channel": req.Channel})
+	}
+
+	if verified {
+		logger.InfoContext(ctx, "contact already verified", "channel", req.Channel)
+		return resp, utils.ErrContactAlreadyVerified.WithDetails(map[string]string{"channel": req.Channel})
+	}
+
+	if err = s.otpService.SendOTP(ctx, account.ID, purpose, req.Channel, recipient); err != nil {
+		return resp, err
+	}
+
+	resp = contactResponse(account)
+	resp.VerificationTTL = int(s.otpTTL.Seconds())
+
+	return resp, nil
+}
+
+func (s *serviceVerificationCtx) VerifyContact(ctx context.Context, req model.VerifyContactRequest) (resp model.ContactResponse, err error) {
+	var (
+		eventName = "serviceVerificationCtx.VerifyContact"
+		logger    = slog.With("event", eventName, "account_mask_id", req.AccountMaskID, "channel", req.Channel)
+	)
+
+	ctx, span := tracing.Start(ctx, eventName)
+	defer span.End()
+
+	if _, err = govalidator.ValidateStruct(req); err != nil {
+		logger.WarnContext(ctx, "error validate request", "error", err)
+		return resp, err
+	}
+
+	account, err := s.accountRepo.FindOneAccountByAccountMaskID(ctx, req.AccountMaskID)
+	if err != nil {
+		logger.ErrorContext(ctx, "failed to find account by account mask", "error", err)
+		if errors.Is(err, sql.ErrNoRows) {
+			return resp, utils.ErrDataNotFound
+		}
+		return resp, utils.ErrInternal
+	}
+
+	recipient, _, purpose := contactOf(account, req.Channel)
+	otp, err := s.otpService.VerifyOTP(ctx, account.ID, purpose, req.Code)
+	if err != nil {
+		return resp, err
+	}
+
+	// the code was sent to an address that is not on the account anymore
+	if otp.Recipient != recipient {
+		logger.InfoContext(ctx, "otp recipient does not match current contact")
+		return resp, utils.ErrInvalidOTP
+	}
+
+	tx, err := s.transactionRepo.BeginTrx(ctx)
+	if err != nil {
+		logger.ErrorContext(ctx, "error begin transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if err = s.otpService.ConsumeOTP(ctx, tx, otp); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		return resp, err
+	}
+
+	verifiedAt := sql.NullTime{Time: time.Now().UTC(), Valid: true}
+	if req.Channel == model.ContactChannelEmail {
+		account.EmailVerifiedAt = verifiedAt
+	} else {
+		account.PhoneVerifiedAt = verifiedAt
+	}
+
+	account.UpdatedBy = sql.NullString{String: account.UserName, Valid: true}
+	if _, err = s.accountRepo.UpdateAccountContact(ctx, tx, account); err != nil {
+		s.transactionRepo.RollbackTrx(ctx, tx)
+		logger.ErrorContext(ctx, "failed to update contact", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	if err = s.transactionRepo.CommitTrx(ctx, tx); err != nil {
+		logger.ErrorContext(ctx, "error commit transaction", "error", err)
+		return resp, utils.ErrInternal
+	}
+
+	return contactResponse(account), nil
+}
+
+// contactOf recipient, verification state and otp purpose of a contact channel
+func contactOf(account model.AccountBaseModel, channel string) (string, bool, string) {
+	if channel == model.ContactChannelEmail {
+		return account.Email.String, account.EmailVerifiedAt.Valid, model.OTPPurposeVerifyEmail
+	}
+
+	return account.Phone.String, account.PhoneVerifiedAt.Valid, model.OTPPurposeVerifyPhone
+}
+
+func contactResponse(account model.AccountBaseModel) model.ContactResponse {
+	return model.ContactResponse{
+		Email:         account.Email.String,
+		EmailVerified: account.EmailVerifiedAt.Valid,
+		Phone:         account.Phone.String,
+		PhoneVerified: account.PhoneVerifiedAt.Valid,
+	}
+}
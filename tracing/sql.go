@@ -0,0 +1,165 @@
+package tracing
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+)
+
+// NewConnector open connections of d with dsn and trace every query and statement run on them. Queries only become
+// children of the request span when they are run with its context, which every repo does through the *Context methods.
+func NewConnector(d driver.Driver, dsn string) driver.Connector {
+	return &connector{driver: d, dsn: dsn}
+}
+
+type connector struct {
+	driver driver.Driver
+	dsn    string
+}
+
+func (c *connector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tracedConn{Conn: conn}, nil
+}
+
+func (c *connector) Driver() driver.Driver {
+	return c.driver
+}
+
+// tracedConn require the context aware interfaces, database/sql falls back to the legacy ones without a context otherwise
+type tracedConn struct {
+	driver.Conn
+}
+
+type contextConn interface {
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+}
+
+func (c *tracedConn) inner() (contextConn, error) {
+	conn, ok := c.Conn.(contextConn)
+	if !ok {
+		return nil, errors.New("tracing: driver connection does not support contexts")
+	}
+	return conn, nil
+}
+
+func (c *tracedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	conn, err := c.inner()
+	if err != nil {
+		return nil, err
+	}
+	return conn.BeginTx(ctx, opts)
+}
+
+func (c *tracedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	conn, err := c.inner()
+	if err != nil {
+		return nil, err
+	}
+
+	stmt, err := conn.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &tracedStmt{Stmt: stmt, query: query}, nil
+}
+
+func (c *tracedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (result driver.Result, err error) {
+	conn, err := c.inner()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := startQuery(ctx, query)
+	defer func() { endQuery(span, err) }()
+
+	return conn.ExecContext(ctx, query, args)
+}
+
+func (c *tracedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (rows driver.Rows, err error) {
+	conn, err := c.inner()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := startQuery(ctx, query)
+	defer func() { endQuery(span, err) }()
+
+	return conn.QueryContext(ctx, query, args)
+}
+
+func (c *tracedConn) Ping(ctx context.Context) error {
+	conn, err := c.inner()
+	if err != nil {
+		return err
+	}
+	return conn.Ping(ctx)
+}
+
+type tracedStmt struct {
+	driver.Stmt
+	query string
+}
+
+func (s *tracedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (result driver.Result, err error) {
+	stmt, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		return nil, errors.New("tracing: driver statement does not support contexts")
+	}
+
+	ctx, span := startQuery(ctx, s.query)
+	defer func() { endQuery(span, err) }()
+
+	return stmt.ExecContext(ctx, args)
+}
+
+func (s *tracedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (rows driver.Rows, err error) {
+	stmt, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return nil, errors.New("tracing: driver statement does not support contexts")
+	}
+
+	ctx, span := startQuery(ctx, s.query)
+	defer func() { endQuery(span, err) }()
+
+	return stmt.QueryContext(ctx, args)
+}
+
+// startQuery span named after the sql operation, the statement is recorded without its arguments. Queries outside of
+// a traced operation, e.g. the polls of the export worker or migrations, are not recorded.
+func startQuery(ctx context.Context, query string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return ctx, trace.SpanFromContext(ctx)
+	}
+
+	operation := "QUERY"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+
+	return Start(ctx, "db "+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		semconv.DBSystemPostgreSQL,
+		semconv.DBOperationName(operation),
+		semconv.DBQueryText(strings.TrimSpace(query)),
+	))
+}
+
+func endQuery(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, driver.ErrSkip) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,250 @@
+package unittest
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	mock_interfaces "github.com/dwiangraeni/dealls/interfaces/mocks"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/golang/mock/gomock"
+	"io"
+	"testing"
+	"time"
+)
+
+func Test_RequestAccountExport(t *testing.T) {
+	defCtx := context.Background()
+
+	tests := []struct {
+		name    string
+		latest  model.AccountExportBaseModel
+		wantErr error
+	}{
+		{
+			name:   "success request first export",
+			latest: model.AccountExportBaseModel{},
+		},
+		{
+			name:   "success request after previous export is done",
+			latest: model.AccountExportBaseModel{Status: model.AccountExportDone},
+		},
+		{
+			name:    "error export already queued",
+			latest:  model.AccountExportBaseModel{Status: model.AccountExportPending},
+			wantErr: utils.ErrExportInProgress,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock_interfaces.NewMockIAccountRepo(ctrl)
+			mockAccountExportRepo := mock_interfaces.NewMockIAccountExportRepo(ctrl)
+
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(model.AccountBaseModel{ID: 1, AccountMaskID: "mask_id"}, nil)
+			latestErr := error(nil)
+			if tt.latest.Status == "" {
+				latestErr = sql.ErrNoRows
+			}
+			mockAccountExportRepo.EXPECT().GetLatestAccountExportByAccountID(gomock.Any(), int64(1)).Return(tt.latest, latestErr)
+			if tt.wantErr == nil {
+				mockAccountExportRepo.EXPECT().InsertAccountExport(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, export *model.AccountExportBaseModel) error {
+						export.ExportUID = "uid"
+						return nil
+					})
+			}
+
+			s := MockNewAccountExportService(MockAccountExportService{accountRepo: mockAccountRepo, accountExportRepo: mockAccountExportRepo})
+			got, err := s.RequestAccountExport(defCtx, "mask_id")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("RequestAccountExport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (got.ExportUID != "uid" || got.Status != model.AccountExportPending) {
+				t.Errorf("RequestAccountExport() got = %+v, want pending export uid", got)
+			}
+		})
+	}
+}
+
+func Test_ProcessAccountExport(t *testing.T) {
+	defCtx := context.Background()
+	job := model.AccountExportBaseModel{ID: 1, ExportUID: "uid", AccountID: 1, Status: model.AccountExportProcessing, AccountMaskID: "mask_id"}
+	account := model.AccountBaseModel{ID: 1, AccountMaskID: "mask_id", Name: "name", UserName: "username", Password: "hash"}
+
+	tests := []struct {
+		name       string
+		swipesErr  error
+		wantStatus string
+	}{
+		{
+			name:       "success build archive",
+			wantStatus: model.AccountExportDone,
+		},
+		{
+			name:       "error read swipes marks job failed",
+			swipesErr:  errors.New("db error"),
+			wantStatus: model.AccountExportFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock_interfaces.NewMockIAccountRepo(ctrl)
+			mockUserSwipeLogRepo := mock_interfaces.NewMockIUserSwipeLogRepo(ctrl)
+			mockPremiumPackageRepo := mock_interfaces.NewMockIPremiumPackageRepo(ctrl)
+			mockAccountExportRepo := mock_interfaces.NewMockIAccountExportRepo(ctrl)
+			mockAccountProfileRepo := mock_interfaces.NewMockIAccountProfileRepo(ctrl)
+			mockStorage := mock_interfaces.NewMockIFileStorage(ctrl)
+
+			mockAccountExportRepo.EXPECT().ClaimAccountExport(gomock.Any(), gomock.Any()).Return(job, nil)
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(account, nil)
+			mockAccountProfileRepo.EXPECT().GetListAccountPhotoByAccountID(gomock.Any(), int64(1)).
+				Return([]model.AccountPhotoBaseModel{{URL: "https://cdn.example.com/1.jpg"}}, nil)
+			mockAccountProfileRepo.EXPECT().GetListAccountInterestByAccountID(gomock.Any(), int64(1)).Return([]string{"hiking"}, nil)
+			mockUserSwipeLogRepo.EXPECT().GetListUserSwipeLogBySwiperID(gomock.Any(), int64(1)).
+				Return([]model.AccountExportSwipe{{SwipeeAccountMaskID: "mask_id1", SwipeType: "LIKE"}}, tt.swipesErr)
+
+			var archive bytes.Buffer
+			if tt.swipesErr == nil {
+				mockUserSwipeLogRepo.EXPECT().GetListMatchByAccountID(gomock.Any(), int64(1)).Return(nil, nil)
+				mockPremiumPackageRepo.EXPECT().GetListPurchaseByAccountID(gomock.Any(), int64(1)).Return(nil, nil)
+				mockStorage.EXPECT().Save(gomock.Any(), "export/mask_id/uid.zip", gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ string, content io.Reader) error {
+						_, err := io.Copy(&archive, content)
+						return err
+					})
+			}
+
+			mockAccountExportRepo.EXPECT().CompleteAccountExport(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, export *model.AccountExportBaseModel) error {
+					if export.Status != tt.wantStatus || !export.CompletedAt.Valid {
+						t.Errorf("CompleteAccountExport() export = %+v, want status %v", export, tt.wantStatus)
+					}
+					return nil
+				})
+
+			s := MockNewAccountExportService(MockAccountExportService{
+				accountRepo:        mockAccountRepo,
+				userSwipeLogRepo:   mockUserSwipeLogRepo,
+				premiumPackageRepo: mockPremiumPackageRepo,
+				accountProfileRepo: mockAccountProfileRepo,
+				accountExportRepo:  mockAccountExportRepo,
+				storage:            mockStorage,
+				policy:             model.AccountExportPolicy{ProcessingTimeout: time.Minute},
+			})
+
+			processed, err := s.ProcessAccountExport(defCtx)
+			if err != nil || !processed {
+				t.Fatalf("ProcessAccountExport() = %v, %v, want processed", processed, err)
+			}
+			if tt.wantStatus != model.AccountExportDone {
+				return
+			}
+
+			reader, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+			if err != nil {
+				t.Fatalf("zip.NewReader() error = %v", err)
+			}
+
+			files := map[string][]byte{}
+			for _, file := range reader.File {
+				rc, err := file.Open()
+				if err != nil {
+					t.Fatalf("Open(%s) error = %v", file.Name, err)
+				}
+				files[file.Name], _ = io.ReadAll(rc)
+				rc.Close()
+			}
+
+			for _, name := range []string{"profile.json", "swipes.json", "matches.json", "purchases.json"} {
+				if _, ok := files[name]; !ok {
+					t.Errorf("archive is missing %s", name)
+				}
+			}
+
+			if bytes.Contains(files["profile.json"], []byte("hash")) {
+				t.Errorf("profile.json must not contain the password hash")
+			}
+
+			var profile model.AccountExportProfile
+			if err = json.Unmarshal(files["profile.json"], &profile); err != nil || len(profile.Photos) != 1 ||
+				len(profile.Interests) != 1 || profile.Interests[0] != "hiking" {
+				t.Errorf("profile.json = %s, want the photo and interest", files["profile.json"])
+			}
+
+			var matches []model.AccountExportMatch
+			if err = json.Unmarshal(files["matches.json"], &matches); err != nil || matches == nil {
+				t.Errorf("matches.json = %s, want an empty list", files["matches.json"])
+			}
+		})
+	}
+}
+
+func Test_OpenAccountExport(t *testing.T) {
+	defCtx := context.Background()
+	completedAt := sql.NullTime{Time: time.Now().UTC().Add(-2 * time.Hour), Valid: true}
+
+	tests := []struct {
+		name    string
+		export  model.AccountExportBaseModel
+		wantErr error
+	}{
+		{
+			name:   "success open archive",
+			export: model.AccountExportBaseModel{AccountMaskID: "mask_id", Status: model.AccountExportDone, FilePath: "export/mask_id/uid.zip", CompletedAt: sql.NullTime{Time: time.Now().UTC(), Valid: true}},
+		},
+		{
+			name:    "error archive of another account",
+			export:  model.AccountExportBaseModel{AccountMaskID: "mask_id1", Status: model.AccountExportDone, FilePath: "export/mask_id1/uid.zip", CompletedAt: completedAt},
+			wantErr: utils.ErrDataNotFound,
+		},
+		{
+			name:    "error archive still processing",
+			export:  model.AccountExportBaseModel{AccountMaskID: "mask_id", Status: model.AccountExportProcessing},
+			wantErr: utils.ErrExportNotReady,
+		},
+		{
+			name:    "error archive expired",
+			export:  model.AccountExportBaseModel{AccountMaskID: "mask_id", Status: model.AccountExportDone, FilePath: "export/mask_id/uid.zip", CompletedAt: completedAt},
+			wantErr: utils.ErrExportExpired,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountExportRepo := mock_interfaces.NewMockIAccountExportRepo(ctrl)
+			mockStorage := mock_interfaces.NewMockIFileStorage(ctrl)
+
+			mockAccountExportRepo.EXPECT().GetAccountExportByUID(gomock.Any(), "uid").Return(tt.export, nil)
+			if tt.wantErr == nil {
+				mockStorage.EXPECT().Open(gomock.Any(), tt.export.FilePath).Return(io.NopCloser(bytes.NewReader(nil)), nil)
+			}
+
+			s := MockNewAccountExportService(MockAccountExportService{
+				accountExportRepo: mockAccountExportRepo,
+				storage:           mockStorage,
+				policy:            model.AccountExportPolicy{TTL: time.Hour},
+			})
+
+			archive, err := s.OpenAccountExport(defCtx, "mask_id", "uid")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("OpenAccountExport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if archive != nil {
+				archive.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package unittest
+
+import (
+	"context"
+	"crypto/rsa"
+	"database/sql"
+	"errors"
+	mock_interfaces "github.com/dwiangraeni/dealls/interfaces/mocks"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/request"
+	"github.com/dwiangraeni/dealls/utils"
+	mockUtils "github.com/dwiangraeni/dealls/utils/mocks"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+func Test_DeleteAccount(t *testing.T) {
+	defCtx := context.Background()
+	trx := &sql.Tx{}
+	account := model.AccountBaseModel{ID: 1, AccountMaskID: "mask_id", UserName: "username", Name: "name", Status: model.AccountStatusActive}
+
+	tests := []struct {
+		name          string
+		req           model.DeleteAccountRequest
+		validPassword bool
+		wantErr       error
+	}{
+		{
+			name:          "success delete account",
+			req:           model.DeleteAccountRequest{AccountMaskID: "mask_id", Password: "password"},
+			validPassword: true,
+		},
+		{
+			name:    "error wrong password",
+			req:     model.DeleteAccountRequest{AccountMaskID: "mask_id", Password: "wrong"},
+			wantErr: utils.ErrInvalidPassword,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock_interfaces.NewMockIAccountRepo(ctrl)
+			mockOTPRepo := mock_interfaces.NewMockIOTPRepo(ctrl)
+			mockIdentityVerificationRepo := mock_interfaces.NewMockIIdentityVerificationRepo(ctrl)
+			mockTransactionRepo := mock_interfaces.NewMockITransactionRepo(ctrl)
+			mockAccountExportRepo := mock_interfaces.NewMockIAccountExportRepo(ctrl)
+			mockAccountProfileRepo := mock_interfaces.NewMockIAccountProfileRepo(ctrl)
+			mockSelfieStorage := mock_interfaces.NewMockIFileStorage(ctrl)
+			mockExportStorage := mock_interfaces.NewMockIFileStorage(ctrl)
+			mockPassUtils := mockUtils.NewMockPasswordHasher(ctrl)
+
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(account, nil)
+			mockAccountRepo.EXPECT().FindOneAccountByAccountUserName(gomock.Any(), "username").Return(model.AccountBaseModel{Password: "hash"}, nil)
+			mockPassUtils.EXPECT().CheckPasswordHash(tt.req.Password, "hash").Return(tt.validPassword)
+
+			if tt.wantErr == nil {
+				mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+				mockAccountRepo.EXPECT().AnonymizeAccount(gomock.Any(), trx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *sql.Tx, got model.AccountBaseModel) (model.AccountBaseModel, error) {
+						if got.Name != model.DeletedAccountName || got.UserName != "deleted-mask_id" || !got.DeletedAt.Valid {
+							t.Errorf("AnonymizeAccount() account = %+v, want anonymized", got)
+						}
+						return got, nil
+					})
+				mockOTPRepo.EXPECT().DeleteOTPByAccountID(gomock.Any(), trx, int64(1)).Return(nil)
+				mockAccountProfileRepo.EXPECT().DeleteAccountProfileByAccountID(gomock.Any(), trx, int64(1)).Return(nil)
+				mockIdentityVerificationRepo.EXPECT().ClearSelfieByAccountID(gomock.Any(), trx, int64(1)).Return([]string{"identity/mask_id/selfie.jpg"}, nil)
+				mockAccountExportRepo.EXPECT().DeleteAccountExportByAccountID(gomock.Any(), trx, int64(1)).Return([]string{"export/mask_id/uid.zip"}, nil)
+				mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+				mockSelfieStorage.EXPECT().Delete(gomock.Any(), "identity/mask_id/selfie.jpg").Return(nil)
+				mockExportStorage.EXPECT().Delete(gomock.Any(), "export/mask_id/uid.zip").Return(nil)
+			}
+
+			s := MockNewAccountLifecycleService(MockAccountLifecycleService{
+				accountRepo:              mockAccountRepo,
+				otpRepo:                  mockOTPRepo,
+				identityVerificationRepo: mockIdentityVerificationRepo,
+				accountExportRepo:        mockAccountExportRepo,
+				accountProfileRepo:       mockAccountProfileRepo,
+				transactionRepo:          mockTransactionRepo,
+				selfieStorage:            mockSelfieStorage,
+				exportStorage:            mockExportStorage,
+				utilsPass:                mockPassUtils,
+			})
+
+			if err := s.DeleteAccount(defCtx, tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_DeactivateAccountKeepsWarning(t *testing.T) {
+	defCtx := context.Background()
+	trx := &sql.Tx{}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mock_interfaces.NewMockIAccountRepo(ctrl)
+	mockLoginAttemptRepo := mock_interfaces.NewMockILoginAttemptRepo(ctrl)
+	mockTransactionRepo := mock_interfaces.NewMockITransactionRepo(ctrl)
+	mockPassUtils := mockUtils.NewMockPasswordHasher(ctrl)
+
+	warned := model.AccountBaseModel{ID: 1, AccountMaskID: "mask_id", UserName: "username", Status: model.AccountStatusWarned}
+	var stored model.AccountBaseModel
+
+	mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(warned, nil)
+	mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil).Times(2)
+	mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil).Times(2)
+	mockAccountRepo.EXPECT().UpdateAccountStatus(gomock.Any(), trx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+			stored = account
+			return account, nil
+		}).Times(2)
+
+	lifecycle := MockNewAccountLifecycleService(MockAccountLifecycleService{accountRepo: mockAccountRepo, transactionRepo: mockTransactionRepo})
+	if _, err := lifecycle.DeactivateAccount(defCtx, "mask_id"); err != nil {
+		t.Fatalf("DeactivateAccount() error = %v", err)
+	}
+	if stored.Status != model.AccountStatusDeactivated {
+		t.Fatalf("DeactivateAccount() status = %v, want %v", stored.Status, model.AccountStatusDeactivated)
+	}
+
+	mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), gomock.Any()).Return(model.LoginAttemptBaseModel{}, sql.ErrNoRows)
+	mockAccountRepo.EXPECT().FindOneAccountByAccountUserName(gomock.Any(), "username").Return(stored, nil)
+	mockPassUtils.EXPECT().CheckPasswordHash(gomock.Any(), gomock.Any()).Return(true)
+	mockPassUtils.EXPECT().GenerateToken(gomock.Any(), gomock.Any()).Return("token", nil)
+	mockLoginAttemptRepo.EXPECT().ResetLoginAttempt(gomock.Any(), gomock.Any()).Return(nil)
+
+	auth := MockNewAuthService(MockAuthService{
+		accountRepo:      mockAccountRepo,
+		loginAttemptRepo: mockLoginAttemptRepo,
+		transactionRepo:  mockTransactionRepo,
+		keySet:           middleware.NewStaticKeySet("kid", middleware.SigningKey{KID: "kid", PrivateKey: &rsa.PrivateKey{}}),
+		utilsPass:        mockPassUtils,
+	})
+	if _, err := auth.Login(defCtx, request.LoginRequest{Username: "username", Password: "password"}); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+	if stored.Status != model.AccountStatusWarned || stored.ReactivateStatus.Valid {
+		t.Errorf("Login() reactivated status = %v, want %v", stored.Status, model.AccountStatusWarned)
+	}
+}
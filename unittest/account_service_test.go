@@ -2,11 +2,11 @@ package unittest
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/dwiangraeni/dealls/interfaces"
 	mocks "github.com/dwiangraeni/dealls/interfaces/mocks"
 	"github.com/dwiangraeni/dealls/model"
-	"github.com/dwiangraeni/dealls/service"
 	"github.com/dwiangraeni/dealls/utils"
 	"github.com/golang/mock/gomock"
 	"reflect"
@@ -153,7 +153,7 @@ func Test_GetListAccountNewMatchPagination(t *testing.T) {
 					},
 				},
 				LoadMore:   true,
-				NextCursor: "qDoKxg65k1",
+				NextCursor: testCursorCodec().Encode(2, utils.DirectionNext, "account|"),
 				PrevCursor: "",
 				Limit:      2,
 				Keywords:   "",
@@ -168,7 +168,7 @@ func Test_GetListAccountNewMatchPagination(t *testing.T) {
 				ctx: defCtx,
 				req: model.PaginationRequest{
 					Limit:  2,
-					Cursor: "qDoKxg65k1",
+					Cursor: testCursorCodec().Encode(2, utils.DirectionNext, "account|"),
 				},
 			},
 			mockScenario: mockScenario{
@@ -256,7 +256,7 @@ func Test_GetListAccountNewMatchPagination(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAccountRepo := mocks.NewMockIAccountRepo(mockCtr)
-			s := service.NewAccountService(mockAccountRepo)
+			s := MockNewAccountService(MockAccountService{accountRepo: mockAccountRepo})
 
 			if tt.mockScenario.isMockEnable.isMockAccountRepo {
 				mockAccountRepo.EXPECT().GetListAccountNewMatchPagination(gomock.Any(), gomock.Any()).Return(tt.mockScenario.getListAccountNewMatchPaginationResp.resp, tt.mockScenario.getListAccountNewMatchPaginationResp.err)
@@ -280,3 +280,389 @@ func Test_GetListAccountNewMatchPagination(t *testing.T) {
 	}
 
 }
+
+func Test_GetListAccountNewMatchPagination_Boost(t *testing.T) {
+	defCtx := context.Background()
+	cursor := testCursorCodec()
+	policy := model.AccountEffectPolicy{BoostRadiusKM: 50, BoostSlots: 3}
+	boosted := []model.AccountBaseModel{{ID: 9, AccountMaskID: "boosted_mask_id", Type: "PREMIUM", Name: "boosted", UserName: "boosted"}}
+	accounts := []model.AccountBaseModel{
+		{ID: 3, AccountMaskID: "mask_id3", Type: "FREE", Name: "test3", UserName: "test3"},
+		{ID: 2, AccountMaskID: "mask_id2", Type: "FREE", Name: "test2", UserName: "test2"},
+	}
+	// four boosted accounts nearby, the repo returns the three that fit the slots and the fourth stays in the pages
+	slotted := []model.AccountBaseModel{
+		{ID: 12, AccountMaskID: "boosted_mask_id12", Type: "PREMIUM", Name: "boosted12", UserName: "boosted12"},
+		{ID: 11, AccountMaskID: "boosted_mask_id11", Type: "PREMIUM", Name: "boosted11", UserName: "boosted11"},
+		{ID: 10, AccountMaskID: "boosted_mask_id10", Type: "PREMIUM", Name: "boosted10", UserName: "boosted10"},
+	}
+	overflow := model.AccountBaseModel{ID: 4, AccountMaskID: "boosted_mask_id4", Type: "PREMIUM", Name: "boosted4", UserName: "boosted4"}
+
+	tests := []struct {
+		name         string
+		req          model.PaginationRequest
+		boosted      []model.AccountBaseModel
+		accounts     []model.AccountBaseModel
+		wantExcluded []int64
+		want         model.ListAccountPagination
+	}{
+		{
+			name:         "success boosted accounts on top of the first page",
+			req:          model.PaginationRequest{Limit: 1, AccountMaskID: "mask_id"},
+			boosted:      boosted,
+			accounts:     accounts,
+			wantExcluded: []int64{9},
+			want: model.ListAccountPagination{
+				Data: []model.AccountResponse{
+					{AccountMaskID: "boosted_mask_id", Type: "PREMIUM", Name: "boosted", UserName: "boosted", IsBoosted: true},
+					{AccountMaskID: "mask_id3", Type: "FREE", Name: "test3", UserName: "test3"},
+				},
+				LoadMore:   true,
+				NextCursor: cursor.Encode(3, utils.DirectionNext, "account|mask_id"),
+				Limit:      1,
+			},
+		},
+		{
+			name:         "success only boosted accounts",
+			req:          model.PaginationRequest{Limit: 1, AccountMaskID: "mask_id"},
+			boosted:      boosted,
+			wantExcluded: []int64{9},
+			want: model.ListAccountPagination{
+				Data:  []model.AccountResponse{{AccountMaskID: "boosted_mask_id", Type: "PREMIUM", Name: "boosted", UserName: "boosted", IsBoosted: true}},
+				Limit: 1,
+			},
+		},
+		{
+			name:         "success next page leaves out the boosted accounts of the first page",
+			req:          model.PaginationRequest{Limit: 1, AccountMaskID: "mask_id", Cursor: cursor.Encode(3, utils.DirectionNext, "account|mask_id")},
+			boosted:      boosted,
+			accounts:     accounts[1:],
+			wantExcluded: []int64{9},
+			want: model.ListAccountPagination{
+				Data:       []model.AccountResponse{{AccountMaskID: "mask_id2", Type: "FREE", Name: "test2", UserName: "test2"}},
+				PrevCursor: cursor.Encode(2, utils.DirectionPrev, "account|mask_id"),
+				Limit:      1,
+			},
+		},
+		{
+			name:         "success boosted accounts beyond the slots stay in the pages",
+			req:          model.PaginationRequest{Limit: 2, AccountMaskID: "mask_id"},
+			boosted:      slotted,
+			accounts:     []model.AccountBaseModel{overflow, accounts[0]},
+			wantExcluded: []int64{12, 11, 10},
+			want: model.ListAccountPagination{
+				Data: []model.AccountResponse{
+					{AccountMaskID: "boosted_mask_id12", Type: "PREMIUM", Name: "boosted12", UserName: "boosted12", IsBoosted: true},
+					{AccountMaskID: "boosted_mask_id11", Type: "PREMIUM", Name: "boosted11", UserName: "boosted11", IsBoosted: true},
+					{AccountMaskID: "boosted_mask_id10", Type: "PREMIUM", Name: "boosted10", UserName: "boosted10", IsBoosted: true},
+					{AccountMaskID: "boosted_mask_id4", Type: "PREMIUM", Name: "boosted4", UserName: "boosted4"},
+					{AccountMaskID: "mask_id3", Type: "FREE", Name: "test3", UserName: "test3"},
+				},
+				Limit: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+			mockAccountRepo.EXPECT().GetListAccountBoosted(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, req model.PaginationRequest) ([]model.AccountBaseModel, error) {
+					if req.Limit != 3 || req.BoostRadiusKM != 50 {
+						t.Errorf("GetListAccountBoosted() req = %+v, want 3 slots within 50 km", req)
+					}
+					return tt.boosted, nil
+				})
+			mockAccountRepo.EXPECT().GetListAccountNewMatchPagination(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, req model.PaginationRequest) ([]model.AccountBaseModel, error) {
+					// the pages only leave out the boosted accounts put on top
+					if !reflect.DeepEqual(req.ExcludeIDs, tt.wantExcluded) {
+						t.Errorf("GetListAccountNewMatchPagination() excluded = %v, want %v", req.ExcludeIDs, tt.wantExcluded)
+					}
+					return tt.accounts, nil
+				})
+
+			s := MockNewAccountService(MockAccountService{accountRepo: mockAccountRepo, effectPolicy: policy})
+			got, err := s.GetListAccountNewMatchPagination(defCtx, tt.req)
+			if err != nil {
+				t.Fatalf("GetListAccountNewMatchPagination() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetListAccountNewMatchPagination() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetAccountProfile(t *testing.T) {
+	defCtx := context.Background()
+	birthDate := sql.NullTime{Time: time.Now().UTC().AddDate(-25, 0, -1), Valid: true}
+	viewer := model.AccountBaseModel{ID: 1, AccountMaskID: "mask_id", Status: model.AccountStatusActive,
+		Latitude: sql.NullFloat64{Float64: -6.2, Valid: true}, Longitude: sql.NullFloat64{Float64: 106.8, Valid: true}}
+	target := model.AccountBaseModel{ID: 2, AccountMaskID: "mask_id1", Name: "test", UserName: "test", Status: model.AccountStatusActive,
+		Bio: sql.NullString{String: "hi", Valid: true}, BirthDate: birthDate,
+		Latitude: sql.NullFloat64{Float64: -6.2, Valid: true}, Longitude: sql.NullFloat64{Float64: 106.9, Valid: true}}
+	photos := []model.AccountPhotoBaseModel{{AccountID: 2, URL: "https://cdn.example.com/1.jpg"}}
+
+	age, distance := 25, 12
+	hiddenAge := target
+	hiddenAge.HideAge = true
+	hidden := target
+	hidden.IsHidden = true
+
+	tests := []struct {
+		name       string
+		req        model.GetAccountProfileRequest
+		target     model.AccountBaseModel
+		blocked    bool
+		incognito  string // the account with a running INCOGNITO effect: viewer or target
+		wantViewed bool
+		want       model.AccountProfileResponse
+		wantErr    error
+	}{
+		{
+			name:       "success view profile",
+			req:        model.GetAccountProfileRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			target:     target,
+			wantViewed: true,
+			want: model.AccountProfileResponse{AccountMaskID: "mask_id1", Name: "test", UserName: "test", Bio: "hi", Age: &age,
+				DistanceKM: &distance, Photos: []model.AccountPhotoResponse{{URL: "https://cdn.example.com/1.jpg"}}, Interests: []string{"coffee"}},
+		},
+		{
+			name:       "success view profile with hidden age",
+			req:        model.GetAccountProfileRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			target:     hiddenAge,
+			wantViewed: true,
+			want: model.AccountProfileResponse{AccountMaskID: "mask_id1", Name: "test", UserName: "test", Bio: "hi",
+				DistanceKM: &distance, Photos: []model.AccountPhotoResponse{{URL: "https://cdn.example.com/1.jpg"}}, Interests: []string{"coffee"}},
+		},
+		{
+			name:   "success view profile with hidden visits is not recorded",
+			req:    model.GetAccountProfileRequest{AccountMaskID: "hidden_visits_mask_id", TargetAccountMaskID: "mask_id1"},
+			target: target,
+			want: model.AccountProfileResponse{AccountMaskID: "mask_id1", Name: "test", UserName: "test", Bio: "hi", Age: &age,
+				DistanceKM: &distance, Photos: []model.AccountPhotoResponse{{URL: "https://cdn.example.com/1.jpg"}}, Interests: []string{"coffee"}},
+		},
+		{
+			name:      "success view profile as incognito viewer is not recorded",
+			req:       model.GetAccountProfileRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			target:    target,
+			incognito: "viewer",
+			want: model.AccountProfileResponse{AccountMaskID: "mask_id1", Name: "test", UserName: "test", Bio: "hi", Age: &age,
+				DistanceKM: &distance, Photos: []model.AccountPhotoResponse{{URL: "https://cdn.example.com/1.jpg"}}, Interests: []string{"coffee"}},
+		},
+		{
+			name:      "error incognito account",
+			req:       model.GetAccountProfileRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			target:    target,
+			incognito: "target",
+			wantErr:   utils.ErrAccountBlocked,
+		},
+		{
+			name:    "error blocked account",
+			req:     model.GetAccountProfileRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			target:  target,
+			blocked: true,
+			wantErr: utils.ErrAccountBlocked,
+		},
+		{
+			name:    "error hidden account",
+			req:     model.GetAccountProfileRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			target:  hidden,
+			wantErr: utils.ErrAccountBlocked,
+		},
+		{
+			name:    "error account not found",
+			req:     model.GetAccountProfileRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			wantErr: utils.ErrDataNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+			mockAccountBlockRepo := mocks.NewMockIAccountBlockRepo(ctrl)
+			mockAccountProfileRepo := mocks.NewMockIAccountProfileRepo(ctrl)
+
+			hiddenVisits := viewer
+			hiddenVisits.HideVisits = true
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(viewer, nil).AnyTimes()
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "hidden_visits_mask_id").Return(hiddenVisits, nil).AnyTimes()
+			if tt.target.ID == 0 {
+				mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id1").Return(model.AccountBaseModel{}, sql.ErrNoRows)
+			} else {
+				mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id1").Return(tt.target, nil)
+			}
+			mockAccountBlockRepo.EXPECT().IsAccountBlocked(gomock.Any(), int64(1), int64(2)).Return(tt.blocked, nil).AnyTimes()
+			mockAccountRepo.EXPECT().IsAccountIncognitoFor(gomock.Any(), int64(2), int64(1)).Return(tt.incognito == "target", nil).AnyTimes()
+			mockAccountRepo.EXPECT().IsAccountIncognitoFor(gomock.Any(), int64(1), int64(2)).Return(tt.incognito == "viewer", nil).AnyTimes()
+			mockAccountProfileRepo.EXPECT().GetListAccountPhotoByAccountID(gomock.Any(), int64(2)).Return(photos, nil).AnyTimes()
+			mockAccountProfileRepo.EXPECT().GetListAccountInterestByAccountID(gomock.Any(), int64(2)).Return([]string{"coffee"}, nil).AnyTimes()
+			if tt.wantViewed {
+				mockAccountProfileRepo.EXPECT().InsertProfileView(gomock.Any(), &model.ProfileViewBaseModel{ViewerID: 1, ViewedID: 2}).Return(nil)
+			}
+
+			s := MockNewAccountService(MockAccountService{accountRepo: mockAccountRepo, accountBlockRepo: mockAccountBlockRepo,
+				accountProfileRepo: mockAccountProfileRepo})
+			got, err := s.GetAccountProfile(defCtx, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetAccountProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetAccountProfile() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetAccountProfile_Self(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the owner sees its own hidden age, there is no distance to itself and the view is not recorded
+	account := model.AccountBaseModel{ID: 1, AccountMaskID: "mask_id", HideAge: true, IsHidden: true,
+		BirthDate: sql.NullTime{Time: time.Now().UTC().AddDate(-30, 0, -1), Valid: true},
+		Latitude:  sql.NullFloat64{Float64: -6.2, Valid: true}, Longitude: sql.NullFloat64{Float64: 106.8, Valid: true}}
+
+	mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+	mockAccountProfileRepo := mocks.NewMockIAccountProfileRepo(ctrl)
+	mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(account, nil)
+	mockAccountProfileRepo.EXPECT().GetListAccountPhotoByAccountID(gomock.Any(), int64(1)).Return(nil, nil)
+	mockAccountProfileRepo.EXPECT().GetListAccountInterestByAccountID(gomock.Any(), int64(1)).Return(nil, nil)
+
+	s := MockNewAccountService(MockAccountService{accountRepo: mockAccountRepo, accountProfileRepo: mockAccountProfileRepo})
+	got, err := s.GetAccountProfile(context.Background(), model.GetAccountProfileRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id"})
+	if err != nil {
+		t.Fatalf("GetAccountProfile() error = %v", err)
+	}
+	if got.Age == nil || *got.Age != 30 || got.DistanceKM != nil {
+		t.Errorf("GetAccountProfile() got = %+v, want age 30 and no distance", got)
+	}
+	if got.Photos == nil || got.Interests == nil {
+		t.Errorf("GetAccountProfile() photos and interests must be empty lists, got = %+v", got)
+	}
+}
+
+func Test_UpdateProfilePrivacy(t *testing.T) {
+	yes, no := true, false
+	stored := model.AccountBaseModel{ID: 1, AccountMaskID: "mask_id", UserName: "test", HideDistance: true, HideVisits: true}
+
+	tests := []struct {
+		name string
+		req  model.UpdateProfilePrivacyRequest
+		want model.ProfilePrivacyResponse
+	}{
+		{
+			name: "success update only the given field",
+			req:  model.UpdateProfilePrivacyRequest{AccountMaskID: "mask_id", HideAge: &yes},
+			want: model.ProfilePrivacyResponse{AccountMaskID: "mask_id", HideAge: true, HideDistance: true, HideVisits: true},
+		},
+		{
+			name: "success turn settings off",
+			req:  model.UpdateProfilePrivacyRequest{AccountMaskID: "mask_id", HideDistance: &no, HideVisits: &no},
+			want: model.ProfilePrivacyResponse{AccountMaskID: "mask_id"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(stored, nil)
+			mockAccountRepo.EXPECT().UpdateAccountPrivacy(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+					return account, nil
+				})
+
+			s := MockNewAccountService(MockAccountService{accountRepo: mockAccountRepo})
+			got, err := s.UpdateProfilePrivacy(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("UpdateProfilePrivacy() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("UpdateProfilePrivacy() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_GetListProfileVisitorPagination(t *testing.T) {
+	defCtx := context.Background()
+	viewedAt := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	visitors := []model.ProfileVisitorBaseModel{
+		{ID: 30, ViewedAt: viewedAt, AccountMaskID: "mask_id3", Type: "FREE", Name: "test3", UserName: "test3"},
+		{ID: 20, ViewedAt: viewedAt, AccountMaskID: "mask_id2", Type: "PREMIUM", Name: "test2", UserName: "test2", IsVerified: true},
+		{ID: 10, ViewedAt: viewedAt, AccountMaskID: "mask_id1", Type: "FREE", Name: "test1", UserName: "test1"},
+	}
+
+	tests := []struct {
+		name      string
+		purchased bool
+		visitors  []model.ProfileVisitorBaseModel
+		want      model.ListProfileVisitorPagination
+		wantErr   error
+	}{
+		{
+			name:      "success list visitors with load more",
+			purchased: true,
+			visitors:  visitors,
+			want: model.ListProfileVisitorPagination{
+				Unlocked: true,
+				Total:    3,
+				Data: []model.ProfileVisitorResponse{
+					{AccountMaskID: "mask_id3", Type: "FREE", Name: "test3", UserName: "test3", ViewedAt: viewedAt},
+					{AccountMaskID: "mask_id2", Type: "PREMIUM", Name: "test2", UserName: "test2", IsVerified: true, ViewedAt: viewedAt},
+				},
+				LoadMore:   true,
+				NextCursor: testCursorCodec().Encode(20, utils.DirectionNext, "profile_visitor|mask_id"),
+				Limit:      2,
+			},
+		},
+		{
+			name:      "success list visitors with no data",
+			purchased: true,
+			want:      model.ListProfileVisitorPagination{Unlocked: true, Total: 3, Data: []model.ProfileVisitorResponse{}, Limit: 2},
+		},
+		{
+			name: "success count only without visitors package",
+			want: model.ListProfileVisitorPagination{Total: 3, Data: []model.ProfileVisitorResponse{}, Limit: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+			mockAccountProfileRepo := mocks.NewMockIAccountProfileRepo(ctrl)
+			mockPremiumPackageRepo := mocks.NewMockIPremiumPackageRepo(ctrl)
+
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(model.AccountBaseModel{ID: 1}, nil)
+			mockAccountProfileRepo.EXPECT().CountProfileVisitor(gomock.Any(), int64(1)).Return(int64(3), nil)
+			if !tt.purchased {
+				mockPremiumPackageRepo.EXPECT().GetPremiumPackageUserByTitleAndAccountID(gomock.Any(), model.PremiumPackageVisitors, int64(1)).
+					Return(model.PremiumPackageUserBaseModel{}, sql.ErrNoRows)
+			} else {
+				mockPremiumPackageRepo.EXPECT().GetPremiumPackageUserByTitleAndAccountID(gomock.Any(), model.PremiumPackageVisitors, int64(1)).
+					Return(model.PremiumPackageUserBaseModel{ID: 1}, nil)
+				mockAccountProfileRepo.EXPECT().GetListProfileVisitorPagination(gomock.Any(),
+					model.ListProfileVisitorRequest{AccountMaskID: "mask_id", Limit: 3, ViewedID: 1}).Return(tt.visitors, nil)
+			}
+
+			s := MockNewAccountService(MockAccountService{accountRepo: mockAccountRepo, accountProfileRepo: mockAccountProfileRepo,
+				premiumPackageRepo: mockPremiumPackageRepo})
+			got, err := s.GetListProfileVisitorPagination(defCtx, model.ListProfileVisitorRequest{AccountMaskID: "mask_id", Limit: 2})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetListProfileVisitorPagination() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetListProfileVisitorPagination() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
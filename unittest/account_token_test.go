@@ -0,0 +1,286 @@
+package unittest
+
+import (
+	"context"
+	"errors"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/utils"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_VerifyAccessToken(t *testing.T) {
+	defCtx := context.Background()
+	dir := t.TempDir()
+	hasher := utils.NewBcryptPasswordHasher()
+	account := model.AccountBaseModel{AccountMaskID: "mask_id", UserName: "username"}
+
+	oldKey, err := middleware.GenerateKeyFile(dir, "20240101T000000Z", 1024)
+	if err != nil {
+		t.Fatalf("GenerateKeyFile() error = %v", err)
+	}
+
+	newKey, err := middleware.GenerateKeyFile(dir, "20240201T000000Z", 1024)
+	if err != nil {
+		t.Fatalf("GenerateKeyFile() error = %v", err)
+	}
+
+	keySet, err := middleware.NewFileKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("NewFileKeySet() error = %v", err)
+	}
+
+	strangerKey, err := middleware.GenerateKeyFile(t.TempDir(), "20240301T000000Z", 1024)
+	if err != nil {
+		t.Fatalf("GenerateKeyFile() error = %v", err)
+	}
+
+	signWith := func(key middleware.SigningKey) string {
+		token, err := hasher.GenerateToken(account, key)
+		if err != nil {
+			t.Fatalf("GenerateToken() error = %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "success verify token signed with active key",
+			token: signWith(newKey),
+		},
+		{
+			name:  "success verify token signed with rotated out key",
+			token: signWith(oldKey),
+		},
+		{
+			name:    "error unknown kid",
+			token:   signWith(strangerKey),
+			wantErr: true,
+		},
+		{
+			name:    "error kid of other key",
+			token:   signWith(middleware.SigningKey{KID: oldKey.KID, PrivateKey: strangerKey.PrivateKey}),
+			wantErr: true,
+		},
+		{
+			name:    "error malformed token",
+			token:   "token",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := middleware.NewAccountToken(keySet).VerifyAccessToken(defCtx, tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyAccessToken() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.AccountMaskID != account.AccountMaskID {
+				t.Errorf("VerifyAccessToken() got = %v, want %v", got.AccountMaskID, account.AccountMaskID)
+			}
+		})
+	}
+}
+
+func Test_FileKeySet(t *testing.T) {
+	dir := t.TempDir()
+	for _, kid := range []string{"20240101T000000Z", "20240201T000000Z", "20240301T000000Z"} {
+		if _, err := middleware.GenerateKeyFile(dir, kid, 1024); err != nil {
+			t.Fatalf("GenerateKeyFile() error = %v", err)
+		}
+	}
+
+	keySet, err := middleware.NewFileKeySet(dir, "")
+	if err != nil {
+		t.Fatalf("NewFileKeySet() error = %v", err)
+	}
+
+	key, err := keySet.SigningKey()
+	if err != nil || key.KID != "20240301T000000Z" {
+		t.Errorf("SigningKey() got = %v, err %v, want newest key", key.KID, err)
+	}
+
+	if got := len(keySet.JWKS().Keys); got != 3 {
+		t.Errorf("JWKS() got = %d keys, want 3", got)
+	}
+
+	removed, err := middleware.PruneKeyFiles(dir, middleware.KeyPrunePolicy{Keep: 2})
+	if err != nil || len(removed) != 1 || removed[0] != "20240101T000000Z" {
+		t.Errorf("PruneKeyFiles() got = %v, err %v", removed, err)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "20240101T000000Z.pem")); !os.IsNotExist(err) {
+		t.Errorf("PruneKeyFiles() key file still exists, err %v", err)
+	}
+
+	if _, err = middleware.NewFileKeySet(dir, "unknown"); err == nil {
+		t.Errorf("NewFileKeySet() with unknown active kid want error")
+	}
+}
+
+func Test_PruneKeyFiles(t *testing.T) {
+	kids := []string{"20240101T000000Z", "20240201T000000Z", "20240301T000000Z", "20240401T000000Z"}
+
+	tests := []struct {
+		name        string
+		policy      middleware.KeyPrunePolicy
+		ages        []time.Duration // age of the key files, in kids order
+		wantRemoved []string
+	}{
+		{
+			name:        "success remove keys replaced long ago",
+			policy:      middleware.KeyPrunePolicy{Keep: 1, MinAge: 25 * time.Hour},
+			ages:        []time.Duration{96 * time.Hour, 72 * time.Hour, 48 * time.Hour, time.Hour},
+			wantRemoved: []string{kids[0], kids[1]},
+		},
+		{
+			name:        "success never remove the configured active key",
+			policy:      middleware.KeyPrunePolicy{Keep: 1, ActiveKID: kids[0], MinAge: time.Hour},
+			ages:        []time.Duration{96 * time.Hour, 72 * time.Hour, 48 * time.Hour, 2 * time.Hour},
+			wantRemoved: []string{kids[1], kids[2]},
+		},
+		{
+			name:   "success keep every key replaced within the min age",
+			policy: middleware.KeyPrunePolicy{Keep: 1, MinAge: 25 * time.Hour},
+			ages:   []time.Duration{3 * time.Hour, 2 * time.Hour, time.Hour, time.Minute},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for i, kid := range kids {
+				if _, err := middleware.GenerateKeyFile(dir, kid, 1024); err != nil {
+					t.Fatalf("GenerateKeyFile() error = %v", err)
+				}
+				modTime := time.Now().Add(-tt.ages[i])
+				if err := os.Chtimes(filepath.Join(dir, kid+".pem"), modTime, modTime); err != nil {
+					t.Fatalf("Chtimes() error = %v", err)
+				}
+			}
+
+			removed, err := middleware.PruneKeyFiles(dir, tt.policy)
+			if err != nil {
+				t.Fatalf("PruneKeyFiles() error = %v", err)
+			}
+			if !reflect.DeepEqual(removed, tt.wantRemoved) {
+				t.Errorf("PruneKeyFiles() got = %v, want %v", removed, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+type staticStatusChecker string
+
+func (s staticStatusChecker) GetAccountStatus(ctx context.Context, accountMaskID string) (string, error) {
+	return string(s), nil
+}
+
+func Test_RequireAccountToken_AccountStatus(t *testing.T) {
+	key, err := middleware.GenerateKeyFile(t.TempDir(), "20240101T000000Z", 1024)
+	if err != nil {
+		t.Fatalf("GenerateKeyFile() error = %v", err)
+	}
+
+	token, err := utils.NewBcryptPasswordHasher().GenerateToken(model.AccountBaseModel{AccountMaskID: "mask_id"}, key)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		status string
+		want   int
+	}{
+		{name: "active account", status: model.AccountStatusActive, want: http.StatusOK},
+		{name: "warned account", status: model.AccountStatusWarned, want: http.StatusOK},
+		{name: "suspended account", status: model.AccountStatusSuspended, want: http.StatusForbidden},
+		{name: "banned account", status: model.AccountStatusBanned, want: http.StatusForbidden},
+		{name: "account no longer exists", status: "", want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := middleware.NewTokenValidator(middleware.NewAccountToken(middleware.NewStaticKeySet(key.KID, key)),
+				staticStatusChecker(tt.status))
+			h := validator.RequireAccountToken()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("RequireAccountToken() status code = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+type staticAccountToken struct {
+	claim *middleware.AccessTokenClaim
+	err   error
+}
+
+func (s staticAccountToken) VerifyAccessToken(ctx context.Context, token string) (*middleware.AccessTokenClaim, error) {
+	return s.claim, s.err
+}
+
+func Test_RequireAccountToken_Verification(t *testing.T) {
+	tests := []struct {
+		name  string
+		token staticAccountToken
+		want  int
+	}{
+		{name: "success valid token", token: staticAccountToken{claim: &middleware.AccessTokenClaim{AccountMaskID: "mask_id"}}, want: http.StatusOK},
+		{name: "error invalid token", token: staticAccountToken{err: errors.New("invalid token")}, want: http.StatusUnauthorized},
+		{name: "error other verification failure", token: staticAccountToken{err: errors.New("key set unavailable")}, want: http.StatusUnauthorized},
+		{name: "error no claim without error", token: staticAccountToken{}, want: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := middleware.NewTokenValidator(tt.token, staticStatusChecker(model.AccountStatusActive))
+			h := validator.RequireAccountToken()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				claim, ok := middleware.ClaimFromContext(r.Context())
+				if !ok || claim.AccountMaskID != "mask_id" {
+					t.Errorf("ClaimFromContext() got = %v, %v, want claim of mask_id", claim, ok)
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Authorization", "Bearer token")
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("RequireAccountToken() status code = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ClaimFromContext(t *testing.T) {
+	if claim, ok := middleware.ClaimFromContext(context.Background()); ok {
+		t.Errorf("ClaimFromContext() without claim got = %v, want not ok", claim)
+	}
+
+	if claim, ok := middleware.ClaimFromContext(middleware.WithClaim(context.Background(), nil)); ok {
+		t.Errorf("ClaimFromContext() with nil claim got = %v, want not ok", claim)
+	}
+
+	ctx := context.WithValue(context.Background(), "token", &middleware.AccessTokenClaim{AccountMaskID: "mask_id"})
+	if claim, ok := middleware.ClaimFromContext(ctx); ok {
+		t.Errorf("ClaimFromContext() with string key got = %v, want not ok", claim)
+	}
+}
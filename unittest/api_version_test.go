@@ -0,0 +1,119 @@
+package unittest
+
+import (
+	"encoding/json"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_CompareVersion(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		wantCmp int
+		wantOK  bool
+	}{
+		{a: "1.4.0", b: "1.4", wantCmp: 0, wantOK: true},
+		{a: "1.10.0", b: "1.9.9", wantCmp: 1, wantOK: true},
+		{a: "v2.0.1", b: "2.1", wantCmp: -1, wantOK: true},
+		{a: "2.1.0-beta.1", b: "2.1.0", wantCmp: 0, wantOK: true},
+		{a: "latest", b: "2.1.0", wantOK: false},
+		{a: "", b: "2.1.0", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			cmp, ok := middleware.CompareVersion(tt.a, tt.b)
+			if ok != tt.wantOK || (ok && cmp != tt.wantCmp) {
+				t.Errorf("CompareVersion() = %d, %v, want %d, %v", cmp, ok, tt.wantCmp, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_RequireClientVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		minVersion    string
+		clientVersion string
+		wantStatus    int
+	}{
+		{name: "success version above minimum", minVersion: "2.3.0", clientVersion: "2.10.0", wantStatus: http.StatusOK},
+		{name: "success version equal to minimum", minVersion: "2.3.0", clientVersion: "2.3", wantStatus: http.StatusOK},
+		{name: "success request without header", minVersion: "2.3.0", wantStatus: http.StatusOK},
+		{name: "success check disabled", clientVersion: "0.1.0", wantStatus: http.StatusOK},
+		{name: "error version below minimum", minVersion: "2.3.0", clientVersion: "2.2.9", wantStatus: http.StatusUpgradeRequired},
+		{name: "error version not numeric", minVersion: "2.3.0", clientVersion: "dev", wantStatus: http.StatusUpgradeRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := middleware.RequireClientVersion(tt.minVersion)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/dealls/v1/account/list", nil)
+			if tt.clientVersion != "" {
+				req.Header.Set(middleware.ClientVersionHeader, tt.clientVersion)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("RequireClientVersion() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusUpgradeRequired {
+				return
+			}
+
+			var body response.ResponseWrapper
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if body.Code != response.CodeClientUpgradeRequired || body.Errors["min_version"] != tt.minVersion {
+				t.Errorf("RequireClientVersion() body = %+v, want %s with min_version %s", body, response.CodeClientUpgradeRequired, tt.minVersion)
+			}
+		})
+	}
+}
+
+func Test_Deprecated(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		sunsetAt   time.Time
+		wantStatus int
+	}{
+		{name: "success before sunset", sunsetAt: time.Now().Add(24 * time.Hour), wantStatus: http.StatusOK},
+		{name: "error after sunset", sunsetAt: time.Now().Add(-time.Hour), wantStatus: http.StatusGone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := middleware.Deprecated(middleware.Deprecation{
+				DeprecatedAt: deprecatedAt,
+				SunsetAt:     tt.sunsetAt,
+				Successor:    "/dealls/v1",
+			})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/dealls/auth/login", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Deprecated() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Deprecation"); got != "@1792368000" {
+				t.Errorf("Deprecated() Deprecation = %q, want @1792368000", got)
+			}
+			if got := rec.Header().Get("Sunset"); got != tt.sunsetAt.UTC().Format(http.TimeFormat) {
+				t.Errorf("Deprecated() Sunset = %q, want %q", got, tt.sunsetAt.UTC().Format(http.TimeFormat))
+			}
+			if got := rec.Header().Get("Link"); got != `</dealls/v1>; rel="successor-version"` {
+				t.Errorf("Deprecated() Link = %q", got)
+			}
+		})
+	}
+}
@@ -2,9 +2,13 @@ package unittest
 
 import (
 	"context"
+	"crypto/rsa"
+	"database/sql"
 	"errors"
 	"github.com/dwiangraeni/dealls/interfaces"
 	mocks "github.com/dwiangraeni/dealls/interfaces/mocks"
+	"github.com/dwiangraeni/dealls/metrics"
+	"github.com/dwiangraeni/dealls/middleware"
 	"github.com/dwiangraeni/dealls/model"
 	"github.com/dwiangraeni/dealls/resources/request"
 	"github.com/dwiangraeni/dealls/resources/response"
@@ -13,6 +17,7 @@ import (
 	mockUtils "github.com/dwiangraeni/dealls/utils/mocks"
 	"github.com/golang/mock/gomock"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -20,13 +25,24 @@ import (
 func Test_Login(t *testing.T) {
 	defCtx := context.Background()
 	mockCtr := gomock.NewController(t)
+	keySet := middleware.NewStaticKeySet("kid", middleware.SigningKey{KID: "kid", PrivateKey: &rsa.PrivateKey{}})
+	loginPolicy := model.LoginAttemptPolicy{MaxAttempts: 5, Window: time.Minute, BaseLockout: time.Minute, MaxLockout: time.Hour}
 
 	defer mockCtr.Finish()
 
 	type isMockEnable struct {
-		isMockAccountRepo       bool
-		isMockCheckPasswordHash bool
-		isMockGenerateToken     bool
+		isMockGetLoginAttempt       bool
+		isMockAccountRepo           bool
+		isMockCheckPasswordHash     bool
+		isMockGenerateToken         bool
+		isMockIncrementLoginAttempt bool
+		isMockLockLoginAttempt      bool
+		isMockResetLoginAttempt     bool
+	}
+
+	type loginAttemptResp struct {
+		resp model.LoginAttemptBaseModel
+		err  error
 	}
 
 	type findOneAccountByAccountUserNameResp struct {
@@ -50,9 +66,11 @@ func Test_Login(t *testing.T) {
 
 	type mockScenario struct {
 		isMockEnable                        isMockEnable
+		getLoginAttemptResp                 loginAttemptResp
 		findOneAccountByAccountUserNameResp findOneAccountByAccountUserNameResp
 		checkPasswordHashResp               checkPasswordHashResp
 		generateTokenResp                   generateTokenResp
+		incrementLoginAttemptResp           loginAttemptResp
 	}
 
 	tests := []struct {
@@ -73,7 +91,11 @@ func Test_Login(t *testing.T) {
 			},
 			mockScenario: mockScenario{
 				isMockEnable: isMockEnable{
-					isMockAccountRepo: true,
+					isMockGetLoginAttempt: true,
+					isMockAccountRepo:     true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					err: sql.ErrNoRows,
 				},
 				findOneAccountByAccountUserNameResp: findOneAccountByAccountUserNameResp{
 					resp: model.AccountBaseModel{},
@@ -82,7 +104,35 @@ func Test_Login(t *testing.T) {
 			},
 			want:    nil,
 			wantErr: true,
-			msgErr:  errors.New(`invalid login`),
+			msgErr:  utils.ErrInternal,
+		},
+		{
+			name:    "error unknown username",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx:  defCtx,
+				form: request.LoginRequest{},
+			},
+			mockScenario: mockScenario{
+				isMockEnable: isMockEnable{
+					isMockGetLoginAttempt:       true,
+					isMockAccountRepo:           true,
+					isMockIncrementLoginAttempt: true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					err: sql.ErrNoRows,
+				},
+				incrementLoginAttemptResp: loginAttemptResp{
+					resp: model.LoginAttemptBaseModel{FailedCount: 1},
+				},
+				findOneAccountByAccountUserNameResp: findOneAccountByAccountUserNameResp{
+					resp: model.AccountBaseModel{},
+					err:  sql.ErrNoRows,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  utils.ErrInvalidCredentials,
 		},
 		{
 			name:    "error when check Password Hash",
@@ -93,8 +143,16 @@ func Test_Login(t *testing.T) {
 			},
 			mockScenario: mockScenario{
 				isMockEnable: isMockEnable{
-					isMockAccountRepo:       true,
-					isMockCheckPasswordHash: true,
+					isMockGetLoginAttempt:       true,
+					isMockAccountRepo:           true,
+					isMockCheckPasswordHash:     true,
+					isMockIncrementLoginAttempt: true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					err: sql.ErrNoRows,
+				},
+				incrementLoginAttemptResp: loginAttemptResp{
+					resp: model.LoginAttemptBaseModel{FailedCount: 1},
 				},
 				findOneAccountByAccountUserNameResp: findOneAccountByAccountUserNameResp{
 					resp: model.AccountBaseModel{
@@ -108,7 +166,7 @@ func Test_Login(t *testing.T) {
 			},
 			want:    nil,
 			wantErr: true,
-			msgErr:  errors.New(`invalid login`),
+			msgErr:  utils.ErrInvalidCredentials,
 		},
 		{
 			name:    "error when generate token",
@@ -119,9 +177,14 @@ func Test_Login(t *testing.T) {
 			},
 			mockScenario: mockScenario{
 				isMockEnable: isMockEnable{
+					isMockGetLoginAttempt:   true,
 					isMockAccountRepo:       true,
 					isMockCheckPasswordHash: true,
 					isMockGenerateToken:     true,
+					isMockResetLoginAttempt: true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					err: sql.ErrNoRows,
 				},
 				findOneAccountByAccountUserNameResp: findOneAccountByAccountUserNameResp{
 					resp: model.AccountBaseModel{
@@ -141,6 +204,119 @@ func Test_Login(t *testing.T) {
 			wantErr: true,
 			msgErr:  utils.ErrInternal,
 		},
+		{
+			name:    "error when get login attempt",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx:  defCtx,
+				form: request.LoginRequest{},
+			},
+			mockScenario: mockScenario{
+				isMockEnable: isMockEnable{
+					isMockGetLoginAttempt: true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					err: errors.New(`internal error`),
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  utils.ErrInternal,
+		},
+		{
+			name:    "error when login is locked",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx:  defCtx,
+				form: request.LoginRequest{},
+			},
+			mockScenario: mockScenario{
+				isMockEnable: isMockEnable{
+					isMockGetLoginAttempt: true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					resp: model.LoginAttemptBaseModel{
+						FailedCount: 5,
+						LockedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+					},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  &utils.RateLimitedError{RetryAfter: time.Hour},
+		},
+		{
+			name:    "error wrong password reach max attempts",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx:  defCtx,
+				form: request.LoginRequest{},
+			},
+			mockScenario: mockScenario{
+				isMockEnable: isMockEnable{
+					isMockGetLoginAttempt:       true,
+					isMockAccountRepo:           true,
+					isMockCheckPasswordHash:     true,
+					isMockIncrementLoginAttempt: true,
+					isMockLockLoginAttempt:      true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					resp: model.LoginAttemptBaseModel{
+						FailedCount: 4,
+						LockedUntil: sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+					},
+				},
+				findOneAccountByAccountUserNameResp: findOneAccountByAccountUserNameResp{
+					resp: model.AccountBaseModel{
+						Password: "password",
+					},
+				},
+				checkPasswordHashResp: checkPasswordHashResp{
+					resp: false,
+				},
+				incrementLoginAttemptResp: loginAttemptResp{
+					resp: model.LoginAttemptBaseModel{FailedCount: 5},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  utils.ErrInvalidCredentials,
+		},
+		{
+			name:    "error account is suspended",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx: defCtx,
+				form: request.LoginRequest{
+					Username: "username",
+					Password: "password",
+				},
+			},
+			mockScenario: mockScenario{
+				isMockEnable: isMockEnable{
+					isMockGetLoginAttempt:   true,
+					isMockAccountRepo:       true,
+					isMockCheckPasswordHash: true,
+					isMockResetLoginAttempt: true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					err: sql.ErrNoRows,
+				},
+				findOneAccountByAccountUserNameResp: findOneAccountByAccountUserNameResp{
+					resp: model.AccountBaseModel{
+						Password:       "$2a$10$ub3ry5Y.2lpSBKqGdV6XSeLC/K.sedsTAcPJ7GTIty30Put8lrmKq",
+						Status:         model.AccountStatusSuspended,
+						SuspendedUntil: sql.NullTime{Time: time.Now().Add(time.Hour), Valid: true},
+					},
+				},
+				checkPasswordHashResp: checkPasswordHashResp{
+					resp: true,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  utils.ErrAccountSuspended,
+		},
 		{
 			name:    "success login",
 			service: MockNewAuthService(MockAuthService{}),
@@ -153,9 +329,14 @@ func Test_Login(t *testing.T) {
 			},
 			mockScenario: mockScenario{
 				isMockEnable: isMockEnable{
+					isMockGetLoginAttempt:   true,
 					isMockAccountRepo:       true,
 					isMockCheckPasswordHash: true,
 					isMockGenerateToken:     true,
+					isMockResetLoginAttempt: true,
+				},
+				getLoginAttemptResp: loginAttemptResp{
+					err: sql.ErrNoRows,
 				},
 				findOneAccountByAccountUserNameResp: findOneAccountByAccountUserNameResp{
 					resp: model.AccountBaseModel{
@@ -179,8 +360,25 @@ func Test_Login(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAccountRepo := mocks.NewMockIAccountRepo(mockCtr)
+			mockLoginAttemptRepo := mocks.NewMockILoginAttemptRepo(mockCtr)
 			mockPassUtils := mockUtils.NewMockPasswordHasher(mockCtr)
-			s := service.NewAuthService(mockAccountRepo, "publicKey", "privateKey", mockPassUtils)
+			s := service.NewAuthService(mockAccountRepo, mockLoginAttemptRepo, nil, nil, keySet, mockPassUtils, nil, loginPolicy, metrics.NewNoopMetrics())
+
+			if tt.mockScenario.isMockEnable.isMockGetLoginAttempt {
+				mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), gomock.Any()).Return(tt.mockScenario.getLoginAttemptResp.resp, tt.mockScenario.getLoginAttemptResp.err)
+			}
+
+			if tt.mockScenario.isMockEnable.isMockIncrementLoginAttempt {
+				mockLoginAttemptRepo.EXPECT().IncrementLoginAttempt(gomock.Any(), gomock.Any(), loginPolicy.Window).Return(tt.mockScenario.incrementLoginAttemptResp.resp, tt.mockScenario.incrementLoginAttemptResp.err)
+			}
+
+			if tt.mockScenario.isMockEnable.isMockLockLoginAttempt {
+				mockLoginAttemptRepo.EXPECT().LockLoginAttempt(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			if tt.mockScenario.isMockEnable.isMockResetLoginAttempt {
+				mockLoginAttemptRepo.EXPECT().ResetLoginAttempt(gomock.Any(), gomock.Any()).Return(nil)
+			}
 
 			if tt.mockScenario.isMockEnable.isMockAccountRepo {
 				mockAccountRepo.EXPECT().FindOneAccountByAccountUserName(gomock.Any(), gomock.Any()).Return(tt.mockScenario.findOneAccountByAccountUserNameResp.resp, tt.mockScenario.findOneAccountByAccountUserNameResp.err)
@@ -214,6 +412,11 @@ func Test_Register(t *testing.T) {
 	defCtx := context.Background()
 	mockCtr := gomock.NewController(t)
 
+	keySet := middleware.NewStaticKeySet("kid")
+	passwordPolicy, err := utils.NewPasswordPolicy(8, "")
+	if err != nil {
+		t.Fatalf("NewPasswordPolicy() error = %v", err)
+	}
 	dateStr := "2021-08-01T00:00:00Z"
 	date, _ := time.Parse(time.RFC3339, dateStr)
 
@@ -255,12 +458,87 @@ func Test_Register(t *testing.T) {
 		msgErr       error
 	}{
 		{
-			name:    "error when generate password",
+			name:    "error validate empty request",
 			service: MockNewAuthService(MockAuthService{}),
 			args: args{
 				ctx:  defCtx,
 				form: request.RegisterRequest{},
 			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  errors.New("name is required;password is required;username is required"),
+		},
+		{
+			name:    "error validate username charset and name length",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx: defCtx,
+				form: request.RegisterRequest{
+					Name:     strings.Repeat("n", 46),
+					Username: "user name!",
+					Password: "s3cure-Passphrase",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  errors.New("name must be at most 45 characters;username may only contain letters digits dot or underscore"),
+		},
+		{
+			name:    "error password too short",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx: defCtx,
+				form: request.RegisterRequest{
+					Name:     "name",
+					Username: "username",
+					Password: "a",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  errors.New("password: password is too short, minimum 8 characters"),
+		},
+		{
+			name:    "error password in breach list",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx: defCtx,
+				form: request.RegisterRequest{
+					Name:     "name",
+					Username: "username",
+					Password: "Password123",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  errors.New("password: " + utils.ErrPasswordBreached.Error()),
+		},
+		{
+			name:    "error password contains username",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx: defCtx,
+				form: request.RegisterRequest{
+					Name:     "name",
+					Username: "dwiangraeni",
+					Password: "dwiangraeni-2024",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+			msgErr:  errors.New("password: " + utils.ErrPasswordHasUsername.Error()),
+		},
+		{
+			name:    "error when generate password",
+			service: MockNewAuthService(MockAuthService{}),
+			args: args{
+				ctx: defCtx,
+				form: request.RegisterRequest{
+					Name:     "name",
+					Username: "username",
+					Password: "s3cure-Passphrase",
+				},
+			},
 			mockScenario: mockScenario{
 				isMockEnable: isMockEnable{
 					isMockGeneratePass: true,
@@ -278,8 +556,12 @@ func Test_Register(t *testing.T) {
 			name:    "error when insert account",
 			service: MockNewAuthService(MockAuthService{}),
 			args: args{
-				ctx:  defCtx,
-				form: request.RegisterRequest{},
+				ctx: defCtx,
+				form: request.RegisterRequest{
+					Name:     "name",
+					Username: "username",
+					Password: "s3cure-Passphrase",
+				},
 			},
 			mockScenario: mockScenario{
 				isMockEnable: isMockEnable{
@@ -303,8 +585,12 @@ func Test_Register(t *testing.T) {
 			name:    "error duplicate when insert account",
 			service: MockNewAuthService(MockAuthService{}),
 			args: args{
-				ctx:  defCtx,
-				form: request.RegisterRequest{},
+				ctx: defCtx,
+				form: request.RegisterRequest{
+					Name:     "name",
+					Username: "username",
+					Password: "s3cure-Passphrase",
+				},
 			},
 			mockScenario: mockScenario{
 				isMockEnable: isMockEnable{
@@ -332,7 +618,7 @@ func Test_Register(t *testing.T) {
 				form: request.RegisterRequest{
 					Name:     "name",
 					Username: "username",
-					Password: "password",
+					Password: "s3cure-Passphrase",
 				},
 			},
 			mockScenario: mockScenario{
@@ -374,7 +660,7 @@ func Test_Register(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockAccountRepo := mocks.NewMockIAccountRepo(mockCtr)
 			mockPassUtils := mockUtils.NewMockPasswordHasher(mockCtr)
-			s := service.NewAuthService(mockAccountRepo, "publicKey", "privateKey", mockPassUtils)
+			s := service.NewAuthService(mockAccountRepo, nil, nil, nil, keySet, mockPassUtils, passwordPolicy, model.LoginAttemptPolicy{}, metrics.NewNoopMetrics())
 
 			if tt.mockScenario.isMockEnable.isMockGeneratePass {
 				mockPassUtils.EXPECT().GeneratePassword(gomock.Any()).Return(tt.mockScenario.generatePasswordResp.resp, tt.mockScenario.generatePasswordResp.err)
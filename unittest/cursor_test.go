@@ -0,0 +1,97 @@
+package unittest
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dwiangraeni/dealls/utils"
+)
+
+func Test_CursorCodec(t *testing.T) {
+	issuedAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := issuedAt
+	codec := utils.NewCursorCodecWithClock("secret", time.Hour, func() time.Time { return now })
+	token := codec.Encode(42, utils.DirectionNext, "account_report|OPEN")
+	payload, signature, _ := strings.Cut(token, ".")
+
+	tests := []struct {
+		name    string
+		codec   utils.CursorCodec
+		token   string
+		filter  string
+		after   time.Duration
+		want    utils.Cursor
+		wantErr error
+	}{
+		{
+			name:   "success round trip",
+			codec:  codec,
+			token:  token,
+			filter: "account_report|OPEN",
+			want:   utils.Cursor{ID: 42, Direction: utils.DirectionNext},
+		},
+		{
+			name:    "error signed with another secret",
+			codec:   utils.NewCursorCodecWithClock("other", time.Hour, func() time.Time { return now }),
+			token:   token,
+			filter:  "account_report|OPEN",
+			wantErr: utils.ErrCursorSignature,
+		},
+		{
+			name:    "error payload swapped under the signature",
+			codec:   codec,
+			token:   strings.SplitN(codec.Encode(1, utils.DirectionNext, "account_report|OPEN"), ".", 2)[0] + "." + signature,
+			filter:  "account_report|OPEN",
+			wantErr: utils.ErrCursorSignature,
+		},
+		{
+			name:    "error cursor of another filter",
+			codec:   codec,
+			token:   token,
+			filter:  "account_report|RESOLVED",
+			wantErr: utils.ErrCursorStale,
+		},
+		{
+			name:    "error expired cursor",
+			codec:   codec,
+			token:   token,
+			filter:  "account_report|OPEN",
+			after:   time.Hour + time.Second,
+			wantErr: utils.ErrCursorStale,
+		},
+		{
+			name:    "error legacy hashids cursor",
+			codec:   codec,
+			token:   "qDoKxg65k1",
+			filter:  "account_report|OPEN",
+			wantErr: utils.ErrCursorMalformed,
+		},
+		{
+			name:    "error garbage signature",
+			codec:   codec,
+			token:   payload + ".%%%",
+			filter:  "account_report|OPEN",
+			wantErr: utils.ErrCursorMalformed,
+		},
+		{
+			name:    "error empty cursor",
+			codec:   codec,
+			filter:  "account_report|OPEN",
+			wantErr: utils.ErrCursorMalformed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now = issuedAt.Add(tt.after)
+			got, err := tt.codec.Decode(tt.token, tt.filter)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got.ID != tt.want.ID || got.Direction != tt.want.Direction {
+				t.Errorf("Decode() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
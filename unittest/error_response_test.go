@@ -0,0 +1,133 @@
+package unittest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/asaskevich/govalidator"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"github.com/dwiangraeni/dealls/utils"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_HandleAppError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantStatus     int
+		wantCode       string
+		wantMessage    string
+		wantErrors     map[string]string
+		wantRetryAfter string
+	}{
+		{
+			name:        "invalid credentials",
+			err:         utils.ErrInvalidCredentials,
+			wantStatus:  http.StatusUnauthorized,
+			wantCode:    response.CodeInvalidCredentials,
+			wantMessage: utils.ErrInvalidCredentials.Message,
+		},
+		{
+			name:        "wrapped not found",
+			err:         fmt.Errorf("find export: %w", utils.ErrDataNotFound),
+			wantStatus:  http.StatusNotFound,
+			wantCode:    response.CodeNotFound,
+			wantMessage: utils.ErrDataNotFound.Message,
+		},
+		{
+			name:        "details are reported",
+			err:         utils.ErrContactNotSet.WithDetails(map[string]string{"channel": "email"}),
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeContactNotSet,
+			wantMessage: utils.ErrContactNotSet.Message,
+			wantErrors:  map[string]string{"channel": "email"},
+		},
+		{
+			name:        "invalid cursor",
+			err:         utils.ErrInvalidCursor,
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeInvalidCursor,
+			wantMessage: utils.ErrInvalidCursor.Message,
+		},
+		{
+			name:        "validation errors by field",
+			err:         govalidator.Errors{govalidator.Error{Name: "username", Err: errors.New("username is required")}},
+			wantStatus:  http.StatusBadRequest,
+			wantCode:    response.CodeValidationFailed,
+			wantMessage: utils.ErrInvalidParameter.Message,
+			wantErrors:  map[string]string{"username": "username is required"},
+		},
+		{
+			name:           "rate limited with retry after",
+			err:            &utils.RateLimitedError{RetryAfter: 1500 * time.Millisecond},
+			wantStatus:     http.StatusTooManyRequests,
+			wantCode:       response.CodeTooManyRequests,
+			wantMessage:    "too many requests, please try again later (retry after 2s)",
+			wantRetryAfter: "2",
+		},
+		{
+			name:        "internal cause is not leaked",
+			err:         utils.ErrInternal.Wrap(errors.New("pq: connection refused")),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    response.CodeInternal,
+			wantMessage: "error internal",
+		},
+		{
+			name:        "unknown error is internal",
+			err:         errors.New("pq: connection refused"),
+			wantStatus:  http.StatusInternalServerError,
+			wantCode:    response.CodeInternal,
+			wantMessage: "error internal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			response.HandleAppError(rec, tt.err)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("HandleAppError() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("HandleAppError() Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+
+			var body response.ResponseWrapper
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatalf("Decode() error = %v", err)
+			}
+			if body.Success || body.Code != tt.wantCode || body.Message != tt.wantMessage {
+				t.Errorf("HandleAppError() body = %+v, want code %s message %q", body, tt.wantCode, tt.wantMessage)
+			}
+			if len(body.Errors) != len(tt.wantErrors) {
+				t.Errorf("HandleAppError() errors = %v, want %v", body.Errors, tt.wantErrors)
+			}
+			for field, msg := range tt.wantErrors {
+				if body.Errors[field] != msg {
+					t.Errorf("HandleAppError() errors[%s] = %q, want %q", field, body.Errors[field], msg)
+				}
+			}
+		})
+	}
+}
+
+func Test_AppErrorIs(t *testing.T) {
+	err := fmt.Errorf("checkout: %w", utils.ErrPackageAlreadyPurchased.WithDetails(map[string]string{"package": "SWIPE"}))
+	if !errors.Is(err, utils.ErrPackageAlreadyPurchased) {
+		t.Errorf("errors.Is() = false, want a copy with details to match its sentinel")
+	}
+	if errors.Is(err, utils.ErrDuplicateData) {
+		t.Errorf("errors.Is() = true, want errors with another code not to match")
+	}
+
+	cause := errors.New("unexpected EOF")
+	if !errors.Is(utils.ErrInvalidBody.Wrap(cause), cause) {
+		t.Errorf("errors.Is() = false, want the wrapped cause to match")
+	}
+	if !errors.Is(&utils.RateLimitedError{RetryAfter: time.Second}, utils.ErrTooManyRequests) {
+		t.Errorf("errors.Is() = false, want RateLimitedError to match ErrTooManyRequests")
+	}
+}
@@ -0,0 +1,85 @@
+package unittest
+
+import (
+	"context"
+	"errors"
+	mock_interfaces "github.com/dwiangraeni/dealls/interfaces/mocks"
+	"github.com/dwiangraeni/dealls/migration"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+func Test_CheckReadiness(t *testing.T) {
+	defCtx := context.Background()
+	applied := migration.Status{Migration: migration.Migration{Version: 1}, Applied: true}
+
+	tests := []struct {
+		name           string
+		drain          bool
+		pingErr        error
+		statuses       []migration.Status
+		statusErr      error
+		wantReady      bool
+		wantMigrations string
+	}{
+		{
+			name:           "success every migration applied",
+			statuses:       []migration.Status{applied},
+			wantReady:      true,
+			wantMigrations: "ok",
+		},
+		{
+			name:           "error database unreachable",
+			pingErr:        errors.New("connection refused"),
+			wantMigrations: "unknown",
+		},
+		{
+			name:           "error migration pending",
+			statuses:       []migration.Status{applied, {Migration: migration.Migration{Version: 2}}},
+			wantMigrations: "1 pending",
+		},
+		{
+			name:           "error migration modified",
+			statuses:       []migration.Status{{Migration: migration.Migration{Version: 1}, Applied: true, Modified: true}},
+			wantMigrations: "1 modified",
+		},
+		{
+			name:           "error migration status",
+			statusErr:      errors.New("db error"),
+			wantMigrations: "unknown",
+		},
+		{
+			name:           "error shutting down",
+			drain:          true,
+			statuses:       []migration.Status{applied},
+			wantMigrations: "ok",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDB := mock_interfaces.NewMockIDatabasePinger(ctrl)
+			mockMigration := mock_interfaces.NewMockIMigrationStatus(ctrl)
+
+			mockDB.EXPECT().PingContext(gomock.Any()).Return(tt.pingErr)
+			if tt.pingErr == nil {
+				mockMigration.EXPECT().Status(gomock.Any()).Return(tt.statuses, tt.statusErr)
+			}
+
+			s := MockNewHealthService(MockHealthService{db: mockDB, migration: mockMigration})
+			if tt.drain {
+				s.Drain()
+			}
+
+			got := s.CheckReadiness(defCtx)
+			if got.Ready != tt.wantReady {
+				t.Errorf("CheckReadiness() ready = %v, want %v, checks %v", got.Ready, tt.wantReady, got.Checks)
+			}
+			if got.Checks["migrations"] != tt.wantMigrations {
+				t.Errorf("CheckReadiness() migrations = %q, want %q", got.Checks["migrations"], tt.wantMigrations)
+			}
+		})
+	}
+}
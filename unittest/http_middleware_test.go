@@ -0,0 +1,180 @@
+package unittest
+
+import (
+	"encoding/json"
+	"github.com/dwiangraeni/dealls/handler"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func decodeErrorResponse(t *testing.T, rec *httptest.ResponseRecorder) response.ResponseWrapper {
+	t.Helper()
+
+	var body response.ResponseWrapper
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+
+	return body
+}
+
+func Test_Recoverer(t *testing.T) {
+	h := middleware.Recoverer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map")
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dealls/v1/account/list", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("Recoverer() status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if body := decodeErrorResponse(t, rec); body.Code != response.CodeInternal || body.Message != "error internal" {
+		t.Errorf("Recoverer() body = %+v, want %s", body, response.CodeInternal)
+	}
+}
+
+func Test_CORS(t *testing.T) {
+	policy := middleware.CORSPolicy{
+		AllowedOrigins: []string{"https://app.dealls.com"},
+		AllowedMethods: []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders: []string{"Authorization", "Content-Type"},
+		ExposedHeaders: []string{"X-Request-ID"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	tests := []struct {
+		name          string
+		method        string
+		origin        string
+		preflight     bool
+		wantStatus    int
+		wantAllowOrig string
+	}{
+		{name: "success request from allowed origin", method: http.MethodGet, origin: "https://app.dealls.com", wantStatus: http.StatusOK, wantAllowOrig: "https://app.dealls.com"},
+		{name: "success preflight from allowed origin", method: http.MethodOptions, origin: "https://app.dealls.com", preflight: true, wantStatus: http.StatusNoContent, wantAllowOrig: "https://app.dealls.com"},
+		{name: "success request without origin", method: http.MethodGet, wantStatus: http.StatusOK},
+		{name: "success request from other origin served without headers", method: http.MethodGet, origin: "https://evil.example", wantStatus: http.StatusOK},
+		{name: "error preflight from other origin", method: http.MethodOptions, origin: "https://evil.example", preflight: true, wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := middleware.CORS(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, "/dealls/v1/account/list", nil)
+			if tt.origin != "" {
+				req.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("CORS() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Access-Control-Allow-Origin"); got != tt.wantAllowOrig {
+				t.Errorf("CORS() Access-Control-Allow-Origin = %q, want %q", got, tt.wantAllowOrig)
+			}
+			if tt.preflight && tt.wantStatus == http.StatusNoContent {
+				if got := rec.Header().Get("Access-Control-Allow-Methods"); got != "GET, POST" {
+					t.Errorf("CORS() Access-Control-Allow-Methods = %q", got)
+				}
+				if got := rec.Header().Get("Access-Control-Max-Age"); got != "600" {
+					t.Errorf("CORS() Access-Control-Max-Age = %q, want 600", got)
+				}
+			}
+		})
+	}
+}
+
+func Test_LimitBody(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		routeMax  int64
+		wantError bool
+	}{
+		{name: "success body within limit", body: strings.Repeat("a", 8)},
+		{name: "success route raising the limit", body: strings.Repeat("a", 32), routeMax: 64},
+		{name: "error body over limit", body: strings.Repeat("a", 32), wantError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readErr error
+			h := middleware.LimitBody(16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.routeMax > 0 {
+					middleware.SetBodyLimit(w, r, tt.routeMax)
+				}
+				_, readErr = io.ReadAll(r.Body)
+			}))
+
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/dealls/v1/auth/login", strings.NewReader(tt.body)))
+
+			if (readErr != nil) != tt.wantError {
+				t.Errorf("LimitBody() read error = %v, wantError %v", readErr, tt.wantError)
+			}
+		})
+	}
+}
+
+func Test_StrictJSONBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "error unknown field", body: `{"username":"dwi","password":"secret","is_admin":true}`, wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidBody},
+		{name: "error trailing value", body: `{"username":"dwi","password":"secret"}{}`, wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidBody},
+		{name: "error malformed body", body: `{"username":`, wantStatus: http.StatusBadRequest, wantCode: response.CodeInvalidBody},
+		{name: "error body over limit", body: `{"username":"` + strings.Repeat("a", 64) + `"}`, wantStatus: http.StatusRequestEntityTooLarge, wantCode: response.CodeBodyTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the body is rejected before the service is called
+			h := middleware.LimitBody(64)(http.HandlerFunc(handler.NewAuthHandler(nil).HandlerLogin))
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/dealls/v1/auth/login", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("HandlerLogin() status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if body := decodeErrorResponse(t, rec); body.Code != tt.wantCode {
+				t.Errorf("HandlerLogin() code = %s, want %s", body.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func Test_SecurityHeaders(t *testing.T) {
+	h := middleware.SecurityHeaders(365 * 24 * time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	want := map[string]string{
+		"X-Content-Type-Options":    "nosniff",
+		"X-Frame-Options":           "DENY",
+		"Referrer-Policy":           "no-referrer",
+		"Content-Security-Policy":   "default-src 'none'; frame-ancestors 'none'",
+		"Strict-Transport-Security": "max-age=31536000; includeSubDomains",
+	}
+	for header, value := range want {
+		if got := rec.Header().Get(header); got != value {
+			t.Errorf("SecurityHeaders() %s = %q, want %q", header, got, value)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package unittest
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	mock_interfaces "github.com/dwiangraeni/dealls/interfaces/mocks"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+// pngHeader is enough for content sniffing to report image/png
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type identityVerificationMocks struct {
+	accountRepo              *mock_interfaces.MockIAccountRepo
+	premiumPackageRepo       *mock_interfaces.MockIPremiumPackageRepo
+	identityVerificationRepo *mock_interfaces.MockIIdentityVerificationRepo
+	transactionRepo          *mock_interfaces.MockITransactionRepo
+	storage                  *mock_interfaces.MockIFileStorage
+}
+
+func newIdentityVerificationMocks(ctrl *gomock.Controller) identityVerificationMocks {
+	return identityVerificationMocks{
+		accountRepo:              mock_interfaces.NewMockIAccountRepo(ctrl),
+		premiumPackageRepo:       mock_interfaces.NewMockIPremiumPackageRepo(ctrl),
+		identityVerificationRepo: mock_interfaces.NewMockIIdentityVerificationRepo(ctrl),
+		transactionRepo:          mock_interfaces.NewMockITransactionRepo(ctrl),
+		storage:                  mock_interfaces.NewMockIFileStorage(ctrl),
+	}
+}
+
+func (m identityVerificationMocks) service() MockIdentityVerificationService {
+	return MockIdentityVerificationService{
+		accountRepo:              m.accountRepo,
+		premiumPackageRepo:       m.premiumPackageRepo,
+		identityVerificationRepo: m.identityVerificationRepo,
+		transactionRepo:          m.transactionRepo,
+		storage:                  m.storage,
+		policy:                   model.IdentityVerificationPolicy{MaxSelfieSize: 1024},
+	}
+}
+
+func Test_SubmitIdentityVerification(t *testing.T) {
+	defCtx := context.Background()
+	account := model.AccountBaseModel{ID: 1, AccountMaskID: "mask_id", Type: model.AccountTypePremium}
+
+	tests := []struct {
+		name    string
+		selfie  []byte
+		size    int64
+		mock    func(m identityVerificationMocks)
+		wantErr error
+	}{
+		{
+			name:   "success submit selfie",
+			selfie: pngHeader,
+			mock: func(m identityVerificationMocks) {
+				m.accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(account, nil)
+				m.premiumPackageRepo.EXPECT().GetPremiumPackageUserByTitleAndAccountID(gomock.Any(), model.PremiumPackageVerified, int64(1)).
+					Return(model.PremiumPackageUserBaseModel{ID: 1}, nil)
+				m.identityVerificationRepo.EXPECT().GetLatestIdentityVerificationByAccountID(gomock.Any(), int64(1)).
+					Return(model.IdentityVerificationBaseModel{Status: model.IdentityVerificationRejected}, nil)
+				m.storage.EXPECT().Save(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				m.identityVerificationRepo.EXPECT().InsertIdentityVerification(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name:    "error selfie too large",
+			selfie:  pngHeader,
+			size:    2048,
+			mock:    func(m identityVerificationMocks) {},
+			wantErr: utils.ErrSelfieTooLarge,
+		},
+		{
+			name:   "error account already verified",
+			selfie: pngHeader,
+			mock: func(m identityVerificationMocks) {
+				verified := account
+				verified.IsVerified = true
+				m.accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(verified, nil)
+			},
+			wantErr: utils.ErrAlreadyVerified,
+		},
+		{
+			name:   "error verified package not purchased",
+			selfie: pngHeader,
+			mock: func(m identityVerificationMocks) {
+				m.accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(account, nil)
+				m.premiumPackageRepo.EXPECT().GetPremiumPackageUserByTitleAndAccountID(gomock.Any(), model.PremiumPackageVerified, int64(1)).
+					Return(model.PremiumPackageUserBaseModel{}, sql.ErrNoRows)
+			},
+			wantErr: utils.ErrVerificationNotPurchased,
+		},
+		{
+			name:   "error submission already pending",
+			selfie: pngHeader,
+			mock: func(m identityVerificationMocks) {
+				m.accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(account, nil)
+				m.premiumPackageRepo.EXPECT().GetPremiumPackageUserByTitleAndAccountID(gomock.Any(), model.PremiumPackageVerified, int64(1)).
+					Return(model.PremiumPackageUserBaseModel{ID: 1}, nil)
+				m.identityVerificationRepo.EXPECT().GetLatestIdentityVerificationByAccountID(gomock.Any(), int64(1)).
+					Return(model.IdentityVerificationBaseModel{Status: model.IdentityVerificationPending}, nil)
+			},
+			wantErr: utils.ErrVerificationPending,
+		},
+		{
+			name:   "error selfie is not an image",
+			selfie: []byte("definitely not an image"),
+			mock: func(m identityVerificationMocks) {
+				m.accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(account, nil)
+				m.premiumPackageRepo.EXPECT().GetPremiumPackageUserByTitleAndAccountID(gomock.Any(), model.PremiumPackageVerified, int64(1)).
+					Return(model.PremiumPackageUserBaseModel{ID: 1}, nil)
+				m.identityVerificationRepo.EXPECT().GetLatestIdentityVerificationByAccountID(gomock.Any(), int64(1)).
+					Return(model.IdentityVerificationBaseModel{}, sql.ErrNoRows)
+			},
+			wantErr: utils.ErrInvalidSelfie,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newIdentityVerificationMocks(ctrl)
+			tt.mock(m)
+
+			size := tt.size
+			if size == 0 {
+				size = int64(len(tt.selfie))
+			}
+
+			got, err := MockNewIdentityVerificationService(m.service()).SubmitIdentityVerification(defCtx, model.SubmitIdentityVerificationRequest{
+				AccountMaskID: "mask_id",
+				Size:          size,
+				Selfie:        bytes.NewReader(tt.selfie),
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SubmitIdentityVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Status != model.IdentityVerificationPending {
+				t.Errorf("SubmitIdentityVerification() status = %v, want %v", got.Status, model.IdentityVerificationPending)
+			}
+		})
+	}
+}
+
+func Test_ReviewIdentityVerification(t *testing.T) {
+	defCtx := context.Background()
+	trx := &sql.Tx{}
+	pending := model.IdentityVerificationBaseModel{
+		ID:            1,
+		AccountID:     1,
+		Status:        model.IdentityVerificationPending,
+		AccountMaskID: "mask_id",
+	}
+
+	tests := []struct {
+		name    string
+		req     model.ReviewIdentityVerificationRequest
+		mock    func(m identityVerificationMocks)
+		want    string
+		wantErr error
+	}{
+		{
+			name: "success approve grants the verified badge",
+			req:  model.ReviewIdentityVerificationRequest{VerificationUID: "uid", ReviewedBy: "admin", Approve: true},
+			mock: func(m identityVerificationMocks) {
+				m.identityVerificationRepo.EXPECT().GetIdentityVerificationByUID(gomock.Any(), "uid").Return(pending, nil)
+				m.transactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+				m.identityVerificationRepo.EXPECT().ReviewIdentityVerification(gomock.Any(), trx, gomock.Any()).Return(nil)
+				m.accountRepo.EXPECT().UpdateAccountVerified(gomock.Any(), trx,
+					model.AccountBaseModel{ID: 1, UpdatedBy: sql.NullString{String: "admin", Valid: true}}).
+					Return(model.AccountBaseModel{ID: 1, IsVerified: true}, nil)
+				m.transactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+			},
+			want: model.IdentityVerificationApproved,
+		},
+		{
+			name: "success reject keeps the account unverified",
+			req:  model.ReviewIdentityVerificationRequest{VerificationUID: "uid", ReviewedBy: "admin", Reason: "face is not visible"},
+			mock: func(m identityVerificationMocks) {
+				m.identityVerificationRepo.EXPECT().GetIdentityVerificationByUID(gomock.Any(), "uid").Return(pending, nil)
+				m.transactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+				m.identityVerificationRepo.EXPECT().ReviewIdentityVerification(gomock.Any(), trx, gomock.Any()).Return(nil)
+				m.transactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+			},
+			want: model.IdentityVerificationRejected,
+		},
+		{
+			name:    "error reject without reason",
+			req:     model.ReviewIdentityVerificationRequest{VerificationUID: "uid", ReviewedBy: "admin"},
+			mock:    func(m identityVerificationMocks) {},
+			wantErr: utils.ErrRejectReasonRequired,
+		},
+		{
+			name: "error submission already reviewed",
+			req:  model.ReviewIdentityVerificationRequest{VerificationUID: "uid", ReviewedBy: "admin", Approve: true},
+			mock: func(m identityVerificationMocks) {
+				reviewed := pending
+				reviewed.Status = model.IdentityVerificationApproved
+				m.identityVerificationRepo.EXPECT().GetIdentityVerificationByUID(gomock.Any(), "uid").Return(reviewed, nil)
+			},
+			wantErr: utils.ErrVerificationReviewed,
+		},
+		{
+			name: "error submission reviewed concurrently",
+			req:  model.ReviewIdentityVerificationRequest{VerificationUID: "uid", ReviewedBy: "admin", Approve: true},
+			mock: func(m identityVerificationMocks) {
+				m.identityVerificationRepo.EXPECT().GetIdentityVerificationByUID(gomock.Any(), "uid").Return(pending, nil)
+				m.transactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+				m.identityVerificationRepo.EXPECT().ReviewIdentityVerification(gomock.Any(), trx, gomock.Any()).Return(sql.ErrNoRows)
+				m.transactionRepo.EXPECT().RollbackTrx(gomock.Any(), trx)
+			},
+			wantErr: utils.ErrVerificationReviewed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newIdentityVerificationMocks(ctrl)
+			tt.mock(m)
+
+			got, err := MockNewIdentityVerificationService(m.service()).ReviewIdentityVerification(defCtx, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReviewIdentityVerification() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && got.Status != tt.want {
+				t.Errorf("ReviewIdentityVerification() status = %v, want %v", got.Status, tt.want)
+			}
+		})
+	}
+}
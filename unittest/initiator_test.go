@@ -1,49 +1,180 @@
 package unittest
 
 import (
+	"time"
+
 	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/metrics"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
 	"github.com/dwiangraeni/dealls/service"
 	"github.com/dwiangraeni/dealls/utils"
 )
 
+// noopMetrics tests only set metrics when they assert on the emitted events
+func noopMetrics(m interfaces.IMetrics) interfaces.IMetrics {
+	if m == nil {
+		return metrics.NewNoopMetrics()
+	}
+	return m
+}
+
+// testCursorCodec signs with a fixed secret and clock so tests can build the cursors a service is expected to return
+func testCursorCodec() utils.CursorCodec {
+	return utils.NewCursorCodecWithClock("test-cursor-secret", time.Hour, func() time.Time {
+		return time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	})
+}
+
+// cursorCodecOrDefault tests only set a codec when they build cursors with another secret or clock
+func cursorCodecOrDefault(c utils.CursorCodec) utils.CursorCodec {
+	if c == nil {
+		return testCursorCodec()
+	}
+	return c
+}
+
 type MockAccountService struct {
-	accountRepo interfaces.IAccountRepo
-	hashCursor  utils.HashInterface
+	accountRepo        interfaces.IAccountRepo
+	accountBlockRepo   interfaces.IAccountBlockRepo
+	accountProfileRepo interfaces.IAccountProfileRepo
+	premiumPackageRepo interfaces.IPremiumPackageRepo
+	effectPolicy       model.AccountEffectPolicy
+	metrics            interfaces.IMetrics
+	cursorCodec        utils.CursorCodec
 }
 
 func MockNewAccountService(ms MockAccountService) interfaces.IAccountService {
-	return service.NewAccountService(ms.accountRepo)
+	return service.NewAccountService(ms.accountRepo, ms.accountBlockRepo, ms.accountProfileRepo, ms.premiumPackageRepo,
+		ms.effectPolicy, noopMetrics(ms.metrics), cursorCodecOrDefault(ms.cursorCodec))
 }
 
 type MockAuthService struct {
-	accountRepo interfaces.IAccountRepo
-	publicKey   string
-	privateKey  string
-	utilsPass   utils.PasswordHasher
+	accountRepo      interfaces.IAccountRepo
+	loginAttemptRepo interfaces.ILoginAttemptRepo
+	transactionRepo  interfaces.ITransactionRepo
+	otpService       interfaces.IOTPService
+	keySet           middleware.KeySet
+	utilsPass        utils.PasswordHasher
+	passwordPolicy   utils.PasswordPolicy
+	loginPolicy      model.LoginAttemptPolicy
+	metrics          interfaces.IMetrics
 }
 
 func MockNewAuthService(ms MockAuthService) interfaces.IAuthService {
-	return service.NewAuthService(ms.accountRepo, ms.publicKey, ms.privateKey, ms.utilsPass)
+	return service.NewAuthService(ms.accountRepo, ms.loginAttemptRepo, ms.transactionRepo, ms.otpService, ms.keySet, ms.utilsPass, ms.passwordPolicy, ms.loginPolicy,
+		noopMetrics(ms.metrics))
 }
 
 type MockPremiumPackageService struct {
 	accountRepo        interfaces.IAccountRepo
 	premiumPackageRepo interfaces.IPremiumPackageRepo
-	hashCursor         utils.HashInterface
+	accountEffectRepo  interfaces.IAccountEffectRepo
+	creditLedgerRepo   interfaces.ICreditLedgerRepo
 	transactionRepo    interfaces.ITransactionRepo
+	effectPolicy       model.AccountEffectPolicy
+	metrics            interfaces.IMetrics
+	cursorCodec        utils.CursorCodec
 }
 
 func MockNewPremiumPackageService(ms MockPremiumPackageService) interfaces.IPremiumPackageService {
-	return service.NewPremiumPackageService(ms.accountRepo, ms.premiumPackageRepo, ms.transactionRepo)
+	return service.NewPremiumPackageService(ms.accountRepo, ms.premiumPackageRepo, ms.accountEffectRepo, ms.creditLedgerRepo,
+		ms.transactionRepo, ms.effectPolicy, noopMetrics(ms.metrics), cursorCodecOrDefault(ms.cursorCodec))
 }
 
 type MockUserSwipeLogService struct {
 	userSwipeLogRepo   interfaces.IUserSwipeLogRepo
 	accountRepo        interfaces.IAccountRepo
 	premiumPackageRepo interfaces.IPremiumPackageRepo
+	accountBlockRepo   interfaces.IAccountBlockRepo
+	creditLedgerRepo   interfaces.ICreditLedgerRepo
+	transactionRepo    interfaces.ITransactionRepo
+	metrics            interfaces.IMetrics
 	maxSwipeADay       int
 }
 
 func MockNewUserSwipeLogService(ms MockUserSwipeLogService) interfaces.IUserSwipeLogService {
-	return service.NewUserSwipeLogService(ms.userSwipeLogRepo, ms.accountRepo, ms.premiumPackageRepo, ms.maxSwipeADay)
+	return service.NewUserSwipeLogService(ms.userSwipeLogRepo, ms.accountRepo, ms.premiumPackageRepo, ms.accountBlockRepo,
+		ms.creditLedgerRepo, ms.transactionRepo, noopMetrics(ms.metrics), ms.maxSwipeADay)
+}
+
+type MockOTPService struct {
+	otpRepo         interfaces.IOTPRepo
+	transactionRepo interfaces.ITransactionRepo
+	notifier        interfaces.INotifier
+	otpGenerator    utils.OTPGenerator
+	policy          model.OTPPolicy
+}
+
+func MockNewOTPService(ms MockOTPService) interfaces.IOTPService {
+	return service.NewOTPService(ms.otpRepo, ms.transactionRepo, ms.notifier, ms.otpGenerator, ms.policy)
+}
+
+type MockIdentityVerificationService struct {
+	accountRepo              interfaces.IAccountRepo
+	premiumPackageRepo       interfaces.IPremiumPackageRepo
+	identityVerificationRepo interfaces.IIdentityVerificationRepo
+	transactionRepo          interfaces.ITransactionRepo
+	storage                  interfaces.IFileStorage
+	policy                   model.IdentityVerificationPolicy
+	cursorCodec              utils.CursorCodec
+}
+
+func MockNewIdentityVerificationService(ms MockIdentityVerificationService) interfaces.IIdentityVerificationService {
+	return service.NewIdentityVerificationService(ms.accountRepo, ms.premiumPackageRepo, ms.identityVerificationRepo,
+		ms.transactionRepo, ms.storage, ms.policy, cursorCodecOrDefault(ms.cursorCodec))
+}
+
+type MockModerationService struct {
+	accountRepo       interfaces.IAccountRepo
+	accountBlockRepo  interfaces.IAccountBlockRepo
+	accountReportRepo interfaces.IAccountReportRepo
+	transactionRepo   interfaces.ITransactionRepo
+	cursorCodec       utils.CursorCodec
+}
+
+func MockNewModerationService(ms MockModerationService) interfaces.IModerationService {
+	return service.NewModerationService(ms.accountRepo, ms.accountBlockRepo, ms.accountReportRepo, ms.transactionRepo,
+		cursorCodecOrDefault(ms.cursorCodec))
+}
+
+type MockAccountLifecycleService struct {
+	accountRepo              interfaces.IAccountRepo
+	otpRepo                  interfaces.IOTPRepo
+	identityVerificationRepo interfaces.IIdentityVerificationRepo
+	accountExportRepo        interfaces.IAccountExportRepo
+	accountProfileRepo       interfaces.IAccountProfileRepo
+	transactionRepo          interfaces.ITransactionRepo
+	selfieStorage            interfaces.IFileStorage
+	exportStorage            interfaces.IFileStorage
+	utilsPass                utils.PasswordHasher
+}
+
+func MockNewAccountLifecycleService(ms MockAccountLifecycleService) interfaces.IAccountLifecycleService {
+	return service.NewAccountLifecycleService(ms.accountRepo, ms.otpRepo, ms.identityVerificationRepo, ms.accountExportRepo,
+		ms.accountProfileRepo, ms.transactionRepo, ms.selfieStorage, ms.exportStorage, ms.utilsPass)
+}
+
+type MockAccountExportService struct {
+	accountRepo        interfaces.IAccountRepo
+	userSwipeLogRepo   interfaces.IUserSwipeLogRepo
+	premiumPackageRepo interfaces.IPremiumPackageRepo
+	accountProfileRepo interfaces.IAccountProfileRepo
+	accountExportRepo  interfaces.IAccountExportRepo
+	storage            interfaces.IFileStorage
+	policy             model.AccountExportPolicy
+}
+
+func MockNewAccountExportService(ms MockAccountExportService) interfaces.IAccountExportService {
+	return service.NewAccountExportService(ms.accountRepo, ms.userSwipeLogRepo, ms.premiumPackageRepo, ms.accountProfileRepo,
+		ms.accountExportRepo, ms.storage, ms.policy)
+}
+
+type MockHealthService struct {
+	db        interfaces.IDatabasePinger
+	migration interfaces.IMigrationStatus
+}
+
+func MockNewHealthService(ms MockHealthService) interfaces.IHealthService {
+	return service.NewHealthService(ms.db, ms.migration)
 }
@@ -0,0 +1,103 @@
+package unittest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/dwiangraeni/dealls/logging"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/request"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_LoggingRedact(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(logging.NewHandler(&buf, logging.Config{Level: "info"}))
+
+	logger.Info("reset password",
+		"req", request.ResetPasswordRequest{Username: "username", Code: "123456", NewPassword: "secret-password"},
+		"account", &model.AccountBaseModel{UserName: "username", Password: "hash"},
+		"password", "plain")
+
+	out := buf.String()
+	for _, leaked := range []string{"123456", "secret-password", "hash", "plain"} {
+		if strings.Contains(out, leaked) {
+			t.Errorf("log line contains %q: %s", leaked, out)
+		}
+	}
+	if !strings.Contains(out, `"username":"username"`) {
+		t.Errorf("log line lost the non sensitive fields: %s", out)
+	}
+}
+
+func Test_LoggingLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(logging.NewHandler(&buf, logging.Config{Level: "warn"}))
+
+	logger.Info("dropped")
+	logger.Warn("kept")
+
+	if strings.Contains(buf.String(), "dropped") || !strings.Contains(buf.String(), "kept") {
+		t.Errorf("level warn wrote %s", buf.String())
+	}
+}
+
+func Test_RequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantSame bool
+	}{
+		{
+			name:     "success keep the caller request id",
+			header:   "abc-123",
+			wantSame: true,
+		},
+		{
+			name: "success generate a missing request id",
+		},
+		{
+			name:   "success replace a request id with control characters",
+			header: "abc\n{\"level\":\"ERROR\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(logging.NewHandler(&buf, logging.Config{Level: "info"}))
+
+			handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				logger.InfoContext(r.Context(), "handled")
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				r.Header.Set(middleware.RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			got := w.Header().Get(middleware.RequestIDHeader)
+			if got == "" || (got == tt.header) != tt.wantSame {
+				t.Fatalf("RequestID() header = %q, header sent %q", got, tt.header)
+			}
+
+			var line map[string]interface{}
+			if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+				t.Fatalf("log line is not json: %v", err)
+			}
+			if line["request_id"] != got {
+				t.Errorf("log request_id = %v, want %v", line["request_id"], got)
+			}
+		})
+	}
+
+	if logging.RequestIDFromContext(context.Background()) != "" {
+		t.Errorf("RequestIDFromContext() of a context without id is not empty")
+	}
+}
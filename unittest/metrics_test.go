@@ -0,0 +1,88 @@
+package unittest
+
+import (
+	"github.com/dwiangraeni/dealls/metrics"
+	"github.com/go-chi/chi"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_HTTPMetricsMiddleware(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	router := chi.NewRouter()
+	router.Use(metrics.NewHTTPMiddleware(reg))
+	router.Route("/dealls", func(r chi.Router) {
+		r.Post("/account/{account_mask_id}/block", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})
+	})
+
+	for _, path := range []string{"/dealls/account/mask_id/block", "/dealls/account/mask_id1/block", "/unknown/path"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+
+	got := map[string]uint64{}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range m.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			got[labels["route"]+" "+labels["status"]] = m.GetHistogram().GetSampleCount()
+		}
+	}
+
+	want := map[string]uint64{
+		"/dealls/account/{account_mask_id}/block 201": 2,
+		"unmatched 404": 1,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("series = %v, want %v", got, want)
+	}
+	for series, count := range want {
+		if got[series] != count {
+			t.Errorf("series %q count = %d, want %d", series, got[series], count)
+		}
+	}
+}
+
+func Test_PrometheusMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := metrics.NewPrometheusMetrics(reg)
+
+	m.SwipeRecorded("LIKE")
+	m.SwipeRecorded("LIKE")
+	m.SwipeRecorded("PASS")
+	m.SwipeQuotaRejected()
+	m.CreditSpent("SUPER_LIKE")
+	m.LoginFailed(metrics.LoginFailedLocked)
+
+	expected := `
+# HELP dealls_swipes_total Swipes recorded by swipe type.
+# TYPE dealls_swipes_total counter
+dealls_swipes_total{type="LIKE"} 2
+dealls_swipes_total{type="PASS"} 1
+# HELP dealls_swipe_quota_rejections_total Swipes rejected because the daily quota of a free account was used up.
+# TYPE dealls_swipe_quota_rejections_total counter
+dealls_swipe_quota_rejections_total 1
+# HELP dealls_credit_spends_total Credits spent by credit type.
+# TYPE dealls_credit_spends_total counter
+dealls_credit_spends_total{credit_type="SUPER_LIKE"} 1
+# HELP dealls_login_failures_total Rejected logins by reason.
+# TYPE dealls_login_failures_total counter
+dealls_login_failures_total{reason="locked"} 1
+`
+	if err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"dealls_swipes_total", "dealls_swipe_quota_rejections_total", "dealls_credit_spends_total", "dealls_login_failures_total"); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,109 @@
+package unittest
+
+import (
+	"github.com/dwiangraeni/dealls/migration"
+	"github.com/dwiangraeni/dealls/schema"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func Test_LoadMigration(t *testing.T) {
+	tests := []struct {
+		name         string
+		fsys         fstest.MapFS
+		wantVersions []int64
+		wantErr      bool
+	}{
+		{
+			name: "success sorted by version",
+			fsys: fstest.MapFS{
+				"0010_later.up.sql":    {Data: []byte("SELECT 10;")},
+				"0002_second.up.sql":   {Data: []byte("SELECT 2;")},
+				"0002_second.down.sql": {Data: []byte("SELECT -2;")},
+				"0001_first.up.sql":    {Data: []byte("SELECT 1;")},
+				"schema.go":            {Data: []byte("package schema")},
+			},
+			wantVersions: []int64{1, 2, 10},
+		},
+		{
+			name: "error down file without up file",
+			fsys: fstest.MapFS{
+				"0001_first.down.sql": {Data: []byte("SELECT -1;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "error same version with two names",
+			fsys: fstest.MapFS{
+				"0001_first.up.sql":   {Data: []byte("SELECT 1;")},
+				"0001_other.down.sql": {Data: []byte("SELECT -1;")},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := migration.Load(tt.fsys)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if len(got) != len(tt.wantVersions) {
+				t.Fatalf("Load() got %d migrations, want %d", len(got), len(tt.wantVersions))
+			}
+			for i, m := range got {
+				if m.Version != tt.wantVersions[i] || m.Checksum == "" {
+					t.Errorf("Load()[%d] = %d %q, want version %d with checksum", i, m.Version, m.Checksum, tt.wantVersions[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_CompareMigration(t *testing.T) {
+	migrations, err := migration.Load(fstest.MapFS{
+		"0001_first.up.sql":  {Data: []byte("SELECT 1;")},
+		"0002_second.up.sql": {Data: []byte("SELECT 2;")},
+		"0003_third.up.sql":  {Data: []byte("SELECT 3;")},
+	})
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	applied := map[int64]string{
+		1: migrations[0].Checksum,
+		2: "edited",
+	}
+	statuses := migration.Compare(migrations, func(version int64) (string, time.Time, bool) {
+		checksum, ok := applied[version]
+		return checksum, time.Now(), ok
+	})
+
+	want := []struct{ applied, modified bool }{{true, false}, {true, true}, {false, false}}
+	for i, s := range statuses {
+		if s.Applied != want[i].applied || s.Modified != want[i].modified {
+			t.Errorf("Compare()[%d] applied/modified = %v/%v, want %v/%v", i, s.Applied, s.Modified, want[i].applied, want[i].modified)
+		}
+	}
+}
+
+func Test_EmbeddedMigration(t *testing.T) {
+	migrations, err := migration.Load(schema.Migrations)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migration")
+	}
+
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Errorf("migration %s has version %d, want %d", m.Name, m.Version, i+1)
+		}
+		if m.Down == "" {
+			t.Errorf("migration %04d_%s has no down file", m.Version, m.Name)
+		}
+	}
+}
@@ -0,0 +1,288 @@
+package unittest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	mock_interfaces "github.com/dwiangraeni/dealls/interfaces/mocks"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/resources/request"
+	"github.com/dwiangraeni/dealls/utils"
+	mockUtils "github.com/dwiangraeni/dealls/utils/mocks"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_BlockAccount(t *testing.T) {
+	defCtx := context.Background()
+
+	tests := []struct {
+		name    string
+		req     model.BlockAccountRequest
+		mock    func(accountRepo *mock_interfaces.MockIAccountRepo, accountBlockRepo *mock_interfaces.MockIAccountBlockRepo)
+		wantErr error
+	}{
+		{
+			name: "success block account",
+			req:  model.BlockAccountRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			mock: func(accountRepo *mock_interfaces.MockIAccountRepo, accountBlockRepo *mock_interfaces.MockIAccountBlockRepo) {
+				accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(model.AccountBaseModel{ID: 1}, nil)
+				accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id1").Return(model.AccountBaseModel{ID: 2}, nil)
+				accountBlockRepo.EXPECT().InsertAccountBlock(gomock.Any(), &model.AccountBlockBaseModel{BlockerID: 1, BlockedID: 2}).Return(nil)
+			},
+		},
+		{
+			name: "error block own account",
+			req:  model.BlockAccountRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id"},
+			mock: func(accountRepo *mock_interfaces.MockIAccountRepo, accountBlockRepo *mock_interfaces.MockIAccountBlockRepo) {
+			},
+			wantErr: utils.ErrCannotTargetSelf,
+		},
+		{
+			name: "error target account not found",
+			req:  model.BlockAccountRequest{AccountMaskID: "mask_id", TargetAccountMaskID: "mask_id1"},
+			mock: func(accountRepo *mock_interfaces.MockIAccountRepo, accountBlockRepo *mock_interfaces.MockIAccountBlockRepo) {
+				accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(model.AccountBaseModel{ID: 1}, nil)
+				accountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id1").Return(model.AccountBaseModel{}, sql.ErrNoRows)
+			},
+			wantErr: utils.ErrDataNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock_interfaces.NewMockIAccountRepo(ctrl)
+			mockAccountBlockRepo := mock_interfaces.NewMockIAccountBlockRepo(ctrl)
+			tt.mock(mockAccountRepo, mockAccountBlockRepo)
+
+			s := MockNewModerationService(MockModerationService{accountRepo: mockAccountRepo, accountBlockRepo: mockAccountBlockRepo})
+			if err := s.BlockAccount(defCtx, tt.req); !errors.Is(err, tt.wantErr) {
+				t.Errorf("BlockAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_ModerateAccountReport(t *testing.T) {
+	defCtx := context.Background()
+	trx := &sql.Tx{}
+	openReport := model.AccountReportBaseModel{ID: 1, ReportUID: "uid", Status: model.ReportStatusOpen, ReportedMaskID: "mask_id1"}
+	held := func(status string) sql.NullString { return sql.NullString{String: status, Valid: true} }
+
+	tests := []struct {
+		name           string
+		req            model.ModerateAccountReportRequest
+		reported       model.AccountBaseModel
+		report         model.AccountReportBaseModel
+		wantStatus     string
+		wantReactivate string // status held for the next login of a deactivated account
+		wantErr        error
+	}{
+		{
+			name:       "success warn active account",
+			req:        model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionWarn},
+			reported:   model.AccountBaseModel{ID: 2, Status: model.AccountStatusActive},
+			report:     openReport,
+			wantStatus: model.AccountStatusWarned,
+		},
+		{
+			name:       "success suspend warned account",
+			req:        model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionSuspend, SuspendHours: 24},
+			reported:   model.AccountBaseModel{ID: 2, Status: model.AccountStatusWarned},
+			report:     openReport,
+			wantStatus: model.AccountStatusSuspended,
+		},
+		{
+			name:     "error suspend banned account",
+			req:      model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionSuspend, SuspendHours: 24},
+			reported: model.AccountBaseModel{ID: 2, Status: model.AccountStatusBanned},
+			report:   openReport,
+			wantErr:  utils.ErrActionNotApplicable,
+		},
+		{
+			name:     "error suspend deleted account",
+			req:      model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionSuspend, SuspendHours: 24},
+			reported: model.AccountBaseModel{ID: 2, Status: model.AccountStatusDeleted},
+			report:   openReport,
+			wantErr:  utils.ErrActionNotApplicable,
+		},
+		{
+			name:     "error ban deleted account",
+			req:      model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionBan},
+			reported: model.AccountBaseModel{ID: 2, Status: model.AccountStatusDeleted},
+			report:   openReport,
+			wantErr:  utils.ErrActionNotApplicable,
+		},
+		{
+			name:           "success suspend deactivated account held until login",
+			req:            model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionSuspend, SuspendHours: 24},
+			reported:       model.AccountBaseModel{ID: 2, Status: model.AccountStatusDeactivated, ReactivateStatus: held(model.AccountStatusWarned)},
+			report:         openReport,
+			wantStatus:     model.AccountStatusDeactivated,
+			wantReactivate: model.AccountStatusSuspended,
+		},
+		{
+			name:           "success warn deactivated account held until login",
+			req:            model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionWarn},
+			reported:       model.AccountBaseModel{ID: 2, Status: model.AccountStatusDeactivated},
+			report:         openReport,
+			wantStatus:     model.AccountStatusDeactivated,
+			wantReactivate: model.AccountStatusWarned,
+		},
+		{
+			name:     "error warn deactivated account already warned",
+			req:      model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionWarn},
+			reported: model.AccountBaseModel{ID: 2, Status: model.AccountStatusDeactivated, ReactivateStatus: held(model.AccountStatusWarned)},
+			report:   openReport,
+			wantErr:  utils.ErrActionNotApplicable,
+		},
+		{
+			name:       "success ban deactivated account",
+			req:        model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionBan},
+			reported:   model.AccountBaseModel{ID: 2, Status: model.AccountStatusDeactivated, ReactivateStatus: held(model.AccountStatusWarned)},
+			report:     openReport,
+			wantStatus: model.AccountStatusBanned,
+		},
+		{
+			name: "error timed suspension does not shorten indefinite suspension",
+			req:  model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionSuspend, SuspendHours: 1},
+			reported: model.AccountBaseModel{ID: 2, Status: model.AccountStatusSuspended,
+				SuspendedUntil: sql.NullTime{}},
+			report:  openReport,
+			wantErr: utils.ErrActionNotApplicable,
+		},
+		{
+			name: "error warn suspended account",
+			req:  model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionWarn},
+			reported: model.AccountBaseModel{ID: 2, Status: model.AccountStatusSuspended,
+				SuspendedUntil: sql.NullTime{Time: time.Now().UTC().Add(time.Hour), Valid: true}},
+			report:  openReport,
+			wantErr: utils.ErrActionNotApplicable,
+		},
+		{
+			name: "success warn account with expired suspension",
+			req:  model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionWarn},
+			reported: model.AccountBaseModel{ID: 2, Status: model.AccountStatusSuspended,
+				SuspendedUntil: sql.NullTime{Time: time.Now().UTC().Add(-time.Hour), Valid: true}},
+			report:     openReport,
+			wantStatus: model.AccountStatusWarned,
+		},
+		{
+			name:       "success ban account",
+			req:        model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionBan},
+			reported:   model.AccountBaseModel{ID: 2, Status: model.AccountStatusActive},
+			report:     openReport,
+			wantStatus: model.AccountStatusBanned,
+		},
+		{
+			name:    "error report already handled",
+			req:     model.ModerateAccountReportRequest{ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionBan},
+			report:  model.AccountReportBaseModel{ID: 1, Status: model.ReportStatusDismissed},
+			wantErr: utils.ErrReportAlreadyClosed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mock_interfaces.NewMockIAccountRepo(ctrl)
+			mockAccountReportRepo := mock_interfaces.NewMockIAccountReportRepo(ctrl)
+			mockTransactionRepo := mock_interfaces.NewMockITransactionRepo(ctrl)
+
+			mockAccountReportRepo.EXPECT().GetAccountReportByUID(gomock.Any(), "uid").Return(tt.report, nil)
+			if tt.report.Status == model.ReportStatusOpen {
+				mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+				mockAccountReportRepo.EXPECT().ResolveAccountReport(gomock.Any(), trx, gomock.Any()).Return(nil)
+				mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id1").Return(tt.reported, nil)
+			}
+
+			// a report is only committed together with the account status it resulted in
+			if tt.wantErr == nil {
+				mockAccountRepo.EXPECT().UpdateAccountStatus(gomock.Any(), trx, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+						if account.Status != tt.wantStatus || account.ReactivateStatus.String != tt.wantReactivate {
+							t.Errorf("UpdateAccountStatus() status = %v/%v, want %v/%v", account.Status, account.ReactivateStatus.String, tt.wantStatus, tt.wantReactivate)
+						}
+						return account, nil
+					})
+				mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+			} else if tt.report.Status == model.ReportStatusOpen {
+				mockTransactionRepo.EXPECT().RollbackTrx(gomock.Any(), trx).Return(nil)
+			}
+
+			s := MockNewModerationService(MockModerationService{
+				accountRepo:       mockAccountRepo,
+				accountReportRepo: mockAccountReportRepo,
+				transactionRepo:   mockTransactionRepo,
+			})
+
+			got, err := s.ModerateAccountReport(defCtx, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ModerateAccountReport() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (got.Status != model.ReportStatusResolved || got.Action != tt.req.Action) {
+				t.Errorf("ModerateAccountReport() got = %v/%v, want %v/%v", got.Status, got.Action, model.ReportStatusResolved, tt.req.Action)
+			}
+		})
+	}
+}
+
+func Test_ModerateDeactivatedAccountThenLogin(t *testing.T) {
+	defCtx := context.Background()
+	trx := &sql.Tx{}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mock_interfaces.NewMockIAccountRepo(ctrl)
+	mockAccountReportRepo := mock_interfaces.NewMockIAccountReportRepo(ctrl)
+	mockLoginAttemptRepo := mock_interfaces.NewMockILoginAttemptRepo(ctrl)
+	mockTransactionRepo := mock_interfaces.NewMockITransactionRepo(ctrl)
+	mockPassUtils := mockUtils.NewMockPasswordHasher(ctrl)
+
+	deactivated := model.AccountBaseModel{ID: 2, AccountMaskID: "mask_id1", UserName: "username", Status: model.AccountStatusDeactivated,
+		ReactivateStatus: sql.NullString{String: model.AccountStatusActive, Valid: true}}
+	var stored model.AccountBaseModel
+
+	mockAccountReportRepo.EXPECT().GetAccountReportByUID(gomock.Any(), "uid").
+		Return(model.AccountReportBaseModel{ID: 1, ReportUID: "uid", Status: model.ReportStatusOpen, ReportedMaskID: "mask_id1"}, nil)
+	mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+	mockAccountReportRepo.EXPECT().ResolveAccountReport(gomock.Any(), trx, gomock.Any()).Return(nil)
+	mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id1").Return(deactivated, nil)
+	mockAccountRepo.EXPECT().UpdateAccountStatus(gomock.Any(), trx, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ *sql.Tx, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+			stored = account
+			return account, nil
+		})
+	mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+
+	moderation := MockNewModerationService(MockModerationService{
+		accountRepo:       mockAccountRepo,
+		accountReportRepo: mockAccountReportRepo,
+		transactionRepo:   mockTransactionRepo,
+	})
+	if _, err := moderation.ModerateAccountReport(defCtx, model.ModerateAccountReportRequest{
+		ReportUID: "uid", ModeratedBy: "admin", Action: model.ModerationActionSuspend, SuspendHours: 24}); err != nil {
+		t.Fatalf("ModerateAccountReport() error = %v", err)
+	}
+
+	// the login must not reactivate the account while the suspension runs
+	mockLoginAttemptRepo.EXPECT().GetLoginAttempt(gomock.Any(), gomock.Any()).Return(model.LoginAttemptBaseModel{}, sql.ErrNoRows)
+	mockAccountRepo.EXPECT().FindOneAccountByAccountUserName(gomock.Any(), "username").Return(stored, nil)
+	mockPassUtils.EXPECT().CheckPasswordHash(gomock.Any(), gomock.Any()).Return(true)
+	mockLoginAttemptRepo.EXPECT().ResetLoginAttempt(gomock.Any(), gomock.Any()).Return(nil)
+
+	auth := MockNewAuthService(MockAuthService{
+		accountRepo:      mockAccountRepo,
+		loginAttemptRepo: mockLoginAttemptRepo,
+		transactionRepo:  mockTransactionRepo,
+		utilsPass:        mockPassUtils,
+	})
+	if _, err := auth.Login(defCtx, request.LoginRequest{Username: "username", Password: "password"}); !errors.Is(err, utils.ErrAccountSuspended) {
+		t.Errorf("Login() error = %v, wantErr %v", err, utils.ErrAccountSuspended)
+	}
+}
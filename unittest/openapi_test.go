@@ -0,0 +1,98 @@
+package unittest
+
+import (
+	"encoding/json"
+	"github.com/dwiangraeni/dealls/api"
+	"github.com/dwiangraeni/dealls/docs"
+	"github.com/dwiangraeni/dealls/interfaces"
+	"github.com/dwiangraeni/dealls/middleware"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/viper"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// routeInfra config only, building the router never touches the database
+type routeInfra struct{}
+
+func (routeInfra) Config() *viper.Viper { return viper.New() }
+func (routeInfra) SQLDB() *sqlx.DB      { return nil }
+func (routeInfra) Close() error         { return nil }
+
+// routeServiceManager nil services, handlers only keep them until a request comes in
+type routeServiceManager struct{}
+
+func (routeServiceManager) AuthService() interfaces.IAuthService       { return nil }
+func (routeServiceManager) AccountService() interfaces.IAccountService { return nil }
+func (routeServiceManager) AccountManager() middleware.AccountToken    { return nil }
+func (routeServiceManager) KeySet() middleware.KeySet                  { return nil }
+func (routeServiceManager) RateLimiter() middleware.RateLimiter {
+	return middleware.NewRateLimiter(nil, model.RateLimit{}, nil)
+}
+func (routeServiceManager) OTPService() interfaces.IOTPService                   { return nil }
+func (routeServiceManager) VerificationService() interfaces.IVerificationService { return nil }
+func (routeServiceManager) IdentityVerificationService() interfaces.IIdentityVerificationService {
+	return nil
+}
+func (routeServiceManager) ModerationService() interfaces.IModerationService             { return nil }
+func (routeServiceManager) AccountLifecycleService() interfaces.IAccountLifecycleService { return nil }
+func (routeServiceManager) AccountExportService() interfaces.IAccountExportService       { return nil }
+func (routeServiceManager) UserSwipeLogService() interfaces.IUserSwipeLogService         { return nil }
+func (routeServiceManager) PremiumPackageService() interfaces.IPremiumPackageService     { return nil }
+func (routeServiceManager) HealthService() interfaces.IHealthService                     { return nil }
+func (routeServiceManager) MetricsRegistry() *prometheus.Registry                        { return prometheus.NewRegistry() }
+func (routeServiceManager) Metrics() interfaces.IMetrics                                 { return nil }
+
+func Test_OpenAPICoversRoutes(t *testing.T) {
+	var spec struct {
+		OpenAPI string                                       `json:"openapi"`
+		Paths   map[string]map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(docs.OpenAPI, &spec); err != nil {
+		t.Fatalf("docs/openapi.json is not valid json: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.") {
+		t.Fatalf("openapi = %q, want an OpenAPI 3 document", spec.OpenAPI)
+	}
+
+	router := api.NewRouter(routeInfra{}, routeServiceManager{}, model.BuildInfo{})
+
+	routes := map[string]bool{}
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if !strings.HasPrefix(route, "/dealls/") {
+			return nil
+		}
+		// routes of a router mounted on "/" are walked as /dealls/*/...
+		route = strings.ReplaceAll(route, "/*/", "/")
+
+		// the unversioned routes are deprecated aliases of v1, documented once under /dealls/v1
+		documented := route
+		if _, ok := spec.Paths[route]; !ok {
+			documented = strings.Replace(route, "/dealls/", "/dealls/v1/", 1)
+		}
+
+		routes[strings.ToLower(method)+" "+route] = true
+		if _, ok := spec.Paths[documented][strings.ToLower(method)]; !ok {
+			t.Errorf("route %s %s is missing from docs/openapi.json", method, route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	for path, operations := range spec.Paths {
+		for method, operation := range operations {
+			if !routes[method+" "+path] {
+				t.Errorf("docs/openapi.json describes %s %s, which is not a route", strings.ToUpper(method), path)
+			}
+			if _, ok := operation["responses"]; !ok {
+				t.Errorf("%s %s has no responses", strings.ToUpper(method), path)
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package unittest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	mock_interfaces "github.com/dwiangraeni/dealls/interfaces/mocks"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/utils"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+func Test_OTPService_VerifyOTP(t *testing.T) {
+	defCtx := context.Background()
+	otpGenerator := utils.NewOTPGenerator("secret")
+	policy := model.OTPPolicy{Length: 6, TTL: 10 * time.Minute, MaxAttempts: 3}
+	pending := model.OTPBaseModel{
+		ID:        1,
+		AccountID: 1,
+		Purpose:   model.OTPPurposeResetPassword,
+		CodeHash:  otpGenerator.Hash(model.OTPPurposeResetPassword, 1, "123456"),
+		ExpiresAt: time.Now().UTC().Add(time.Minute),
+	}
+
+	tests := []struct {
+		name    string
+		code    string
+		mock    func(otpRepo *mock_interfaces.MockIOTPRepo)
+		wantErr error
+	}{
+		{
+			name: "success verify otp",
+			code: "123456",
+			mock: func(otpRepo *mock_interfaces.MockIOTPRepo) {
+				otpRepo.EXPECT().GetLatestOTP(gomock.Any(), int64(1), model.OTPPurposeResetPassword).Return(pending, nil)
+				otpRepo.EXPECT().IncrementOTPAttempt(gomock.Any(), int64(1), policy.MaxAttempts).Return(1, nil)
+			},
+		},
+		{
+			name: "error wrong code increments attempts",
+			code: "654321",
+			mock: func(otpRepo *mock_interfaces.MockIOTPRepo) {
+				otpRepo.EXPECT().GetLatestOTP(gomock.Any(), int64(1), model.OTPPurposeResetPassword).Return(pending, nil)
+				otpRepo.EXPECT().IncrementOTPAttempt(gomock.Any(), int64(1), policy.MaxAttempts).Return(1, nil)
+			},
+			wantErr: utils.ErrInvalidOTP,
+		},
+		{
+			name: "error no pending otp",
+			code: "123456",
+			mock: func(otpRepo *mock_interfaces.MockIOTPRepo) {
+				otpRepo.EXPECT().GetLatestOTP(gomock.Any(), int64(1), model.OTPPurposeResetPassword).Return(model.OTPBaseModel{}, sql.ErrNoRows)
+			},
+			wantErr: utils.ErrInvalidOTP,
+		},
+		{
+			name: "error expired otp",
+			code: "123456",
+			mock: func(otpRepo *mock_interfaces.MockIOTPRepo) {
+				expired := pending
+				expired.ExpiresAt = time.Now().UTC().Add(-time.Second)
+				otpRepo.EXPECT().GetLatestOTP(gomock.Any(), int64(1), model.OTPPurposeResetPassword).Return(expired, nil)
+			},
+			wantErr: utils.ErrInvalidOTP,
+		},
+		{
+			name: "error out of attempts",
+			code: "123456",
+			mock: func(otpRepo *mock_interfaces.MockIOTPRepo) {
+				exhausted := pending
+				exhausted.Attempts = policy.MaxAttempts
+				otpRepo.EXPECT().GetLatestOTP(gomock.Any(), int64(1), model.OTPPurposeResetPassword).Return(exhausted, nil)
+				otpRepo.EXPECT().IncrementOTPAttempt(gomock.Any(), int64(1), policy.MaxAttempts).Return(0, sql.ErrNoRows)
+			},
+			wantErr: utils.ErrInvalidOTP,
+		},
+		{
+			name: "error right code after a concurrent guess used the last attempt",
+			code: "123456",
+			mock: func(otpRepo *mock_interfaces.MockIOTPRepo) {
+				otpRepo.EXPECT().GetLatestOTP(gomock.Any(), int64(1), model.OTPPurposeResetPassword).Return(pending, nil)
+				otpRepo.EXPECT().IncrementOTPAttempt(gomock.Any(), int64(1), policy.MaxAttempts).Return(0, sql.ErrNoRows)
+			},
+			wantErr: utils.ErrInvalidOTP,
+		},
+		{
+			name: "error increment attempt",
+			code: "123456",
+			mock: func(otpRepo *mock_interfaces.MockIOTPRepo) {
+				otpRepo.EXPECT().GetLatestOTP(gomock.Any(), int64(1), model.OTPPurposeResetPassword).Return(pending, nil)
+				otpRepo.EXPECT().IncrementOTPAttempt(gomock.Any(), int64(1), policy.MaxAttempts).Return(0, errors.New("error internal"))
+			},
+			wantErr: utils.ErrInternal,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOTPRepo := mock_interfaces.NewMockIOTPRepo(ctrl)
+			tt.mock(mockOTPRepo)
+
+			s := MockNewOTPService(MockOTPService{otpRepo: mockOTPRepo, otpGenerator: otpGenerator, policy: policy})
+			_, err := s.VerifyOTP(defCtx, 1, model.OTPPurposeResetPassword, tt.code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyOTP() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_OTPService_SendOTP_Cooldown(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOTPRepo := mock_interfaces.NewMockIOTPRepo(ctrl)
+	mockOTPRepo.EXPECT().GetLatestOTP(gomock.Any(), int64(1), model.OTPPurposeVerifyEmail).
+		Return(model.OTPBaseModel{ID: 1, CreatedAt: time.Now().UTC()}, nil)
+
+	s := MockNewOTPService(MockOTPService{
+		otpRepo:      mockOTPRepo,
+		otpGenerator: utils.NewOTPGenerator("secret"),
+		policy:       model.OTPPolicy{Length: 6, TTL: 10 * time.Minute, ResendCooldown: time.Minute},
+	})
+
+	err := s.SendOTP(context.Background(), 1, model.OTPPurposeVerifyEmail, model.ContactChannelEmail, "user@example.com")
+
+	var rateLimited *utils.RateLimitedError
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfter <= 0 {
+		t.Errorf("SendOTP() error = %v, want rate limited", err)
+	}
+}
@@ -7,7 +7,6 @@ import (
 	"github.com/dwiangraeni/dealls/interfaces"
 	mocks "github.com/dwiangraeni/dealls/interfaces/mocks"
 	"github.com/dwiangraeni/dealls/model"
-	"github.com/dwiangraeni/dealls/service"
 	"github.com/dwiangraeni/dealls/utils"
 	"github.com/golang/mock/gomock"
 	"reflect"
@@ -133,7 +132,7 @@ func Test_GetListPremiumPackagePagination(t *testing.T) {
 				req: model.PaginationRequest{
 					Limit:         1,
 					AccountMaskID: "123",
-					Cursor:        "123",
+					Cursor:        testCursorCodec().Encode(4, utils.DirectionNext, "premium_package"),
 				},
 			},
 			mockScenario: mockScenario{
@@ -147,6 +146,21 @@ func Test_GetListPremiumPackagePagination(t *testing.T) {
 			want:    model.ListPackagePagination{},
 			wantErr: false,
 		},
+		{
+			name:    "error tampered cursor",
+			service: MockNewPremiumPackageService(MockPremiumPackageService{}),
+			args: args{
+				ctx: defCtx,
+				req: model.PaginationRequest{
+					Limit:         1,
+					AccountMaskID: "123",
+					Cursor:        "123",
+				},
+			},
+			want:    model.ListPackagePagination{},
+			wantErr: true,
+			msgErr:  utils.ErrInvalidCursor,
+		},
 		{
 			name:    "success with no user premium package",
 			service: MockNewPremiumPackageService(MockPremiumPackageService{}),
@@ -268,7 +282,8 @@ func Test_GetListPremiumPackagePagination(t *testing.T) {
 			mockPremiumPackageRepo := mocks.NewMockIPremiumPackageRepo(mockCtr)
 			mockTransactionRepo := mocks.NewMockITransactionRepo(mockCtr)
 
-			s := service.NewPremiumPackageService(mockAccountRepo, mockPremiumPackageRepo, mockTransactionRepo)
+			s := MockNewPremiumPackageService(MockPremiumPackageService{accountRepo: mockAccountRepo, premiumPackageRepo: mockPremiumPackageRepo,
+				transactionRepo: mockTransactionRepo})
 
 			if tt.mockScenario.isMockEnable.isMockGetListPremiumPackagePagination {
 				mockPremiumPackageRepo.EXPECT().GetListPremiumPackagePagination(gomock.Any(), gomock.Any()).Return(tt.mockScenario.getListPremiumPackagePaginationResp.resp, tt.mockScenario.getListPremiumPackagePaginationResp.err)
@@ -581,7 +596,7 @@ func Test_PremiumPackageCheckout(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			msgErr:  errors.New("package already purchased"),
+			msgErr:  utils.ErrPackageAlreadyPurchased,
 		},
 		{
 			name:    "error update account type",
@@ -728,7 +743,8 @@ func Test_PremiumPackageCheckout(t *testing.T) {
 			mockPremiumPackageRepo := mocks.NewMockIPremiumPackageRepo(mockCtr)
 			mockTransactionRepo := mocks.NewMockITransactionRepo(mockCtr)
 
-			s := service.NewPremiumPackageService(mockAccountRepo, mockPremiumPackageRepo, mockTransactionRepo)
+			s := MockNewPremiumPackageService(MockPremiumPackageService{accountRepo: mockAccountRepo, premiumPackageRepo: mockPremiumPackageRepo,
+				transactionRepo: mockTransactionRepo})
 			if tt.mockScenario.isMockEnable.isMockFindOneAccountByAccountMaskID {
 				mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), gomock.Any()).Return(tt.mockScenario.findOneAccountByAccountMaskIDResp.resp, tt.mockScenario.findOneAccountByAccountMaskIDResp.err)
 			}
@@ -770,3 +786,218 @@ func Test_PremiumPackageCheckout(t *testing.T) {
 		})
 	}
 }
+
+func Test_PremiumPackageCheckout_Effect(t *testing.T) {
+	defCtx := context.Background()
+	trx := &sql.Tx{}
+	policy := model.AccountEffectPolicy{Durations: map[string]time.Duration{model.AccountEffectIncognito: 24 * time.Hour}}
+
+	tests := []struct {
+		name    string
+		effect  error // result of InsertAccountEffect
+		wantErr error
+	}{
+		{name: "success buy incognito again"},
+		{name: "error insert account effect", effect: errors.New("error internal"), wantErr: utils.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+			mockPremiumPackageRepo := mocks.NewMockIPremiumPackageRepo(ctrl)
+			mockAccountEffectRepo := mocks.NewMockIAccountEffectRepo(ctrl)
+			mockTransactionRepo := mocks.NewMockITransactionRepo(ctrl)
+
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").
+				Return(model.AccountBaseModel{ID: 1, Type: model.AccountTypePremium}, nil)
+			mockPremiumPackageRepo.EXPECT().GetPremiumPackageByPackageUID(gomock.Any(), "package_uid").
+				Return(model.PremiumPackageBaseModel{ID: 5, Title: model.PremiumPackageIncognito}, nil)
+			mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+
+			// a consumable is never recorded as a one time purchase, so it can be bought again
+			gomock.InOrder(
+				mockAccountRepo.EXPECT().LockAccount(gomock.Any(), trx, int64(1)).Return(nil),
+				mockAccountEffectRepo.EXPECT().InsertAccountEffect(gomock.Any(), trx,
+					&model.AccountEffectBaseModel{AccountID: 1, PremiumPackageID: sql.NullInt64{Int64: 5, Valid: true}, EffectType: model.AccountEffectIncognito}, 24*time.Hour).
+					Return(tt.effect),
+			)
+			if tt.effect == nil {
+				mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+			} else {
+				mockTransactionRepo.EXPECT().RollbackTrx(gomock.Any(), trx).Return(nil)
+			}
+
+			s := MockNewPremiumPackageService(MockPremiumPackageService{accountRepo: mockAccountRepo, premiumPackageRepo: mockPremiumPackageRepo,
+				accountEffectRepo: mockAccountEffectRepo, transactionRepo: mockTransactionRepo, effectPolicy: policy})
+			err := s.PremiumPackageCheckout(defCtx, model.PremiumPackageCheckoutRequest{AccountMaskID: "mask_id", PackageUID: "package_uid"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PremiumPackageCheckout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_PremiumPackageCheckout_Credit(t *testing.T) {
+	defCtx := context.Background()
+	trx := &sql.Tx{}
+	// BOOST is also an effect, the credits of the package win and the boost is started later with one of them
+	policy := model.AccountEffectPolicy{Durations: map[string]time.Duration{model.AccountEffectBoost: 30 * time.Minute}}
+	boostPackage := model.PremiumPackageBaseModel{ID: 4, Title: model.PremiumPackageBoost,
+		CreditType: sql.NullString{String: model.CreditTypeBoost, Valid: true}, CreditAmount: 3}
+
+	tests := []struct {
+		name    string
+		topUp   error // result of InsertCreditLedger
+		wantErr error
+	}{
+		{name: "success top up boost credits"},
+		{name: "error insert credit ledger", topUp: errors.New("error internal"), wantErr: utils.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+			mockPremiumPackageRepo := mocks.NewMockIPremiumPackageRepo(ctrl)
+			mockCreditLedgerRepo := mocks.NewMockICreditLedgerRepo(ctrl)
+			mockTransactionRepo := mocks.NewMockITransactionRepo(ctrl)
+
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").
+				Return(model.AccountBaseModel{ID: 1, Type: model.AccountTypeFree}, nil)
+			mockPremiumPackageRepo.EXPECT().GetPremiumPackageByPackageUID(gomock.Any(), "package_uid").Return(boostPackage, nil)
+			mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+
+			gomock.InOrder(
+				// a FREE account buying credits stays FREE, UpdateAccountType is not expected
+				mockAccountRepo.EXPECT().LockAccount(gomock.Any(), trx, int64(1)).Return(nil),
+				mockCreditLedgerRepo.EXPECT().InsertCreditLedger(gomock.Any(), trx, &model.CreditLedgerBaseModel{
+					AccountID: 1, CreditType: model.CreditTypeBoost, Amount: 3, Reason: model.CreditReasonPurchase,
+					PremiumPackageID: sql.NullInt64{Int64: 4, Valid: true},
+				}).Return(tt.topUp),
+			)
+			if tt.topUp == nil {
+				mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+			} else {
+				mockTransactionRepo.EXPECT().RollbackTrx(gomock.Any(), trx).Return(nil)
+			}
+
+			s := MockNewPremiumPackageService(MockPremiumPackageService{accountRepo: mockAccountRepo, premiumPackageRepo: mockPremiumPackageRepo,
+				creditLedgerRepo: mockCreditLedgerRepo, transactionRepo: mockTransactionRepo, effectPolicy: policy})
+			err := s.PremiumPackageCheckout(defCtx, model.PremiumPackageCheckoutRequest{AccountMaskID: "mask_id", PackageUID: "package_uid"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PremiumPackageCheckout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_GetWallet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+	mockCreditLedgerRepo := mocks.NewMockICreditLedgerRepo(ctrl)
+	mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(model.AccountBaseModel{ID: 1}, nil)
+	mockCreditLedgerRepo.EXPECT().GetListCreditBalanceByAccountID(gomock.Any(), int64(1)).Return([]model.CreditBalance{
+		{CreditType: model.CreditTypeSuperLike, Balance: 4},
+		{CreditType: model.CreditTypeSwipe, Balance: 20},
+	}, nil)
+
+	s := MockNewPremiumPackageService(MockPremiumPackageService{accountRepo: mockAccountRepo, creditLedgerRepo: mockCreditLedgerRepo})
+	got, err := s.GetWallet(context.Background(), "mask_id")
+	if err != nil {
+		t.Fatalf("GetWallet() error = %v", err)
+	}
+
+	if want := (model.WalletResponse{SuperLike: 4, Swipe: 20}); got != want {
+		t.Errorf("GetWallet() got = %+v, want %+v", got, want)
+	}
+}
+
+func Test_ActivateBoost(t *testing.T) {
+	trx := &sql.Tx{}
+	policy := model.AccountEffectPolicy{Durations: map[string]time.Duration{model.AccountEffectBoost: 30 * time.Minute}}
+
+	tests := []struct {
+		name    string
+		policy  model.AccountEffectPolicy
+		spend   error // result of SpendCredit, nil = not called when the policy has no boost
+		wantErr error
+	}{
+		{name: "success spend a boost credit", policy: policy},
+		{name: "error no boost credit left", policy: policy, spend: sql.ErrNoRows, wantErr: utils.ErrInsufficientCredit},
+		{name: "error spend credit", policy: policy, spend: errors.New("error internal"), wantErr: utils.ErrInternal},
+		{name: "error boost not offered", wantErr: utils.ErrDataNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+			mockAccountEffectRepo := mocks.NewMockIAccountEffectRepo(ctrl)
+			mockCreditLedgerRepo := mocks.NewMockICreditLedgerRepo(ctrl)
+			mockTransactionRepo := mocks.NewMockITransactionRepo(ctrl)
+
+			if _, ok := tt.policy.EffectDuration(model.AccountEffectBoost); ok {
+				mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(model.AccountBaseModel{ID: 1}, nil)
+				mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+				mockCreditLedgerRepo.EXPECT().SpendCredit(gomock.Any(), trx, int64(1), model.CreditTypeBoost, 1).Return(tt.spend)
+			}
+			switch {
+			case tt.spend != nil:
+				mockTransactionRepo.EXPECT().RollbackTrx(gomock.Any(), trx).Return(nil)
+			case tt.wantErr == nil:
+				mockAccountEffectRepo.EXPECT().InsertAccountEffect(gomock.Any(), trx,
+					&model.AccountEffectBaseModel{AccountID: 1, EffectType: model.AccountEffectBoost}, 30*time.Minute).
+					DoAndReturn(func(_ context.Context, _ *sql.Tx, req *model.AccountEffectBaseModel, duration time.Duration) error {
+						req.StartsAt = time.Now().UTC().Add(-time.Second)
+						req.EndsAt = req.StartsAt.Add(duration)
+						return nil
+					})
+				mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+			}
+
+			s := MockNewPremiumPackageService(MockPremiumPackageService{accountRepo: mockAccountRepo, accountEffectRepo: mockAccountEffectRepo,
+				creditLedgerRepo: mockCreditLedgerRepo, transactionRepo: mockTransactionRepo, effectPolicy: tt.policy})
+			got, err := s.ActivateBoost(context.Background(), "mask_id")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ActivateBoost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (got.EffectType != model.AccountEffectBoost || !got.IsActive) {
+				t.Errorf("ActivateBoost() got = %+v, want an active BOOST", got)
+			}
+		})
+	}
+}
+
+func Test_GetListAccountEffect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Now().UTC()
+	running := model.AccountEffectBaseModel{EffectType: model.AccountEffectBoost, StartsAt: now.Add(-10 * time.Minute), EndsAt: now.Add(20 * time.Minute)}
+	queued := model.AccountEffectBaseModel{EffectType: model.AccountEffectBoost, StartsAt: running.EndsAt, EndsAt: running.EndsAt.Add(30 * time.Minute)}
+
+	mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+	mockAccountEffectRepo := mocks.NewMockIAccountEffectRepo(ctrl)
+	mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(model.AccountBaseModel{ID: 1}, nil)
+	mockAccountEffectRepo.EXPECT().GetListAccountEffectByAccountID(gomock.Any(), int64(1)).Return([]model.AccountEffectBaseModel{running, queued}, nil)
+
+	s := MockNewPremiumPackageService(MockPremiumPackageService{accountRepo: mockAccountRepo, accountEffectRepo: mockAccountEffectRepo})
+	got, err := s.GetListAccountEffect(context.Background(), "mask_id")
+	if err != nil {
+		t.Fatalf("GetListAccountEffect() error = %v", err)
+	}
+
+	want := []model.AccountEffectResponse{
+		{EffectType: model.AccountEffectBoost, StartsAt: running.StartsAt, EndsAt: running.EndsAt, IsActive: true},
+		{EffectType: model.AccountEffectBoost, StartsAt: queued.StartsAt, EndsAt: queued.EndsAt},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetListAccountEffect() got = %+v, want %+v", got, want)
+	}
+}
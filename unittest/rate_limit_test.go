@@ -0,0 +1,57 @@
+package unittest
+
+import (
+	"context"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/repo"
+	"testing"
+	"time"
+)
+
+func Test_RateLimitMemoryRepo_Allow(t *testing.T) {
+	defCtx := context.Background()
+	rateLimitRepo := repo.NewRateLimitMemoryRepo()
+	limit := model.RateLimit{Rate: 0.5, Burst: 3}
+
+	for i := 0; i < limit.Burst; i++ {
+		got, err := rateLimitRepo.Allow(defCtx, "key", limit)
+		if err != nil || !got.Allowed {
+			t.Fatalf("Allow() call %d got = %v, err %v, want allowed", i, got, err)
+		}
+	}
+
+	got, err := rateLimitRepo.Allow(defCtx, "key", limit)
+	if err != nil || got.Allowed {
+		t.Fatalf("Allow() got = %v, err %v, want rejected", got, err)
+	}
+
+	if got.RetryAfter <= 0 || got.RetryAfter > 2*time.Second {
+		t.Errorf("Allow() retry after = %v, want within 2s", got.RetryAfter)
+	}
+
+	if got, _ = rateLimitRepo.Allow(defCtx, "other_key", limit); !got.Allowed {
+		t.Errorf("Allow() other key got = %v, want allowed", got)
+	}
+}
+
+func Test_LoginAttemptPolicy_LockoutFor(t *testing.T) {
+	policy := model.LoginAttemptPolicy{MaxAttempts: 3, BaseLockout: 30 * time.Second, MaxLockout: 5 * time.Minute}
+
+	tests := []struct {
+		name        string
+		failedCount int
+		want        time.Duration
+	}{
+		{name: "below max attempts", failedCount: 2, want: 0},
+		{name: "first lockout", failedCount: 3, want: 30 * time.Second},
+		{name: "doubled lockout", failedCount: 5, want: 2 * time.Minute},
+		{name: "capped lockout", failedCount: 20, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.LockoutFor(tt.failedCount); got != tt.want {
+				t.Errorf("LockoutFor() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package unittest
+
+import (
+	"context"
+	"database/sql"
+	mock_interfaces "github.com/dwiangraeni/dealls/interfaces/mocks"
+	"github.com/dwiangraeni/dealls/model"
+	"github.com/dwiangraeni/dealls/seed"
+	mockUtils "github.com/dwiangraeni/dealls/utils/mocks"
+	"github.com/golang/mock/gomock"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func Test_NewSeedPlan(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	opts := seed.Options{Accounts: 30, SwipesPerAccount: 10, LikeRatio: 0.5, PremiumRatio: 0.2, HistoryDays: 7, Seed: 42}
+
+	plan := seed.NewPlan(opts, now)
+	if !reflect.DeepEqual(plan, seed.NewPlan(opts, now)) {
+		t.Fatal("NewPlan() with the same seed gave different plans")
+	}
+
+	opts.Seed = 43
+	if reflect.DeepEqual(plan, seed.NewPlan(opts, now)) {
+		t.Error("NewPlan() with another seed gave the same plan")
+	}
+
+	if len(plan.Accounts) != 30 || len(plan.Swipes) != 30*10 {
+		t.Fatalf("NewPlan() got %d accounts and %d swipes, want 30 and 300", len(plan.Accounts), len(plan.Swipes))
+	}
+
+	today := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	pairs := make(map[[2]int]bool)
+	for _, swipe := range plan.Swipes {
+		if swipe.Swiper == swipe.Swipee {
+			t.Errorf("account %d swiped itself", swipe.Swiper)
+		}
+		if pairs[[2]int{swipe.Swiper, swipe.Swipee}] {
+			t.Errorf("account %d swiped %d twice", swipe.Swiper, swipe.Swipee)
+		}
+		pairs[[2]int{swipe.Swiper, swipe.Swipee}] = true
+
+		if !swipe.At.Before(today) || swipe.At.Before(today.AddDate(0, 0, -7)) {
+			t.Errorf("swipe at %v, want within the 7 days before today", swipe.At)
+		}
+	}
+}
+
+func Test_SeederApply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	plan := seed.NewPlan(seed.Options{Accounts: 3, SwipesPerAccount: 2, LikeRatio: 1, Seed: 1}, time.Now().UTC())
+
+	mockAccountRepo := mock_interfaces.NewMockIAccountRepo(ctrl)
+	mockAccountProfileRepo := mock_interfaces.NewMockIAccountProfileRepo(ctrl)
+	mockPremiumPackageRepo := mock_interfaces.NewMockIPremiumPackageRepo(ctrl)
+	mockUserSwipeLogRepo := mock_interfaces.NewMockIUserSwipeLogRepo(ctrl)
+	mockPassUtils := mockUtils.NewMockPasswordHasher(ctrl)
+
+	// the hash is computed once for every account
+	mockPassUtils.EXPECT().GeneratePassword("password").Return("hash", nil).Times(1)
+	mockPremiumPackageRepo.EXPECT().EnsurePremiumPackage(gomock.Any(), gomock.Any()).Return(nil).Times(7)
+
+	// seed_user_0001 exists from an earlier run
+	mockAccountRepo.EXPECT().FindOneAccountByAccountUserName(gomock.Any(), "seed_user_0001").Return(model.AccountBaseModel{ID: 1}, nil)
+	mockAccountRepo.EXPECT().FindOneAccountByAccountUserName(gomock.Any(), gomock.Any()).Return(model.AccountBaseModel{}, sql.ErrNoRows).Times(2)
+	nextID := int64(1)
+	mockAccountRepo.EXPECT().InsertAccount(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, account model.AccountBaseModel) (model.AccountBaseModel, error) {
+			if account.Password != "hash" {
+				t.Errorf("InsertAccount() password = %q, want the hash", account.Password)
+			}
+			nextID++
+			account.ID = nextID
+			return account, nil
+		})
+
+	// only the created accounts get photos and interests
+	mockAccountProfileRepo.EXPECT().InsertAccountPhoto(gomock.Any(), gomock.Any()).Times(2).
+		DoAndReturn(func(_ context.Context, photos []model.AccountPhotoBaseModel) error {
+			if len(photos) == 0 || photos[0].AccountID == 1 || photos[0].Position != 0 {
+				t.Errorf("InsertAccountPhoto() photos = %+v", photos)
+			}
+			return nil
+		})
+	mockAccountProfileRepo.EXPECT().InsertAccountInterest(gomock.Any(), gomock.Not(int64(1)), gomock.Any()).Return(nil).Times(2)
+
+	mockUserSwipeLogRepo.EXPECT().InsertUserSwipeLogHistory(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, swipes []model.UserSwipeLogBaseModel) error {
+			for _, swipe := range swipes {
+				if swipe.SwiperID == 1 {
+					t.Errorf("swipes of the existing account were inserted again")
+				}
+			}
+			return nil
+		})
+
+	s := seed.NewSeeder(mockAccountRepo, mockAccountProfileRepo, mockPremiumPackageRepo, mockUserSwipeLogRepo, nil, mockPassUtils)
+	got, err := s.Apply(context.Background(), plan, "password")
+	if err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	want := seed.Result{AccountsCreated: 2, AccountsSkipped: 1, Packages: 7, Swipes: 4}
+	if got != want {
+		t.Errorf("Apply() got = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,116 @@
+package unittest
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/dwiangraeni/dealls/tracing"
+	"github.com/go-chi/chi"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func setupTestTracer(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	return recorder
+}
+
+func Test_TracingHTTPMiddleware(t *testing.T) {
+	recorder := setupTestTracer(t)
+
+	router := chi.NewRouter()
+	router.Route("/dealls", func(r chi.Router) {
+		r.Use(tracing.NewHTTPMiddleware())
+		r.Post("/account/{account_mask_id}/block", func(w http.ResponseWriter, r *http.Request) {
+			_, span := tracing.Start(r.Context(), "serviceModerationCtx.BlockAccount")
+			span.End()
+			w.WriteHeader(http.StatusInternalServerError)
+		})
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/dealls/account/mask_id/block", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), r)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+
+	service, server := spans[0], spans[1]
+	if server.Name() != "POST /dealls/account/{account_mask_id}/block" {
+		t.Errorf("server span name = %q", server.Name())
+	}
+	if server.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("server span did not continue the traceparent trace, got %s", server.SpanContext().TraceID())
+	}
+	if server.Status().Code != codes.Error {
+		t.Errorf("server span status = %v, want error for a 500", server.Status().Code)
+	}
+	if service.Parent().SpanID() != server.SpanContext().SpanID() {
+		t.Errorf("service span is not a child of the server span")
+	}
+}
+
+func Test_TracingSQLConnector(t *testing.T) {
+	recorder := setupTestTracer(t)
+
+	db := sql.OpenDB(tracing.NewConnector(fakeDriver{}, ""))
+	defer db.Close()
+
+	ctx, parent := tracing.Start(context.Background(), "serviceAccountCtx.GetAccountStatus")
+	if _, err := db.ExecContext(ctx, "UPDATE account SET status = $1", "ACTIVE"); err != nil {
+		t.Fatalf("ExecContext() error = %v", err)
+	}
+	if _, err := db.QueryContext(ctx, "SELECT broken"); err == nil {
+		t.Fatalf("QueryContext() error = nil, want the driver error")
+	}
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("got %d spans, want 3", len(spans))
+	}
+
+	if spans[0].Name() != "db UPDATE" || spans[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("exec span = %q, want db UPDATE child of the service span", spans[0].Name())
+	}
+	if spans[1].Name() != "db SELECT" || spans[1].Status().Code != codes.Error {
+		t.Errorf("query span = %q %v, want failed db SELECT", spans[1].Name(), spans[1].Status().Code)
+	}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, errors.New("not supported") }
+func (fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return nil, errors.New("not supported")
+}
+func (fakeConn) PrepareContext(context.Context, string) (driver.Stmt, error) {
+	return nil, errors.New("not supported")
+}
+func (fakeConn) Ping(context.Context) error { return nil }
+func (fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return nil, io.ErrUnexpectedEOF
+}
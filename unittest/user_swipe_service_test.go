@@ -2,11 +2,11 @@ package unittest
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"github.com/dwiangraeni/dealls/interfaces"
 	mocks "github.com/dwiangraeni/dealls/interfaces/mocks"
 	"github.com/dwiangraeni/dealls/model"
-	"github.com/dwiangraeni/dealls/service"
 	"github.com/dwiangraeni/dealls/utils"
 	"github.com/golang/mock/gomock"
 	"reflect"
@@ -16,6 +16,7 @@ import (
 func Test_ProcessUserSwipe(t *testing.T) {
 	defCtx := context.Background()
 	mockCtr := gomock.NewController(t)
+	trx := &sql.Tx{}
 	req := model.UserSwipeRequest{
 		SwiperAccountMaskID: "mask_id",
 		SwipeType:           "LIKE",
@@ -29,6 +30,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 		isMockFindOneAccountBySwiperAccountMaskID      bool
 		isMockGetPremiumPackageUserByTitleAndAccountID bool
 		isMockFindOneAccountBySwipeeAccountMaskID      bool
+		isMockIsAccountBlocked                         bool
 		isMockGetUserSwipeLogBySwiperIDAndSwpeeID      bool
 		isMockInsertUserSwipeLog                       bool
 	}
@@ -53,6 +55,11 @@ func Test_ProcessUserSwipe(t *testing.T) {
 		err  error
 	}
 
+	type isAccountBlockedResp struct {
+		resp bool
+		err  error
+	}
+
 	type insertUserSwipeLogResp struct {
 		err error
 	}
@@ -68,6 +75,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 		findOneAccountByAccountSwiperMaskIDResp      findOneAccountByAccountMaskIDResp
 		getPremiumPackageUserByTitleAndAccountIDResp getPremiumPackageUserByTitleAndAccountIDResp
 		findOneAccountByAccountSwipeeMaskIDResp      findOneAccountByAccountMaskIDResp
+		isAccountBlockedResp                         isAccountBlockedResp
 		getUserSwipeLogBySwiperIDAndSwpeeIDResp      getUserSwipeLogBySwiperIDAndSwpeeIDResp
 		insertUserSwipeLogResp                       insertUserSwipeLogResp
 	}
@@ -171,7 +179,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 			msgErr:  utils.ErrInternal,
 		},
 		{
-			name:    "error total swipe a day is already reach the limit",
+			name:    "error get account by account mask id (swipee)",
 			service: MockNewUserSwipeLogService(MockUserSwipeLogService{}),
 			args: args{
 				ctx: defCtx,
@@ -182,6 +190,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 					isMockGetSwipeCountByAccountID:                 true,
 					isMockFindOneAccountBySwiperAccountMaskID:      true,
 					isMockGetPremiumPackageUserByTitleAndAccountID: true,
+					isMockFindOneAccountBySwipeeAccountMaskID:      true,
 				},
 				getSwipeCountByAccountIDResp: getSwipeCountByAccountIDResp{
 					resp: model.SwipeCountBaseModel{
@@ -197,14 +206,20 @@ func Test_ProcessUserSwipe(t *testing.T) {
 					},
 				},
 				getPremiumPackageUserByTitleAndAccountIDResp: getPremiumPackageUserByTitleAndAccountIDResp{
-					resp: model.PremiumPackageUserBaseModel{},
+					resp: model.PremiumPackageUserBaseModel{
+						ID: 1,
+					},
+				},
+				findOneAccountByAccountSwipeeMaskIDResp: findOneAccountByAccountMaskIDResp{
+					resp: model.AccountBaseModel{},
+					err:  errors.New("error internal"),
 				},
 			},
 			wantErr: true,
-			msgErr:  errors.New("total swipe a day is already reach the limit, upgrade your account to get more swipe"),
+			msgErr:  utils.ErrInternal,
 		},
 		{
-			name:    "error get account by account mask id (swipee)",
+			name:    "error swipee account is blocked",
 			service: MockNewUserSwipeLogService(MockUserSwipeLogService{}),
 			args: args{
 				ctx: defCtx,
@@ -216,32 +231,27 @@ func Test_ProcessUserSwipe(t *testing.T) {
 					isMockFindOneAccountBySwiperAccountMaskID:      true,
 					isMockGetPremiumPackageUserByTitleAndAccountID: true,
 					isMockFindOneAccountBySwipeeAccountMaskID:      true,
+					isMockIsAccountBlocked:                         true,
 				},
 				getSwipeCountByAccountIDResp: getSwipeCountByAccountIDResp{
-					resp: model.SwipeCountBaseModel{
-						AccountID:      1,
-						TotalSwipeADay: 10,
-						TotalSwipe:     10,
-					},
-					err: nil,
+					resp: model.SwipeCountBaseModel{AccountID: 1},
 				},
 				findOneAccountByAccountSwiperMaskIDResp: findOneAccountByAccountMaskIDResp{
 					resp: model.AccountBaseModel{
 						ID: 1,
 					},
 				},
-				getPremiumPackageUserByTitleAndAccountIDResp: getPremiumPackageUserByTitleAndAccountIDResp{
-					resp: model.PremiumPackageUserBaseModel{
-						ID: 1,
+				findOneAccountByAccountSwipeeMaskIDResp: findOneAccountByAccountMaskIDResp{
+					resp: model.AccountBaseModel{
+						ID: 2,
 					},
 				},
-				findOneAccountByAccountSwipeeMaskIDResp: findOneAccountByAccountMaskIDResp{
-					resp: model.AccountBaseModel{},
-					err:  errors.New("error internal"),
+				isAccountBlockedResp: isAccountBlockedResp{
+					resp: true,
 				},
 			},
 			wantErr: true,
-			msgErr:  utils.ErrInternal,
+			msgErr:  utils.ErrAccountBlocked,
 		},
 		{
 			name:    "error get user swipe log by swiper id and swipee id",
@@ -256,6 +266,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 					isMockFindOneAccountBySwiperAccountMaskID:      true,
 					isMockGetPremiumPackageUserByTitleAndAccountID: true,
 					isMockFindOneAccountBySwipeeAccountMaskID:      true,
+					isMockIsAccountBlocked:                         true,
 					isMockGetUserSwipeLogBySwiperIDAndSwpeeID:      true,
 				},
 				getSwipeCountByAccountIDResp: getSwipeCountByAccountIDResp{
@@ -302,6 +313,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 					isMockFindOneAccountBySwiperAccountMaskID:      true,
 					isMockGetPremiumPackageUserByTitleAndAccountID: true,
 					isMockFindOneAccountBySwipeeAccountMaskID:      true,
+					isMockIsAccountBlocked:                         true,
 					isMockGetUserSwipeLogBySwiperIDAndSwpeeID:      true,
 				},
 				getSwipeCountByAccountIDResp: getSwipeCountByAccountIDResp{
@@ -334,7 +346,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 				},
 			},
 			wantErr: true,
-			msgErr:  errors.New("user already swipe this user"),
+			msgErr:  utils.ErrAlreadySwiped,
 		},
 		{
 			name:    "error insert user swipe log",
@@ -349,6 +361,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 					isMockFindOneAccountBySwiperAccountMaskID:      true,
 					isMockGetPremiumPackageUserByTitleAndAccountID: true,
 					isMockFindOneAccountBySwipeeAccountMaskID:      true,
+					isMockIsAccountBlocked:                         true,
 					isMockGetUserSwipeLogBySwiperIDAndSwpeeID:      true,
 					isMockInsertUserSwipeLog:                       true,
 				},
@@ -398,6 +411,7 @@ func Test_ProcessUserSwipe(t *testing.T) {
 					isMockFindOneAccountBySwiperAccountMaskID:      true,
 					isMockGetPremiumPackageUserByTitleAndAccountID: true,
 					isMockFindOneAccountBySwipeeAccountMaskID:      true,
+					isMockIsAccountBlocked:                         true,
 					isMockGetUserSwipeLogBySwiperIDAndSwpeeID:      true,
 					isMockInsertUserSwipeLog:                       true,
 				},
@@ -439,8 +453,11 @@ func Test_ProcessUserSwipe(t *testing.T) {
 			mockAccountRepo := mocks.NewMockIAccountRepo(mockCtr)
 			mockPremiumPackageRepo := mocks.NewMockIPremiumPackageRepo(mockCtr)
 			mockUserSwipeLogRepo := mocks.NewMockIUserSwipeLogRepo(mockCtr)
+			mockAccountBlockRepo := mocks.NewMockIAccountBlockRepo(mockCtr)
+			mockTransactionRepo := mocks.NewMockITransactionRepo(mockCtr)
 
-			s := service.NewUserSwipeLogService(mockUserSwipeLogRepo, mockAccountRepo, mockPremiumPackageRepo, 10)
+			s := MockNewUserSwipeLogService(MockUserSwipeLogService{userSwipeLogRepo: mockUserSwipeLogRepo, accountRepo: mockAccountRepo,
+				premiumPackageRepo: mockPremiumPackageRepo, accountBlockRepo: mockAccountBlockRepo, transactionRepo: mockTransactionRepo, maxSwipeADay: 10})
 
 			if tt.mockScenario.isMockEnable.isMockGetSwipeCountByAccountID {
 				mockUserSwipeLogRepo.EXPECT().GetSwipeCountByAccountID(gomock.Any(), gomock.Any()).Return(tt.mockScenario.getSwipeCountByAccountIDResp.resp, tt.mockScenario.getSwipeCountByAccountIDResp.err)
@@ -458,12 +475,22 @@ func Test_ProcessUserSwipe(t *testing.T) {
 				mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), gomock.Any()).Return(tt.mockScenario.findOneAccountByAccountSwipeeMaskIDResp.resp, tt.mockScenario.findOneAccountByAccountSwipeeMaskIDResp.err)
 			}
 
+			if tt.mockScenario.isMockEnable.isMockIsAccountBlocked {
+				mockAccountBlockRepo.EXPECT().IsAccountBlocked(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.mockScenario.isAccountBlockedResp.resp, tt.mockScenario.isAccountBlockedResp.err)
+			}
+
 			if tt.mockScenario.isMockEnable.isMockGetUserSwipeLogBySwiperIDAndSwpeeID {
 				mockUserSwipeLogRepo.EXPECT().GetUserSwipeLogBySwiperIDAndSwpeeID(gomock.Any(), gomock.Any(), gomock.Any()).Return(tt.mockScenario.getUserSwipeLogBySwiperIDAndSwpeeIDResp.resp, tt.mockScenario.getUserSwipeLogBySwiperIDAndSwpeeIDResp.err)
 			}
 
 			if tt.mockScenario.isMockEnable.isMockInsertUserSwipeLog {
-				mockUserSwipeLogRepo.EXPECT().InsertUserSwipeLog(gomock.Any(), gomock.Any()).Return(model.UserSwipeLogBaseModel{}, tt.mockScenario.insertUserSwipeLogResp.err)
+				mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+				mockUserSwipeLogRepo.EXPECT().InsertUserSwipeLog(gomock.Any(), trx, gomock.Any()).Return(model.UserSwipeLogBaseModel{}, tt.mockScenario.insertUserSwipeLogResp.err)
+				if tt.mockScenario.insertUserSwipeLogResp.err != nil {
+					mockTransactionRepo.EXPECT().RollbackTrx(gomock.Any(), trx).Return(nil)
+				} else {
+					mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+				}
 			}
 
 			err := s.ProcessUserSwipe(tt.args.ctx, tt.args.req)
@@ -480,3 +507,70 @@ func Test_ProcessUserSwipe(t *testing.T) {
 		})
 	}
 }
+
+func Test_ProcessUserSwipe_Credit(t *testing.T) {
+	trx := &sql.Tx{}
+
+	tests := []struct {
+		name       string
+		swipeType  string
+		swipedADay int
+		spend      string // credit type spent, empty = no credit needed
+		spendErr   error
+		wantStored model.UserSwipeLogBaseModel
+		wantErr    error
+	}{
+		{name: "success swipe within the daily quota", swipeType: model.SwipeTypeLike, swipedADay: 3,
+			wantStored: model.UserSwipeLogBaseModel{SwiperID: 1, SwipeeID: 2, SwipeType: model.SwipeTypeLike}},
+		{name: "success swipe over the quota with a swipe credit", swipeType: "PASS", swipedADay: 10, spend: model.CreditTypeSwipe,
+			wantStored: model.UserSwipeLogBaseModel{SwiperID: 1, SwipeeID: 2, SwipeType: "PASS"}},
+		{name: "error swipe over the quota without a swipe credit", swipeType: model.SwipeTypeLike, swipedADay: 10, spend: model.CreditTypeSwipe,
+			spendErr: sql.ErrNoRows, wantErr: utils.ErrSwipeLimitReached},
+		{name: "success super like over the quota is paid with its own credit", swipeType: model.SwipeTypeSuperLike, swipedADay: 10, spend: model.CreditTypeSuperLike,
+			wantStored: model.UserSwipeLogBaseModel{SwiperID: 1, SwipeeID: 2, SwipeType: model.SwipeTypeLike, SuperLike: true}},
+		{name: "error super like without a credit", swipeType: model.SwipeTypeSuperLike, spend: model.CreditTypeSuperLike,
+			spendErr: sql.ErrNoRows, wantErr: utils.ErrInsufficientCredit},
+		{name: "error spend credit", swipeType: model.SwipeTypeSuperLike, spend: model.CreditTypeSuperLike,
+			spendErr: errors.New("error internal"), wantErr: utils.ErrInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockUserSwipeLogRepo := mocks.NewMockIUserSwipeLogRepo(ctrl)
+			mockAccountRepo := mocks.NewMockIAccountRepo(ctrl)
+			mockPremiumPackageRepo := mocks.NewMockIPremiumPackageRepo(ctrl)
+			mockAccountBlockRepo := mocks.NewMockIAccountBlockRepo(ctrl)
+			mockCreditLedgerRepo := mocks.NewMockICreditLedgerRepo(ctrl)
+			mockTransactionRepo := mocks.NewMockITransactionRepo(ctrl)
+
+			mockUserSwipeLogRepo.EXPECT().GetSwipeCountByAccountID(gomock.Any(), "mask_id").Return(model.SwipeCountBaseModel{TotalSwipeADay: tt.swipedADay}, nil)
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id").Return(model.AccountBaseModel{ID: 1}, nil)
+			mockPremiumPackageRepo.EXPECT().GetPremiumPackageUserByTitleAndAccountID(gomock.Any(), model.PremiumPackageSwipe, int64(1)).
+				Return(model.PremiumPackageUserBaseModel{}, sql.ErrNoRows)
+			mockAccountRepo.EXPECT().FindOneAccountByAccountMaskID(gomock.Any(), "mask_id1").Return(model.AccountBaseModel{ID: 2}, nil)
+			mockAccountBlockRepo.EXPECT().IsAccountBlocked(gomock.Any(), int64(1), int64(2)).Return(false, nil)
+			mockUserSwipeLogRepo.EXPECT().GetUserSwipeLogBySwiperIDAndSwpeeID(gomock.Any(), int64(1), int64(2)).Return(model.UserSwipeLogBaseModel{}, sql.ErrNoRows)
+			mockTransactionRepo.EXPECT().BeginTrx(gomock.Any()).Return(trx, nil)
+
+			if tt.spend != "" {
+				mockCreditLedgerRepo.EXPECT().SpendCredit(gomock.Any(), trx, int64(1), tt.spend, 1).Return(tt.spendErr)
+			}
+			if tt.wantErr != nil {
+				mockTransactionRepo.EXPECT().RollbackTrx(gomock.Any(), trx).Return(nil)
+			} else {
+				mockUserSwipeLogRepo.EXPECT().InsertUserSwipeLog(gomock.Any(), trx, tt.wantStored).Return(tt.wantStored, nil)
+				mockTransactionRepo.EXPECT().CommitTrx(gomock.Any(), trx).Return(nil)
+			}
+
+			s := MockNewUserSwipeLogService(MockUserSwipeLogService{userSwipeLogRepo: mockUserSwipeLogRepo, accountRepo: mockAccountRepo,
+				premiumPackageRepo: mockPremiumPackageRepo, accountBlockRepo: mockAccountBlockRepo, creditLedgerRepo: mockCreditLedgerRepo,
+				transactionRepo: mockTransactionRepo, maxSwipeADay: 10})
+			err := s.ProcessUserSwipe(context.Background(), model.UserSwipeRequest{SwiperAccountMaskID: "mask_id", SwipeeAccountMaskID: "mask_id1", SwipeType: tt.swipeType})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ProcessUserSwipe() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,26 +1,13 @@
 package utils
 
-import "errors"
-
 const (
 	DirectionNext = "next"
 	DirectionPrev = "prev"
 
-	ConstCursorHashSalt = "Curs0rH4shS4lt0rD342024"
-	ConstHashLength     = 10
-
 	DefaultLimit    = 10
 	DefaultMaxLimit = 100
 )
 
-var (
-	ErrBadRequest       = errors.New("bad request")
-	ErrInternal         = errors.New("error internal")
-	ErrInvalidParameter = errors.New("invalid parameters, please check your input")
-	ErrDuplicateData    = errors.New("duplicate data")
-	ErrDataNotFound     = errors.New("data not found")
-)
-
 func GetPaginationCursor(dataCursor []int, isPrevCursor bool) (prevCursor, nextCursor int64) {
 	prevCursor = int64(dataCursor[0])
 	nextCursor = int64(dataCursor[len(dataCursor)-1])
@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"fmt"
+	"github.com/dwiangraeni/dealls/resources/response"
+	"net/http"
+	"time"
+)
+
+// AppError an error with the stable code and http status it is reported with, see response.HandleAppError.
+// Errors match by code, so errors.Is(err, ErrDataNotFound) holds for a copy carrying details or a cause.
+type AppError struct {
+	Code    string
+	Status  int
+	Message string
+	Details map[string]string
+	cause   error
+}
+
+func NewAppError(code string, status int, message string) *AppError {
+	return &AppError{Code: code, Status: status, Message: message}
+}
+
+func (e *AppError) Error() string {
+	return e.Message
+}
+
+func (e *AppError) Unwrap() error {
+	return e.cause
+}
+
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	return ok && t.Code == e.Code
+}
+
+func (e *AppError) ErrorCode() string {
+	return e.Code
+}
+
+func (e *AppError) HTTPStatus() int {
+	return e.Status
+}
+
+func (e *AppError) ErrorDetails() map[string]string {
+	return e.Details
+}
+
+// WithDetails copy of the error reporting details, e.g. the field or channel at fault
+func (e *AppError) WithDetails(details map[string]string) *AppError {
+	cp := *e
+	cp.Details = details
+	return &cp
+}
+
+// Wrap copy of the error keeping cause for errors.Is/As and logs, the cause is never sent to the client
+func (e *AppError) Wrap(cause error) *AppError {
+	cp := *e
+	cp.cause = cause
+	return &cp
+}
+
+var (
+	ErrBadRequest       = NewAppError(response.CodeBadRequest, http.StatusBadRequest, "bad request")
+	ErrInvalidBody      = NewAppError(response.CodeInvalidBody, http.StatusBadRequest, "invalid request body")
+	ErrBodyTooLarge     = NewAppError(response.CodeBodyTooLarge, http.StatusRequestEntityTooLarge, "request body is too large")
+	ErrInvalidParameter = NewAppError(response.CodeValidationFailed, http.StatusBadRequest, "invalid parameters, please check your input")
+	ErrInvalidCursor    = NewAppError(response.CodeInvalidCursor, http.StatusBadRequest, "invalid or expired cursor, load the list again from the first page")
+	ErrUnauthorized     = NewAppError(response.CodeUnauthorized, http.StatusUnauthorized, "unauthorized")
+	ErrForbidden        = NewAppError(response.CodeForbidden, http.StatusForbidden, "forbidden")
+	ErrDataNotFound     = NewAppError(response.CodeNotFound, http.StatusNotFound, "data not found")
+	ErrDuplicateData    = NewAppError(response.CodeDuplicateData, http.StatusConflict, "duplicate data")
+	ErrTooManyRequests  = NewAppError(response.CodeTooManyRequests, http.StatusTooManyRequests, "too many requests, please try again later")
+	ErrInternal         = NewAppError(response.CodeInternal, http.StatusInternalServerError, "error internal")
+
+	// auth
+	ErrInvalidCredentials = NewAppError(response.CodeInvalidCredentials, http.StatusUnauthorized, "invalid username or password")
+	ErrInvalidOTP         = NewAppError(response.CodeInvalidOTP, http.StatusBadRequest, "invalid or expired code")
+
+	// swipe and premium package
+	ErrSwipeLimitReached       = NewAppError(response.CodeSwipeLimitReached, http.StatusForbidden, "daily swipe limit reached, upgrade your account to get more swipes")
+	ErrAlreadySwiped           = NewAppError(response.CodeAlreadySwiped, http.StatusConflict, "you already swiped this account")
+	ErrPackageAlreadyPurchased = NewAppError(response.CodePackageAlreadyPurchased, http.StatusConflict, "package already purchased")
+	ErrInsufficientCredit      = NewAppError(response.CodeInsufficientCredit, http.StatusPaymentRequired, "not enough credits, buy a package to top up")
+
+	// contact verification
+	ErrContactNotSet          = NewAppError(response.CodeContactNotSet, http.StatusBadRequest, "contact is not set")
+	ErrContactAlreadyVerified = NewAppError(response.CodeContactAlreadyVerified, http.StatusConflict, "contact is already verified")
+
+	// identity verification
+	ErrVerificationNotPurchased = NewAppError(response.CodeVerificationNotPurchased, http.StatusForbidden, "purchase the VERIFIED package to submit an identity verification")
+	ErrAlreadyVerified          = NewAppError(response.CodeAlreadyVerified, http.StatusConflict, "account is already verified")
+	ErrVerificationPending      = NewAppError(response.CodeVerificationPending, http.StatusConflict, "identity verification is already waiting for review")
+	ErrVerificationReviewed     = NewAppError(response.CodeVerificationReviewed, http.StatusConflict, "identity verification was already reviewed")
+	ErrRejectReasonRequired     = NewAppError(response.CodeRejectReasonRequired, http.StatusBadRequest, "reason is required to reject an identity verification")
+	ErrInvalidSelfie            = NewAppError(response.CodeInvalidSelfie, http.StatusBadRequest, "selfie must be a jpeg or png image")
+	ErrSelfieTooLarge           = NewAppError(response.CodeSelfieTooLarge, http.StatusRequestEntityTooLarge, "selfie is too large")
+
+	// moderation
+	ErrCannotTargetSelf    = NewAppError(response.CodeCannotTargetSelf, http.StatusBadRequest, "you cannot block or report your own account")
+	ErrAccountBlocked      = NewAppError(response.CodeAccountUnavailable, http.StatusNotFound, "account is not available")
+	ErrReportAlreadyClosed = NewAppError(response.CodeReportAlreadyClosed, http.StatusConflict, "report was already handled")
+	ErrActionNotApplicable = NewAppError(response.CodeActionNotApplicable, http.StatusConflict, "account status already covers this action, dismiss the report or pick another action")
+
+	// account lifecycle
+	ErrAccountSuspended = NewAppError(response.CodeAccountSuspended, http.StatusForbidden, "account is suspended")
+	ErrAccountBanned    = NewAppError(response.CodeAccountBanned, http.StatusForbidden, "account is banned")
+	ErrInvalidPassword  = NewAppError(response.CodeInvalidPassword, http.StatusForbidden, "password is incorrect")
+
+	// account export
+	ErrExportInProgress = NewAppError(response.CodeExportInProgress, http.StatusConflict, "an export is already in progress")
+	ErrExportNotReady   = NewAppError(response.CodeExportNotReady, http.StatusConflict, "export is not ready yet")
+	ErrExportExpired    = NewAppError(response.CodeExportExpired, http.StatusGone, "export has expired, request a new one")
+)
+
+// RateLimitedError rejected because of a lockout or an empty rate limit bucket.
+type RateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitedError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", ErrTooManyRequests.Error(), e.RetryAfter.Round(time.Second))
+}
+
+func (e *RateLimitedError) Is(target error) bool {
+	return target == ErrTooManyRequests
+}
+
+func (e *RateLimitedError) ErrorCode() string {
+	return ErrTooManyRequests.Code
+}
+
+func (e *RateLimitedError) HTTPStatus() int {
+	return ErrTooManyRequests.Status
+}
+
+func (e *RateLimitedError) ErrorDetails() map[string]string {
+	return nil
+}
+
+// RetryDelay reported to the client as Retry-After
+func (e *RateLimitedError) RetryDelay() time.Duration {
+	return e.RetryAfter
+}
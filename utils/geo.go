@@ -0,0 +1,18 @@
+package utils
+
+import "math"
+
+// earthRadiusKM mean radius of the earth
+const earthRadiusKM = 6371.0
+
+// DistanceKM great-circle distance between two coordinates in kilometers (haversine formula)
+func DistanceKM(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := func(deg float64) float64 { return deg * math.Pi / 180 }
+
+	dLat := toRad(lat2 - lat1)
+	dLng := toRad(lng2 - lng1)
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(toRad(lat1))*math.Cos(toRad(lat2))*math.Sin(dLng/2)*math.Sin(dLng/2)
+
+	return 2 * earthRadiusKM * math.Asin(math.Sqrt(math.Min(1, h)))
+}
@@ -65,7 +65,7 @@ func (mr *MockPasswordHasherMockRecorder) GeneratePassword(password interface{})
 }
 
 // GenerateToken mocks base method.
-func (m *MockPasswordHasher) GenerateToken(account model.AccountBaseModel, key string) (string, error) {
+func (m *MockPasswordHasher) GenerateToken(account model.AccountBaseModel, key middleware.SigningKey) (string, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GenerateToken", account, key)
 	ret0, _ := ret[0].(string)
@@ -80,16 +80,16 @@ func (mr *MockPasswordHasherMockRecorder) GenerateToken(account, key interface{}
 }
 
 // VerifyToken mocks base method.
-func (m *MockPasswordHasher) VerifyToken(token, key string) (*middleware.AccessTokenClaim, error) {
+func (m *MockPasswordHasher) VerifyToken(token string, keySet middleware.KeySet) (*middleware.AccessTokenClaim, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "VerifyToken", token, key)
+	ret := m.ctrl.Call(m, "VerifyToken", token, keySet)
 	ret0, _ := ret[0].(*middleware.AccessTokenClaim)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // VerifyToken indicates an expected call of VerifyToken.
-func (mr *MockPasswordHasherMockRecorder) VerifyToken(token, key interface{}) *gomock.Call {
+func (mr *MockPasswordHasherMockRecorder) VerifyToken(token, keySet interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyToken", reflect.TypeOf((*MockPasswordHasher)(nil).VerifyToken), token, key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyToken", reflect.TypeOf((*MockPasswordHasher)(nil).VerifyToken), token, keySet)
 }
@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+type OTPGenerator interface {
+	Generate(length int) (string, error)
+	Hash(purpose string, accountID int64, code string) string
+	Compare(hash, purpose string, accountID int64, code string) bool
+}
+
+type hmacOTPGenerator struct {
+	secret []byte
+}
+
+// NewOTPGenerator numeric one time codes, only the hmac of a code is meant to be stored.
+func NewOTPGenerator(secret string) OTPGenerator {
+	return &hmacOTPGenerator{secret: []byte(secret)}
+}
+
+func (g *hmacOTPGenerator) Generate(length int) (string, error) {
+	if length <= 0 {
+		length = 6
+	}
+
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(length)), nil)
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	code := n.String()
+	return strings.Repeat("0", length-len(code)) + code, nil
+}
+
+// Hash bind the code to its purpose and account so a code can't be replayed elsewhere
+func (g *hmacOTPGenerator) Hash(purpose string, accountID int64, code string) string {
+	mac := hmac.New(sha256.New, g.secret)
+	mac.Write([]byte(purpose + ":" + strconv.FormatInt(accountID, 10) + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (g *hmacOTPGenerator) Compare(hash, purpose string, accountID int64, code string) bool {
+	return hmac.Equal([]byte(hash), []byte(g.Hash(purpose, accountID, code)))
+}
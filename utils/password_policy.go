@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// bcrypt ignores everything after 72 bytes
+const passwordMaxLength = 72
+
+//go:embed wordlist/common_passwords.txt
+var defaultPasswordWordlist []byte
+
+var (
+	ErrPasswordTooShort     = errors.New("password is too short")
+	ErrPasswordTooLong      = fmt.Errorf("password must not be longer than %d bytes", passwordMaxLength)
+	ErrPasswordBreached     = errors.New("password is too common, it appears in known breach lists")
+	ErrPasswordHasUsername  = errors.New("password must not contain the username")
+	ErrPasswordPolicyConfig = errors.New("invalid password policy")
+)
+
+type PasswordPolicy interface {
+	Validate(password, username string) error
+}
+
+type passwordPolicy struct {
+	minLength int
+	breached  map[string]struct{}
+}
+
+// NewPasswordPolicy length and breach list policy, an empty wordlistPath uses the built-in list.
+func NewPasswordPolicy(minLength int, wordlistPath string) (PasswordPolicy, error) {
+	if minLength <= 0 || minLength > passwordMaxLength {
+		return nil, fmt.Errorf("%w: min length %d", ErrPasswordPolicyConfig, minLength)
+	}
+
+	wordlist := defaultPasswordWordlist
+	if wordlistPath != "" {
+		b, err := os.ReadFile(wordlistPath)
+		if err != nil {
+			return nil, err
+		}
+		wordlist = b
+	}
+
+	breached := make(map[string]struct{})
+	scanner := bufio.NewScanner(bytes.NewReader(wordlist))
+	for scanner.Scan() {
+		if word := strings.TrimSpace(scanner.Text()); word != "" {
+			breached[strings.ToLower(word)] = struct{}{}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return &passwordPolicy{
+		minLength: minLength,
+		breached:  breached,
+	}, nil
+}
+
+func (p *passwordPolicy) Validate(password, username string) error {
+	if utf8.RuneCountInString(password) < p.minLength {
+		return fmt.Errorf("%w, minimum %d characters", ErrPasswordTooShort, p.minLength)
+	}
+
+	if len(password) > passwordMaxLength {
+		return ErrPasswordTooLong
+	}
+
+	lower := strings.ToLower(password)
+	if _, ok := p.breached[lower]; ok {
+		return ErrPasswordBreached
+	}
+
+	if username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		return ErrPasswordHasUsername
+	}
+
+	return nil
+}
@@ -5,15 +5,15 @@ import (
 	"github.com/dwiangraeni/dealls/middleware"
 	"github.com/dwiangraeni/dealls/model"
 	"golang.org/x/crypto/bcrypt"
-	"log"
+	"log/slog"
 	"time"
 )
 
 type PasswordHasher interface {
 	CheckPasswordHash(password, hash string) bool
 	GeneratePassword(password string) (string, error)
-	GenerateToken(account model.AccountBaseModel, key string) (string, error)
-	VerifyToken(token string, key string) (*middleware.AccessTokenClaim, error)
+	GenerateToken(account model.AccountBaseModel, key middleware.SigningKey) (string, error)
+	VerifyToken(token string, keySet middleware.KeySet) (*middleware.AccessTokenClaim, error)
 }
 
 type BcryptPasswordHasher struct{}
@@ -24,7 +24,7 @@ func NewBcryptPasswordHasher() PasswordHasher {
 
 func (h *BcryptPasswordHasher) CheckPasswordHash(password, hash string) bool {
 	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
-		log.Println("error when compare hash and password")
+		slog.Debug("password does not match the hash")
 		return false
 	}
 	return true
@@ -33,43 +33,42 @@ func (h *BcryptPasswordHasher) CheckPasswordHash(password, hash string) bool {
 func (h *BcryptPasswordHasher) GeneratePassword(password string) (string, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		log.Println("error when generate password")
+		slog.Error("error when generate password", "error", err)
 		return "", err
 	}
 	return string(hash), nil
 }
 
-func (h *BcryptPasswordHasher) GenerateToken(account model.AccountBaseModel, key string) (string, error) {
+func (h *BcryptPasswordHasher) GenerateToken(account model.AccountBaseModel, key middleware.SigningKey) (string, error) {
 	now := time.Now().UTC()
 	claim := middleware.AccessTokenClaim{
 		AccountMaskID: account.AccountMaskID,
 		Name:          account.Name,
 		Username:      account.UserName,
 		AccountType:   account.Type,
+		Role:          account.Role,
 	}
 
 	claim.IssuedAt = now.Unix()
-	claim.ExpiresAt = now.Add(time.Hour * 24).Unix()
+	claim.ExpiresAt = now.Add(middleware.AccessTokenTTL).Unix()
 
 	newToken := jwt.NewWithClaims(jwt.SigningMethodRS256, claim)
-	newKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(key))
-	if err != nil {
-		return "", err
-	}
+	newToken.Header["kid"] = key.KID
 
-	tokenString, err := newToken.SignedString(newKey)
+	tokenString, err := newToken.SignedString(key.PrivateKey)
 	if err != nil {
-		log.Printf("[utils.GenerateToken] Error when singnedString token with error: %v\n", err)
+		slog.Error("error when sign token", "event", "utils.GenerateToken", "error", err)
 		return "", err
 	}
 	return tokenString, nil
 
 }
 
-func (h *BcryptPasswordHasher) VerifyToken(token string, key string) (*middleware.AccessTokenClaim, error) {
+func (h *BcryptPasswordHasher) VerifyToken(token string, keySet middleware.KeySet) (*middleware.AccessTokenClaim, error) {
 	claim := new(middleware.AccessTokenClaim)
 	tok, err := jwt.ParseWithClaims(token, claim, func(token *jwt.Token) (interface{}, error) {
-		return jwt.ParseRSAPublicKeyFromPEM([]byte(key))
+		kid, _ := token.Header["kid"].(string)
+		return keySet.PublicKey(kid)
 	})
 
 	if err != nil || !tok.Valid {
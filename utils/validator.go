@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"github.com/asaskevich/govalidator"
+	"regexp"
+)
+
+var (
+	usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9._]+$`)
+	phoneRegex    = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`) // E.164
+)
+
+func init() {
+	// custom tags usable in `valid` struct tags
+	govalidator.TagMap["username"] = func(str string) bool {
+		return usernameRegex.MatchString(str)
+	}
+	govalidator.TagMap["phone"] = func(str string) bool {
+		return phoneRegex.MatchString(str)
+	}
+}